@@ -16,6 +16,7 @@ require (
 	github.com/jessevdk/go-flags v1.5.0
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/keptn/go-utils v0.18.1-0.20220829065650-dc8c0968b133
+	github.com/keptn/keptn/internal v0.0.0-00010101000000-000000000000
 	github.com/nats-io/nats-server/v2 v2.8.4
 	github.com/nats-io/nats.go v1.16.0
 	github.com/sirupsen/logrus v1.8.1
@@ -87,6 +88,7 @@ require (
 replace (
 	github.com/emicklei/go-restful/v3 => github.com/emicklei/go-restful/v3 v3.8.0
 	github.com/gobuffalo/packr/v2 => github.com/gobuffalo/packr/v2 v2.3.2
+	github.com/keptn/keptn/internal => ../internal
 	golang.org/x/crypto => golang.org/x/crypto v0.0.0-20220824171710-5757bc0c5503
 	golang.org/x/net => golang.org/x/net v0.0.0-20220822230855-b0a4917ee28c
 	golang.org/x/text => golang.org/x/text v0.3.7
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/restapi/operations/auth"
+	"github.com/keptn/keptn/internal/commitauthor"
+)
+
+// ForwardedUserHeader and ForwardedEmailHeader contain the user authenticated by a proxy in front of the api-gateway
+const ForwardedUserHeader = "X-Forwarded-User"
+const ForwardedEmailHeader = "X-Forwarded-Email"
+
+// GetAuthHandlerFunc returns the handler of the auth subrequests of the api-gateway. If forwardedUserEnabled is set, the user
+// authenticated by the proxy in front of the api-gateway is returned as author of the commits created by the request
+func GetAuthHandlerFunc(forwardedUserEnabled bool) func(auth.AuthParams, *models.Principal) middleware.Responder {
+	return func(params auth.AuthParams, principal *models.Principal) middleware.Responder {
+		if !forwardedUserEnabled || params.HTTPRequest == nil {
+			return auth.NewAuthOK()
+		}
+		name := params.HTTPRequest.Header.Get(ForwardedUserHeader)
+		email := params.HTTPRequest.Header.Get(ForwardedEmailHeader)
+		return middleware.ResponderFunc(func(rw http.ResponseWriter, producer runtime.Producer) {
+			// the api-gateway passes the author headers from the response of the auth subrequest to the resource-service
+			if name != "" {
+				rw.Header().Set(commitauthor.NameHeader, name)
+				rw.Header().Set(commitauthor.EmailHeader, email)
+			}
+			auth.NewAuthOK().WriteResponse(rw, producer)
+		})
+	}
+}
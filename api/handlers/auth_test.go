@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/api/restapi/operations/auth"
+	"github.com/keptn/keptn/internal/commitauthor"
+)
+
+func TestGetAuthHandlerFunc(t *testing.T) {
+	tests := []struct {
+		name                 string
+		forwardedUserEnabled bool
+		headers              map[string]string
+		wantName             string
+		wantEmail            string
+	}{
+		{
+			name:                 "forwarded user",
+			forwardedUserEnabled: true,
+			headers:              map[string]string{ForwardedUserHeader: "Jane Doe", ForwardedEmailHeader: "jane.doe@example.com"},
+			wantName:             "Jane Doe",
+			wantEmail:            "jane.doe@example.com",
+		},
+		{
+			name:                 "forwarded user disabled",
+			forwardedUserEnabled: false,
+			headers:              map[string]string{ForwardedUserHeader: "Jane Doe", ForwardedEmailHeader: "jane.doe@example.com"},
+		},
+		{
+			name:                 "no forwarded user",
+			forwardedUserEnabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/v1/auth", nil)
+			for key, value := range tt.headers {
+				request.Header.Set(key, value)
+			}
+
+			responder := GetAuthHandlerFunc(tt.forwardedUserEnabled)(auth.AuthParams{HTTPRequest: request}, nil)
+
+			recorder := httptest.NewRecorder()
+			responder.WriteResponse(recorder, runtime.JSONProducer())
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Equal(t, tt.wantName, recorder.Header().Get(commitauthor.NameHeader))
+			require.Equal(t, tt.wantEmail, recorder.Header().Get(commitauthor.EmailHeader))
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"strings"
 	"text/template"
+
+	"github.com/keptn/keptn/internal/templatefuncs"
 )
 
 type templateRenderer struct{}
@@ -28,6 +30,7 @@ func (tr *templateRenderer) RenderString(raw string, context any) (string, error
 
 	t, err := template.New("template").
 		Delims("[[", "]]").
+		Funcs(templatefuncs.FuncMap()).
 		Option("missingkey=error").
 		Parse(raw)
 
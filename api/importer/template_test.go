@@ -38,6 +38,18 @@ func TestRenderContent(t *testing.T) {
 			},
 			expectations: expectation{renderedText: "This is awesomely rendered, while this is not {{ .rendered }}"},
 		},
+		{
+			name: "Template with functions",
+			inputs: input{
+				template: `{"name": [[ quote .name ]], "labels": [[ toJson .labels ]], "owner": [[ default "none" .owner | upper | quote ]], "token": "[[ b64enc .name ]]"}`,
+				context: map[string]any{
+					"name":   `my "project"`,
+					"labels": map[string]string{"team": "a&b"},
+					"owner":  "",
+				},
+			},
+			expectations: expectation{renderedText: `{"name": "my \"project\"", "labels": {"team":"a&b"}, "owner": "NONE", "token": "bXkgInByb2plY3Qi"}`},
+		},
 	}
 
 	for _, tt := range tests {
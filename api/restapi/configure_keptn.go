@@ -16,14 +16,12 @@ import (
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
-	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/keptn/keptn/api/handlers"
 	"github.com/keptn/keptn/api/importer"
 	"github.com/keptn/keptn/api/importer/execute"
 	"github.com/keptn/keptn/api/importer/model"
 	custommiddleware "github.com/keptn/keptn/api/middleware"
-	"github.com/keptn/keptn/api/models"
 	"github.com/keptn/keptn/api/restapi/operations"
 	"github.com/keptn/keptn/api/restapi/operations/auth"
 	"github.com/keptn/keptn/api/restapi/operations/event"
@@ -46,6 +44,7 @@ type EnvConfig struct {
 	MaxEventSizeKB            int64   `envconfig:"MAX_EVENT_SIZE_KB" default:"64"`
 	OAuthEnabled              bool    `envconfig:"OAUTH_ENABLED" default:"false"`
 	OAuthPrefix               string  `envconfig:"OAUTH_PREFIX" default:"keptn:"`
+	ForwardedUserEnabled      bool    `envconfig:"FORWARDED_USER_ENABLED" default:"false"`
 }
 
 // MaxEventSizeBytes returns MaxEventSizeKB in bytes
@@ -94,11 +93,7 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 	//
 	// Example:
 	// api.APIAuthorizer = security.Authorized()
-	api.AuthAuthHandler = auth.AuthHandlerFunc(
-		func(params auth.AuthParams, principal *models.Principal) middleware.Responder {
-			return auth.NewAuthOK()
-		},
-	)
+	api.AuthAuthHandler = auth.AuthHandlerFunc(handlers.GetAuthHandlerFunc(env.ForwardedUserEnabled))
 
 	api.EventPostEventHandler = event.PostEventHandlerFunc(handlers.PostEventHandlerFunc(env.EventValidationEnabled))
 	// api.EventGetEventHandler = event.GetEventHandlerFunc(handlers.GetEventHandlerFunc)
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var getResourceHistoryParams resourceCmdParams
+
+var getResourceHistoryCmd = &cobra.Command{
+	Use:   "resource-history --project=PROJECT [--stage=STAGE] [--service=SERVICE] --resource-uri=RESOURCEURI",
+	Short: "Displays the commits that changed a resource",
+	Long: `Displays the commits of the Git repository of a project that changed the given resource, starting with the most recent one.
+
+- *--project* - is mandatory. The history of the resource in the root folder of the default branch is displayed.
+- *--stage* - is optional. The history of the resource in the root folder of the stage branch is displayed.
+- *--service* - is optional. The history of the resource in the service folder of the stage branch is displayed.
+
+The commit IDs can be used to retrieve a former revision of the resource, or to restore it using 'keptn revert resource'.
+`,
+	Example: `keptn get resource-history --project=sockshop --stage=dev --service=carts --resource-uri=helm/values.yaml
+COMMIT ID                                   AUTHOR     DATE                      MESSAGE
+2f7b0c3d1f6c47f5a3c0b0cb2e1b9a6d3c5e7f01    keptn      2022-03-03T08:15:27Z      Updated resource
+7d0e9f5b3e3a4c1c8f2a6b9d5e4c3b2a1f0e9d8c    keptn      2022-03-01T10:02:11Z      Added resource
+`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return getResourceHistoryParams.validate()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		commits, err := getResourceHistory(getResourceHistoryParams)
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			fmt.Println("No commits found")
+			return nil
+		}
+
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 10, 8, 0, '\t', 0)
+		fmt.Fprintln(w, "COMMIT ID\tAUTHOR\tDATE\tMESSAGE")
+		for _, commit := range commits {
+			message := strings.SplitN(commit.Message, "\n", 2)[0]
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", commit.CommitID, commit.Author, commit.Timestamp.Format(time.RFC3339), message)
+		}
+		return w.Flush()
+	},
+}
+
+func getResourceHistory(params resourceCmdParams) ([]resourceCommit, error) {
+	endPoint, apiToken, err := getResourceServiceCreds()
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []resourceCommit{}
+	nextPageKey := ""
+	for {
+		query := url.Values{}
+		if nextPageKey != "" {
+			query.Set("nextPageKey", nextPageKey)
+		}
+		historyURL := buildResourceURL(endPoint, params, "/history")
+		if len(query) > 0 {
+			historyURL += "?" + query.Encode()
+		}
+
+		page := &resourceHistoryResponse{}
+		if err := doResourceServiceRequest(http.MethodGet, historyURL, apiToken, nil, page); err != nil {
+			return nil, fmt.Errorf("could not retrieve history of resource %s: %s", *params.ResourceURI, err.Error())
+		}
+		commits = append(commits, page.Commits...)
+		if page.NextPageKey == "" || page.NextPageKey == "0" {
+			return commits, nil
+		}
+		nextPageKey = page.NextPageKey
+	}
+}
+
+func init() {
+	getCmd.AddCommand(getResourceHistoryCmd)
+	getResourceHistoryParams.Project = getResourceHistoryCmd.Flags().StringP("project", "", "",
+		"The project containing the resource")
+	getResourceHistoryCmd.MarkFlagRequired("project")
+	getResourceHistoryParams.Stage = getResourceHistoryCmd.Flags().StringP("stage", "", "",
+		"The stage containing the resource")
+	getResourceHistoryParams.Service = getResourceHistoryCmd.Flags().StringP("service", "", "",
+		"The service containing the resource")
+	getResourceHistoryParams.ResourceURI = getResourceHistoryCmd.Flags().StringP("resource-uri", "", "",
+		"The URI of the resource, e.g. helm/values.yaml")
+	getResourceHistoryCmd.MarkFlagRequired("resource-uri")
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetResourceHistory(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	tests := []struct {
+		name         string
+		cmdArgs      string
+		responses    map[string]string
+		responseCode int
+		wantPaths    []string
+		wantOutput   []string
+		wantErr      string
+	}{
+		{
+			name:    "service resource",
+			cmdArgs: "--stage=dev --service=carts --resource-uri=helm/values.yaml",
+			responses: map[string]string{
+				"":  `{"nextPageKey": "1", "totalCount": 2, "commits": [{"commitID": "commit-2", "author": "keptn", "message": "Updated resource", "timestamp": "2022-03-03T08:15:27Z"}]}`,
+				"1": `{"nextPageKey": "0", "totalCount": 2, "commits": [{"commitID": "commit-1", "author": "keptn", "message": "Added resource", "timestamp": "2022-03-01T10:02:11Z"}]}`,
+			},
+			responseCode: http.StatusOK,
+			wantPaths: []string{
+				"/resource-service/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fvalues.yaml/history",
+				"/resource-service/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fvalues.yaml/history",
+			},
+			wantOutput: []string{"commit-2", "commit-1", "Added resource"},
+		},
+		{
+			name:    "project resource",
+			cmdArgs: "--resource-uri=shipyard.yaml",
+			responses: map[string]string{
+				"": `{"commits": [{"commitID": "commit-1", "author": "keptn", "message": "Added shipyard", "timestamp": "2022-03-01T10:02:11Z"}]}`,
+			},
+			responseCode: http.StatusOK,
+			wantPaths:    []string{"/resource-service/v1/project/sockshop/resource/shipyard.yaml/history"},
+			wantOutput:   []string{"commit-1", "Added shipyard"},
+		},
+		{
+			name:    "resource not found",
+			cmdArgs: "--stage=dev --resource-uri=unknown.yaml",
+			responses: map[string]string{
+				"": `{"code": 404, "message": "Resource not found"}`,
+			},
+			responseCode: http.StatusNotFound,
+			wantPaths:    []string{"/resource-service/v1/project/sockshop/stage/dev/resource/unknown.yaml/history"},
+			wantErr:      "could not retrieve history of resource unknown.yaml: Resource not found",
+		},
+		{
+			name:    "service without stage",
+			cmdArgs: "--service=carts --resource-uri=helm/values.yaml",
+			wantErr: "Flag 'stage' is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedPaths := []string{}
+			ts := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.Contains(r.RequestURI, "/v1/metadata") {
+						w.Write([]byte(metadataMockResponse))
+						return
+					}
+					receivedPaths = append(receivedPaths, r.URL.EscapedPath())
+					w.Header().Add("Content-Type", "application/json")
+					w.WriteHeader(tt.responseCode)
+					w.Write([]byte(tt.responses[r.URL.Query().Get("nextPageKey")]))
+				}),
+			)
+			defer ts.Close()
+			t.Setenv("MOCK_SERVER", ts.URL)
+
+			// flag values are kept between executions of the command
+			*getResourceHistoryParams.Stage = ""
+			*getResourceHistoryParams.Service = ""
+
+			r := newRedirector()
+			r.redirectStdOut()
+
+			cmd := fmt.Sprintf("get resource-history --project=sockshop %s --mock", tt.cmdArgs)
+			_, err := executeActionCommandC(cmd)
+			out := r.revertStdOut()
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if len(tt.wantPaths) > 0 {
+				assert.Equal(t, tt.wantPaths, receivedPaths)
+			} else {
+				assert.Empty(t, receivedPaths)
+			}
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, out, want)
+			}
+		})
+	}
+}
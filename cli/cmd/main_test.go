@@ -13,6 +13,9 @@ func TestMain(m *testing.M) {
 	internal.APIProvider = func(baseURL string, authToken string, httpClient ...*http.Client) (*apiutils.APISet, error) {
 		return apiutils.New(baseURL, apiutils.WithAuthToken(authToken), apiutils.WithHTTPClient(&http.Client{}))
 	}
+	internal.HTTPClientProvider = func() (*http.Client, error) {
+		return &http.Client{}, nil
+	}
 	code := m.Run()
 	os.Exit(code)
 }
@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote [ service ]",
+	Short: "Promotes the configuration of a Keptn entity to another stage",
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+}
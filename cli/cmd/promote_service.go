@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type promoteServiceCmdParams struct {
+	Project      *string
+	Stage        *string
+	TargetStage  *string
+	ResourceURIs *[]string
+}
+
+type promoteResourcesRequest struct {
+	TargetStage  string   `json:"targetStage"`
+	ResourceURIs []string `json:"resourceURIs,omitempty"`
+}
+
+var promoteServiceParams promoteServiceCmdParams
+
+var promoteServiceCmd = &cobra.Command{
+	Use:   "service SERVICENAME --project=PROJECT --stage=STAGE --target-stage=TARGETSTAGE [--resource-uri=RESOURCEURI]",
+	Short: "Promotes the resources of a service from one stage to another",
+	Long: `Copies the resources of a service, e.g., its helm chart, SLOs and webhook configurations, from the given stage to the target stage and commits them to the Git repository of the project.
+The service has to exist in the target stage.
+
+By default, all resources of the service are promoted, and resources that only exist in the target stage are removed. To promote a subset of them, the *--resource-uri* flag can be set multiple times; in this case, the other resources of the target stage are kept. The metadata of the service in the target stage is always kept.
+If the stages of the project are represented as branches, promoting all resources merges the branch of the stage into the branch of the target stage, taking the resources of the service from the stage and everything else from the target stage. A subset of the resources is cherry-picked instead.
+
+The returned commit ID can be used as Git commit ID of the next sequence triggered in the target stage, e.g., using 'keptn trigger evaluation --git-commit-id'.
+`,
+	Example: `keptn promote service carts --project=sockshop --stage=dev --target-stage=hardening
+
+keptn promote service carts --project=sockshop --stage=dev --target-stage=hardening --resource-uri=helm/carts.tgz --resource-uri=slo.yaml`,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if *promoteServiceParams.Stage == *promoteServiceParams.TargetStage {
+			return errors.New("The target stage must be different from the stage")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		commitID, err := promoteService(args[0], promoteServiceParams)
+		if err != nil {
+			return err
+		}
+		logging.PrintLog(fmt.Sprintf("Resources of service %s have been promoted from stage %s to stage %s in commit %s", args[0], *promoteServiceParams.Stage, *promoteServiceParams.TargetStage, commitID), logging.InfoLevel)
+		return nil
+	},
+}
+
+func promoteService(service string, params promoteServiceCmdParams) (string, error) {
+	endPoint, apiToken, err := getResourceServiceCreds()
+	if err != nil {
+		return "", err
+	}
+
+	promoteURL := buildServiceURL(endPoint, *params.Project, *params.Stage, service, "/promote")
+	request := promoteResourcesRequest{
+		TargetStage:  *params.TargetStage,
+		ResourceURIs: *params.ResourceURIs,
+	}
+	result := &writeResourceResponse{}
+	if err := doResourceServiceRequest(http.MethodPost, promoteURL, apiToken, request, result); err != nil {
+		return "", fmt.Errorf("could not promote service %s: %s", service, err.Error())
+	}
+	return result.CommitID, nil
+}
+
+func init() {
+	promoteCmd.AddCommand(promoteServiceCmd)
+	promoteServiceParams.Project = promoteServiceCmd.Flags().StringP("project", "", "",
+		"The project containing the service")
+	promoteServiceCmd.MarkFlagRequired("project")
+	promoteServiceParams.Stage = promoteServiceCmd.Flags().StringP("stage", "", "",
+		"The stage the resources are promoted from")
+	promoteServiceCmd.MarkFlagRequired("stage")
+	promoteServiceParams.TargetStage = promoteServiceCmd.Flags().StringP("target-stage", "", "",
+		"The stage the resources are promoted to")
+	promoteServiceCmd.MarkFlagRequired("target-stage")
+	promoteServiceParams.ResourceURIs = promoteServiceCmd.Flags().StringArray("resource-uri", []string{},
+		"The URI of a resource to be promoted, e.g. helm/carts.tgz. Can be set multiple times")
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromoteService(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	tests := []struct {
+		name         string
+		cmdArgs      string
+		responseCode int
+		responseBody string
+		wantPath     string
+		wantRequest  *promoteResourcesRequest
+		wantErr      string
+	}{
+		{
+			name:         "promote all resources",
+			cmdArgs:      "--target-stage=hardening",
+			responseCode: http.StatusOK,
+			responseBody: `{"commitID": "commit-1"}`,
+			wantPath:     "/resource-service/v1/project/sockshop/stage/dev/service/carts/promote",
+			wantRequest:  &promoteResourcesRequest{TargetStage: "hardening"},
+		},
+		{
+			name:         "promote selected resources",
+			cmdArgs:      "--target-stage=hardening --resource-uri=helm/carts.tgz --resource-uri=slo.yaml",
+			responseCode: http.StatusOK,
+			responseBody: `{"commitID": "commit-1"}`,
+			wantPath:     "/resource-service/v1/project/sockshop/stage/dev/service/carts/promote",
+			wantRequest:  &promoteResourcesRequest{TargetStage: "hardening", ResourceURIs: []string{"helm/carts.tgz", "slo.yaml"}},
+		},
+		{
+			name:         "service not found in target stage",
+			cmdArgs:      "--target-stage=production",
+			responseCode: http.StatusNotFound,
+			responseBody: `{"code": 404, "message": "Service not found"}`,
+			wantPath:     "/resource-service/v1/project/sockshop/stage/dev/service/carts/promote",
+			wantRequest:  &promoteResourcesRequest{TargetStage: "production"},
+			wantErr:      "could not promote service carts: Service not found",
+		},
+		{
+			name:         "not authenticated",
+			cmdArgs:      "--target-stage=hardening",
+			responseCode: http.StatusUnauthorized,
+			wantPath:     "/resource-service/v1/project/sockshop/stage/dev/service/carts/promote",
+			wantRequest:  &promoteResourcesRequest{TargetStage: "hardening"},
+			wantErr:      "could not promote service carts: " + internal.ErrNotAuthenticated,
+		},
+		{
+			name:    "same target stage",
+			cmdArgs: "--target-stage=dev",
+			wantErr: "The target stage must be different from the stage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedPath := ""
+			var receivedRequest *promoteResourcesRequest
+			ts := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.Contains(r.RequestURI, "/v1/metadata") {
+						w.Write([]byte(metadataMockResponse))
+						return
+					}
+					receivedPath = r.URL.Path
+					receivedRequest = &promoteResourcesRequest{}
+					_ = json.NewDecoder(r.Body).Decode(receivedRequest)
+					w.Header().Add("Content-Type", "application/json")
+					w.WriteHeader(tt.responseCode)
+					w.Write([]byte(tt.responseBody))
+				}),
+			)
+			defer ts.Close()
+			t.Setenv("MOCK_SERVER", ts.URL)
+
+			// flag values are kept between executions of the command
+			*promoteServiceParams.ResourceURIs = []string{}
+
+			cmd := fmt.Sprintf("promote service carts --project=sockshop --stage=dev %s --mock", tt.cmdArgs)
+			_, err := executeActionCommandC(cmd)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantPath, receivedPath)
+			assert.Equal(t, tt.wantRequest, receivedRequest)
+		})
+	}
+}
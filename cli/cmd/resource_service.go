@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+)
+
+const resourceServiceV1Path = "/resource-service/v1"
+
+type resourceCmdParams struct {
+	Project     *string
+	Stage       *string
+	Service     *string
+	ResourceURI *string
+}
+
+// resourceCommit is a commit that changed a resource, as returned by the resource-service
+type resourceCommit struct {
+	CommitID    string    `json:"commitID"`
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"authorEmail,omitempty"`
+	Message     string    `json:"message"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+type resourceHistoryResponse struct {
+	NextPageKey string           `json:"nextPageKey,omitempty"`
+	TotalCount  float64          `json:"totalCount,omitempty"`
+	Commits     []resourceCommit `json:"commits"`
+}
+
+type writeResourceResponse struct {
+	CommitID string `json:"commitID"`
+}
+
+func (p resourceCmdParams) validate() error {
+	if p.Service != nil && *p.Service != "" && (p.Stage == nil || *p.Stage == "") {
+		return errors.New("Flag 'stage' is missing")
+	}
+	return nil
+}
+
+// buildResourceURL returns the URL of the given sub path of a project, stage or service resource in the API of the resource-service
+func buildResourceURL(endPoint url.URL, params resourceCmdParams, subPath string) string {
+	path := strings.TrimSuffix(endPoint.String(), "/") + resourceServiceV1Path + "/project/" + url.PathEscape(*params.Project)
+	if params.Stage != nil && *params.Stage != "" {
+		path += "/stage/" + url.PathEscape(*params.Stage)
+	}
+	if params.Service != nil && *params.Service != "" {
+		path += "/service/" + url.PathEscape(*params.Service)
+	}
+	return path + "/resource/" + url.QueryEscape(*params.ResourceURI) + subPath
+}
+
+func buildServiceURL(endPoint url.URL, project string, stage string, service string, subPath string) string {
+	return strings.TrimSuffix(endPoint.String(), "/") + resourceServiceV1Path + "/project/" + url.PathEscape(project) +
+		"/stage/" + url.PathEscape(stage) + "/service/" + url.PathEscape(service) + subPath
+}
+
+func getResourceServiceCreds() (url.URL, string, error) {
+	var endPoint url.URL
+	var apiToken string
+	var err error
+	if !mocking {
+		endPoint, apiToken, err = credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+	} else {
+		endPointPtr, _ := url.Parse(os.Getenv("MOCK_SERVER"))
+		endPoint = *endPointPtr
+		apiToken = os.Getenv("MOCK_API_TOKEN")
+	}
+	if err != nil {
+		return endPoint, "", errors.New(authErrorMsg)
+	}
+	logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+	return endPoint, apiToken, nil
+}
+
+// doResourceServiceRequest sends a request with the given JSON body to the resource-service and decodes the response into the given result.
+// The request is sent using the HTTP client of the Keptn API, i.e. OAuth is used if configured, and errors are reported the same way as errors of the Keptn API
+func doResourceServiceRequest(method string, requestURL string, apiToken string, body interface{}, result interface{}) error {
+	var bodyReader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, requestURL, bodyReader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if apiToken != "" {
+		req.Header.Set("x-token", apiToken)
+	}
+
+	client, err := internal.HTTPClientProvider()
+	if err != nil {
+		return internal.OnAPIError(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response: %s", err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := apimodels.Error{}
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Message != nil {
+			return internal.OnAPIError(errors.New(*apiErr.Message))
+		}
+		return internal.OnAPIError(fmt.Errorf(internal.ErrWithStatusCode, resp.StatusCode))
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("could not parse response: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var revertCmd = &cobra.Command{
+	Use:   "revert [ resource ]",
+	Short: "Restores a former revision of a Keptn entity",
+}
+
+func init() {
+	rootCmd.AddCommand(revertCmd)
+}
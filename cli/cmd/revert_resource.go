@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type revertResourceCmdParams struct {
+	resourceCmdParams
+	CommitID *string
+}
+
+var revertResourceParams revertResourceCmdParams
+
+var revertResourceCmd = &cobra.Command{
+	Use:   "resource --project=PROJECT [--stage=STAGE] [--service=SERVICE] --resource-uri=RESOURCEURI --commit-id=COMMITID",
+	Short: "Restores the content a resource had in a former revision",
+	Long: `Restores the content the given resource had in the revision with the given commit ID. The restored content is committed as a new revision, i.e., the history of the resource is kept.
+
+The commit IDs of the revisions of a resource can be retrieved using 'keptn get resource-history'.
+`,
+	Example:      `keptn revert resource --project=sockshop --stage=dev --service=carts --resource-uri=helm/values.yaml --commit-id=7d0e9f5b3e3a4c1c8f2a6b9d5e4c3b2a1f0e9d8c`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return revertResourceParams.validate()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		commitID, err := revertResource(revertResourceParams)
+		if err != nil {
+			return err
+		}
+		logging.PrintLog(fmt.Sprintf("Resource %s has been reverted to revision %s in commit %s", *revertResourceParams.ResourceURI, *revertResourceParams.CommitID, commitID), logging.InfoLevel)
+		return nil
+	},
+}
+
+func revertResource(params revertResourceCmdParams) (string, error) {
+	endPoint, apiToken, err := getResourceServiceCreds()
+	if err != nil {
+		return "", err
+	}
+
+	revertURL := buildResourceURL(endPoint, params.resourceCmdParams, "/revert") + "?commitID=" + url.QueryEscape(*params.CommitID)
+	result := &writeResourceResponse{}
+	if err := doResourceServiceRequest(http.MethodPost, revertURL, apiToken, nil, result); err != nil {
+		return "", fmt.Errorf("could not revert resource %s: %s", *params.ResourceURI, err.Error())
+	}
+	return result.CommitID, nil
+}
+
+func init() {
+	revertCmd.AddCommand(revertResourceCmd)
+	revertResourceParams.Project = revertResourceCmd.Flags().StringP("project", "", "",
+		"The project containing the resource")
+	revertResourceCmd.MarkFlagRequired("project")
+	revertResourceParams.Stage = revertResourceCmd.Flags().StringP("stage", "", "",
+		"The stage containing the resource")
+	revertResourceParams.Service = revertResourceCmd.Flags().StringP("service", "", "",
+		"The service containing the resource")
+	revertResourceParams.ResourceURI = revertResourceCmd.Flags().StringP("resource-uri", "", "",
+		"The URI of the resource, e.g. helm/values.yaml")
+	revertResourceCmd.MarkFlagRequired("resource-uri")
+	revertResourceParams.CommitID = revertResourceCmd.Flags().StringP("commit-id", "", "",
+		"The commit ID of the revision to be restored")
+	revertResourceCmd.MarkFlagRequired("commit-id")
+}
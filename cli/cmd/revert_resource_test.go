@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevertResource(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	tests := []struct {
+		name         string
+		cmdArgs      string
+		responseCode int
+		responseBody string
+		wantRequest  string
+		wantErr      string
+	}{
+		{
+			name:         "revert service resource",
+			cmdArgs:      "--stage=dev --service=carts --resource-uri=helm/values.yaml --commit-id=commit-1",
+			responseCode: http.StatusOK,
+			responseBody: `{"commitID": "commit-3"}`,
+			wantRequest:  "/resource-service/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fvalues.yaml/revert?commitID=commit-1",
+		},
+		{
+			name:         "revision not found",
+			cmdArgs:      "--resource-uri=shipyard.yaml --commit-id=unknown",
+			responseCode: http.StatusNotFound,
+			responseBody: `{"code": 404, "message": "Revision not found"}`,
+			wantRequest:  "/resource-service/v1/project/sockshop/resource/shipyard.yaml/revert?commitID=unknown",
+			wantErr:      "could not revert resource shipyard.yaml: Revision not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedRequest := ""
+			ts := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.Contains(r.RequestURI, "/v1/metadata") {
+						w.Write([]byte(metadataMockResponse))
+						return
+					}
+					if r.Method == http.MethodPost {
+						receivedRequest = r.RequestURI
+					}
+					w.Header().Add("Content-Type", "application/json")
+					w.WriteHeader(tt.responseCode)
+					w.Write([]byte(tt.responseBody))
+				}),
+			)
+			defer ts.Close()
+			t.Setenv("MOCK_SERVER", ts.URL)
+
+			// flag values are kept between executions of the command
+			*revertResourceParams.Stage = ""
+			*revertResourceParams.Service = ""
+
+			cmd := fmt.Sprintf("revert resource --project=sockshop %s --mock", tt.cmdArgs)
+			_, err := executeActionCommandC(cmd)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRequest, receivedRequest)
+		})
+	}
+}
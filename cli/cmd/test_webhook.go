@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/common/fileutils"
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/spf13/cobra"
+)
+
+const webhookTestPath = "/webhook-service/v1/test"
+
+type testWebhookCmdParams struct {
+	Project        *string
+	Stage          *string
+	Service        *string
+	SubscriptionID *string
+	EventFilePath  *string
+	Execute        *bool
+}
+
+var testWebhookParams testWebhookCmdParams
+
+var testWebhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Validates a webhook configuration and renders its requests using a sample event",
+	Long: `Loads the webhook with the given subscription ID from the webhook configurations of the given project, stage and service, the same way the webhook service does for incoming events.
+The requests of the webhook are rendered using the provided sample event and validated against the deny list of the webhook service.
+If the --execute flag is set, the requests are also sent to their targets and their responses are returned. No Keptn events are sent in either case.
+The values of secrets used by the webhook are replaced with '***' in the output.
+`,
+	Example: `keptn test webhook --project=podtato-head --stage=dev --service=helloservice --subscription-id=<subscription-id> --event=./deployment.triggered.json
+
+keptn test webhook --project=podtato-head --stage=dev --service=helloservice --subscription-id=<subscription-id> --event=./deployment.triggered.json --execute`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := testWebhook(testWebhookParams)
+		if err != nil {
+			return err
+		}
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not print result: %w", err)
+		}
+		logging.PrintLog(string(output), logging.QuietLevel)
+		if !result.Valid {
+			return errors.New("webhook test was unsuccessful")
+		}
+		return nil
+	},
+}
+
+func testWebhook(params testWebhookCmdParams) (*lib.TestFireResult, error) {
+	var endPoint url.URL
+	var apiToken string
+	var err error
+	if !mocking {
+		endPoint, apiToken, err = credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+	} else {
+		endPointPtr, _ := url.Parse(os.Getenv("MOCK_SERVER"))
+		endPoint = *endPointPtr
+		apiToken = os.Getenv("MOCK_API_TOKEN")
+	}
+	if err != nil {
+		return nil, errors.New(authErrorMsg)
+	}
+
+	testRequest := lib.TestFireRequest{
+		Project:        *params.Project,
+		Stage:          *params.Stage,
+		Service:        *params.Service,
+		SubscriptionID: *params.SubscriptionID,
+		Execute:        *params.Execute,
+	}
+	if *params.EventFilePath != "" {
+		eventString, err := fileutils.ReadFile(*params.EventFilePath)
+		if err != nil {
+			return nil, err
+		}
+		event := apimodels.KeptnContextExtendedCE{}
+		if err := json.Unmarshal(eventString, &event); err != nil {
+			return nil, fmt.Errorf("failed to map event to API event model. %s", err.Error())
+		}
+		testRequest.Event = event
+	}
+	body, err := json.Marshal(testRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	testURL := strings.TrimSuffix(endPoint.String(), "/") + webhookTestPath
+	logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+	req, err := http.NewRequest(http.MethodPost, testURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiToken != "" {
+		req.Header.Set("x-token", apiToken)
+	}
+
+	client, err := internal.HTTPClientProvider()
+	if err != nil {
+		return nil, fmt.Errorf("could not test webhook: %s", internal.OnAPIError(err).Error())
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not test webhook: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response: %s", err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := apimodels.Error{}
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Message != nil {
+			return nil, fmt.Errorf("could not test webhook: %s", internal.OnAPIError(errors.New(*apiErr.Message)).Error())
+		}
+		return nil, fmt.Errorf("could not test webhook: %s", internal.OnAPIError(fmt.Errorf(internal.ErrWithStatusCode, resp.StatusCode)).Error())
+	}
+
+	result := &lib.TestFireResult{}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return nil, fmt.Errorf("could not parse response: %s", err.Error())
+	}
+	return result, nil
+}
+
+func init() {
+	testCmd.AddCommand(testWebhookCmd)
+	testWebhookParams.Project = testWebhookCmd.Flags().StringP("project", "", "",
+		"The project for which the webhook is tested")
+	testWebhookCmd.MarkFlagRequired("project")
+	testWebhookParams.Stage = testWebhookCmd.Flags().StringP("stage", "", "",
+		"The stage for which the webhook is tested")
+	testWebhookCmd.MarkFlagRequired("stage")
+	testWebhookParams.Service = testWebhookCmd.Flags().StringP("service", "", "",
+		"The service for which the webhook is tested")
+	testWebhookCmd.MarkFlagRequired("service")
+	testWebhookParams.SubscriptionID = testWebhookCmd.Flags().StringP("subscription-id", "", "",
+		"The ID of the subscription the webhook belongs to")
+	testWebhookCmd.MarkFlagRequired("subscription-id")
+	testWebhookParams.EventFilePath = testWebhookCmd.Flags().StringP("event", "e", "",
+		"The file containing the sample event as Cloud Event in JSON, which is used to render the requests of the webhook")
+	testWebhookParams.Execute = testWebhookCmd.Flags().BoolP("execute", "", false,
+		"Sends the rendered requests to their targets")
+}
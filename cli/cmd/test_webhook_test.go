@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleWebhookEvent = `{
+  "type": "sh.keptn.event.webhook.triggered",
+  "specversion": "1.0",
+  "source": "test",
+  "data": {
+    "labels": {"owner": "team-a"}
+  }
+}`
+
+func TestTestWebhook(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	eventFile := filepath.Join(t.TempDir(), "event.json")
+	require.NoError(t, ioutil.WriteFile(eventFile, []byte(sampleWebhookEvent), 0644))
+
+	tests := []struct {
+		name         string
+		cmdArgs      string
+		responseCode int
+		responseBody string
+		wantRequest  *lib.TestFireRequest
+		wantErr      string
+	}{
+		{
+			name:         "valid webhook",
+			cmdArgs:      fmt.Sprintf("--event=%s --execute", eventFile),
+			responseCode: http.StatusOK,
+			responseBody: `{"valid": true, "requests": [{"method": "GET", "url": "http://my-target", "executed": true}]}`,
+			wantRequest: &lib.TestFireRequest{
+				Project:        "podtato-head",
+				Stage:          "dev",
+				Service:        "helloservice",
+				SubscriptionID: "my-subscription",
+				Execute:        true,
+			},
+		},
+		{
+			name:         "invalid webhook",
+			responseCode: http.StatusOK,
+			responseBody: `{"valid": false, "error": "could not retrieve Webhook config: no webhook config found", "requests": []}`,
+			wantRequest: &lib.TestFireRequest{
+				Project:        "podtato-head",
+				Stage:          "dev",
+				Service:        "helloservice",
+				SubscriptionID: "my-subscription",
+			},
+			wantErr: "webhook test was unsuccessful",
+		},
+		{
+			name:         "error response",
+			responseCode: http.StatusBadRequest,
+			responseBody: `{"code": 400, "message": "invalid test request: project, stage and service must be present in the event data"}`,
+			wantErr:      "could not test webhook: invalid test request: project, stage and service must be present in the event data",
+		},
+		{
+			name:         "not authenticated",
+			responseCode: http.StatusUnauthorized,
+			wantErr:      "could not test webhook: " + internal.ErrNotAuthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedRequest *lib.TestFireRequest
+			ts := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.Contains(r.RequestURI, "/v1/metadata") {
+						w.Write([]byte(metadataMockResponse))
+						return
+					}
+					if strings.HasSuffix(r.URL.Path, webhookTestPath) && r.Method == http.MethodPost {
+						receivedRequest = &lib.TestFireRequest{}
+						_ = json.NewDecoder(r.Body).Decode(receivedRequest)
+					}
+					w.Header().Add("Content-Type", "application/json")
+					w.WriteHeader(tt.responseCode)
+					w.Write([]byte(tt.responseBody))
+				}),
+			)
+			defer ts.Close()
+			t.Setenv("MOCK_SERVER", ts.URL)
+
+			// flag values are kept between executions of the command
+			*testWebhookParams.EventFilePath = ""
+			*testWebhookParams.Execute = false
+
+			cmd := fmt.Sprintf("test webhook --project=podtato-head --stage=dev --service=helloservice --subscription-id=my-subscription %s --mock", tt.cmdArgs)
+			_, err := executeActionCommandC(cmd)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NotNil(t, receivedRequest)
+			if tt.wantRequest == nil {
+				return
+			}
+			if tt.cmdArgs != "" {
+				require.NotNil(t, receivedRequest.Event.Type)
+				assert.Equal(t, "sh.keptn.event.webhook.triggered", *receivedRequest.Event.Type)
+			}
+			receivedRequest.Event = tt.wantRequest.Event
+			assert.Equal(t, *tt.wantRequest, *receivedRequest)
+		})
+	}
+}
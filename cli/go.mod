@@ -10,6 +10,7 @@ require (
 	github.com/hashicorp/go-version v1.6.0
 	github.com/invopop/jsonschema v0.5.0
 	github.com/keptn/go-utils v0.18.1-0.20220829065650-dc8c0968b133
+	github.com/keptn/keptn/internal v0.0.0-00010101000000-000000000000
 	github.com/keptn/keptn/webhook-service v0.18.1
 	github.com/mattn/go-shellwords v1.0.12
 	github.com/mitchellh/mapstructure v1.5.0
@@ -96,6 +97,7 @@ replace (
 	github.com/docker/distribution => github.com/docker/distribution v0.0.0-20191216044856-a8371794149d
 	github.com/docker/docker => github.com/moby/moby v17.12.0-ce-rc1.0.20200618181300-9dc6525e6118+incompatible
 	github.com/emicklei/go-restful/v3 => github.com/emicklei/go-restful/v3 v3.8.0
+	github.com/keptn/keptn/internal => ../internal
 	github.com/keptn/keptn/webhook-service => ../webhook-service
 	golang.org/x/crypto => golang.org/x/crypto v0.0.0-20220824171710-5757bc0c5503
 	golang.org/x/text => golang.org/x/text v0.3.7
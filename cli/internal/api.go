@@ -19,6 +19,10 @@ var PublicDiscovery = auth.NewOauthDiscovery(&http.Client{})
 // APIProvider is used to get a handle to the Keptn API clients
 var APIProvider = getAPISet
 
+// HTTPClientProvider is used to get the HTTP client for requests to Keptn APIs that are not covered by the API clients,
+// e.g. the API of the resource-service. If OAuth is in use, the returned client is OAuth enabled, otherwise a fresh HTTP client is returned
+var HTTPClientProvider = getHTTPClient
+
 // getAPISet will create and return an API Set that already
 // contains the correct HTTP Client to be used for contacting the API endpoints
 // Depending on whether OAuth is in use or not it will create an OAUth enabled client or not.
@@ -28,7 +32,22 @@ var APIProvider = getAPISet
 // It is also possible to provide nil as httpClient parameter, in which case a fresh HTTP client will be created
 // but does NOT support OAuth
 func getAPISet(baseURL string, keptnXToken string, httpClient ...*http.Client) (*apiutils.APISet, error) {
-	return getAPISetWithOauthGetter(baseURL, keptnXToken, auth.NewOauthAuthenticator(PublicDiscovery, auth.NewLocalFileOauthStore(), auth.NewBrowser(), &auth.ClosingRedirectHandler{}), httpClient...)
+	return getAPISetWithOauthGetter(baseURL, keptnXToken, newOauthAuthenticator(), httpClient...)
+}
+
+func getHTTPClient() (*http.Client, error) {
+	client, err := getOauthClient(newOauthAuthenticator())
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return &http.Client{}, nil
+	}
+	return client, nil
+}
+
+func newOauthAuthenticator() auth.OAuthenticator {
+	return auth.NewOauthAuthenticator(PublicDiscovery, auth.NewLocalFileOauthStore(), auth.NewBrowser(), &auth.ClosingRedirectHandler{})
 }
 
 func getAPISetWithOauthGetter(baseURL string, keptnXToken string, oauthAuthenticator auth.OAuthenticator, httpClient ...*http.Client) (*apiutils.APISet, error) {
@@ -42,37 +61,45 @@ func getAPISetWithOauthGetter(baseURL string, keptnXToken string, oauthAuthentic
 	}
 	// else, depending on whether OAuth is in use or not,
 	// create and return a APISet with an OAuth enabled HTTP client or not
-	var client *http.Client
+	client, err := getOauthClient(oauthAuthenticator)
+	if err != nil {
+		return nil, err
+	}
+	if client != nil && keptnXToken == "" {
+		return apiutils.New(baseURL, apiutils.WithHTTPClient(client))
+	}
+	return apiutils.New(baseURL, apiutils.WithAuthToken(keptnXToken), apiutils.WithHTTPClient(client))
+}
 
+// getOauthClient returns the ready to use OAuth enabled HTTP client, or nil if OAuth is not in use
+func getOauthClient(oauthAuthenticator auth.OAuthenticator) (*http.Client, error) {
 	// check whether OAuth is in use
-	if storeCreated := oauthAuthenticator.TokenStore().Created(); storeCreated {
-		oauthInfo, err := oauthAuthenticator.TokenStore().GetOauthInfo()
-		if err != nil {
-			return nil, err
-		}
-		// get the ready to use HTTP client
-		client, err := oauthAuthenticator.OauthClient(context.Background())
+	if storeCreated := oauthAuthenticator.TokenStore().Created(); !storeCreated {
+		return nil, nil
+	}
+	oauthInfo, err := oauthAuthenticator.TokenStore().GetOauthInfo()
+	if err != nil {
+		return nil, err
+	}
+	// get the ready to use HTTP client
+	client, err := oauthAuthenticator.OauthClient(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	// check if the HTTP client is still usable, i.e.
+	// make a call to the token endpoint
+	// If it fails, we assume that it can be fixed by
+	// starting the authorization code flow again
+	// TODO: Check for a way to determine whether the error is related to an invalid refresh token
+	_, err = client.Head(oauthInfo.DiscoveryInfo.TokenEndpoint)
+	if err != nil {
+		// start the authorization code flow
+		err = oauthAuthenticator.Auth(*oauthInfo.ClientValues)
 		if err != nil {
 			return nil, err
 		}
-		// check if the HTTP client is still usable, i.e.
-		// make a call to the token endpoint
-		// If it fails, we assume that it can be fixed by
-		// starting the authorization code flow again
-		// TODO: Check for a way to determine whether the error is related to an invalid refresh token
-		_, err = client.Head(oauthInfo.DiscoveryInfo.TokenEndpoint)
-		if err != nil {
-			// start the authorization code flow
-			err = oauthAuthenticator.Auth(*oauthInfo.ClientValues)
-			if err != nil {
-				return nil, err
-			}
-		}
-		if keptnXToken == "" {
-			return apiutils.New(baseURL, apiutils.WithHTTPClient(client))
-		}
 	}
-	return apiutils.New(baseURL, apiutils.WithAuthToken(keptnXToken), apiutils.WithHTTPClient(client))
+	return client, nil
 }
 
 func OnAPIError(err error) error {
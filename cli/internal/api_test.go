@@ -136,3 +136,34 @@ func TestOnAPIError(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetOauthClient(t *testing.T) {
+	t.Run("OAuth not in use", func(t *testing.T) {
+		authenticator := &auth.OAuthAuthenticatorMock{
+			TokenStoreFn: func() auth.OauthStore { return &auth.TokenStoreMock{CreatedFn: func() bool { return false }} },
+		}
+		client, err := getOauthClient(authenticator)
+		require.Nil(t, err)
+		require.Nil(t, client)
+		require.False(t, authenticator.GetAuthClientCalled)
+	})
+	t.Run("OAuth in use", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) { w.WriteHeader(200) }))
+		defer ts.Close()
+		oauthClient := &http.Client{}
+		authenticator := &auth.OAuthAuthenticatorMock{
+			TokenStoreFn: func() auth.OauthStore {
+				return &auth.TokenStoreMock{
+					CreatedFn: func() bool { return true },
+					GetOauthInfoFn: func() (*auth.OauthInfo, error) {
+						return &auth.OauthInfo{DiscoveryInfo: &auth.OauthDiscoveryResult{TokenEndpoint: ts.URL}, ClientValues: &auth.OauthClientValues{}}, nil
+					},
+				}
+			},
+			GetOauthClientFn: func(ctx context.Context) (*http.Client, error) { return oauthClient, nil },
+		}
+		client, err := getOauthClient(authenticator)
+		require.Nil(t, err)
+		require.Same(t, oauthClient, client)
+	})
+}
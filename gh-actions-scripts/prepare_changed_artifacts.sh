@@ -112,6 +112,14 @@ else
   )
 fi
 
+# Changes to the packages shared by several artifacts require the artifacts using them to be built as well
+for changed_file in $CHANGED_FILES; do
+  if [[ $changed_file == "${INTERNAL_FOLDER}"* ]]; then
+    CHANGED_FILES="$CHANGED_FILES $INTERNAL_DEPENDENT_FOLDERS"
+    break
+  fi
+done
+
 echo "Changed files:"
 echo "$CHANGED_FILES"
 matrix_config='{"config":['
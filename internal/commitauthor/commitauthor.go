@@ -0,0 +1,8 @@
+// Package commitauthor contains the headers used to pass the authenticated caller of a request from the api-gateway to the
+// resource-service, which uses it as author of the commits created by the request
+package commitauthor
+
+// NameHeader and EmailHeader contain the author of the commits created by a request. They are set by the api-gateway based on
+// the authenticated caller and can not be chosen by clients
+const NameHeader = "X-Keptn-Author-Name"
+const EmailHeader = "X-Keptn-Author-Email"
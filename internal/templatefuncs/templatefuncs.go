@@ -0,0 +1,111 @@
+// Package templatefuncs provides the functions that are available in the templates of webhooks and import packages
+package templatefuncs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// nowFunc returns the current time used by the 'now' template function
+var nowFunc = time.Now
+
+// FuncMap returns the functions that are available in templates.
+// Only functions without side effects are provided, i.e. templates cannot access files, environment variables or the network
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"toJson":  toJSON,
+		"quote":   quote,
+		"default": defaultValue,
+		"b64enc":  b64enc,
+		"b64dec":  b64dec,
+		"now":     now,
+		"date":    formatDate,
+		"upper":   strings.ToUpper,
+		"lower":   strings.ToLower,
+		"sha256":  sha256sum,
+	}
+}
+
+// toJSON encodes the given value as JSON
+func toJSON(value interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// quote returns the given value as a quoted and escaped JSON string
+func quote(value interface{}) (string, error) {
+	return toJSON(fmt.Sprint(value))
+}
+
+// defaultValue returns the given value, or the default value if the given value is empty
+func defaultValue(defaultVal interface{}, value interface{}) interface{} {
+	if isEmptyValue(value) {
+		return defaultVal
+	}
+	return value
+}
+
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Bool:
+		return !v.Bool()
+	}
+	return false
+}
+
+func b64enc(value string) string {
+	return base64.StdEncoding.EncodeToString([]byte(value))
+}
+
+func b64dec(value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+func now() time.Time {
+	return nowFunc().UTC()
+}
+
+// formatDate formats the given time, which can also be an RFC3339 timestamp like the time of an event, using the given layout
+func formatDate(layout string, value interface{}) (string, error) {
+	switch t := value.(type) {
+	case time.Time:
+		return t.Format(layout), nil
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return "", fmt.Errorf("could not parse time '%s': %w", t, err)
+		}
+		return parsed.Format(layout), nil
+	}
+	return "", fmt.Errorf("unsupported time value of type %T", value)
+}
+
+func sha256sum(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,88 @@
+package templatefuncs
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuncMap(t *testing.T) {
+	nowFunc = func() time.Time {
+		return time.Date(2022, 9, 1, 12, 30, 0, 0, time.UTC)
+	}
+	defer func() { nowFunc = time.Now }()
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"project": "my-project",
+			"message": `say "hello" & <goodbye>`,
+			"labels":  map[string]interface{}{"buildId": "123"},
+			"empty":   "",
+		},
+		"time": "2022-08-30T13:49:49.929Z",
+	}
+	tests := []struct {
+		name        string
+		templateStr string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "toJson",
+			templateStr: `{"labels": {{ toJson .data.labels }}}`,
+			want:        `{"labels": {"buildId":"123"}}`,
+		},
+		{
+			name:        "quote",
+			templateStr: `{"message": {{ quote .data.message }}}`,
+			want:        `{"message": "say \"hello\" & <goodbye>"}`,
+		},
+		{
+			name:        "default",
+			templateStr: `{{ default "none" .data.empty }} {{ default "none" .data.project }} {{ default "none" (index .data "unknown") }}`,
+			want:        "none my-project none",
+		},
+		{
+			name:        "b64enc and b64dec",
+			templateStr: `{{ b64enc "user:password" }} {{ b64dec "dXNlcjpwYXNzd29yZA==" }}`,
+			want:        "dXNlcjpwYXNzd29yZA== user:password",
+		},
+		{
+			name:        "now and date",
+			templateStr: `{{ date "2006-01-02T15:04" now }} {{ date "2006-01-02" .time }}`,
+			want:        "2022-09-01T12:30 2022-08-30",
+		},
+		{
+			name:        "urlquery, upper and lower",
+			templateStr: `{{ urlquery .data.message }} {{ upper .data.project }} {{ lower "ABC" }}`,
+			want:        "say+%22hello%22+%26+%3Cgoodbye%3E MY-PROJECT abc",
+		},
+		{
+			name:        "sha256",
+			templateStr: `{{ sha256 "my-project" }}`,
+			want:        "1c7cd944c28cd888904f3efc2345198507c47563c4b51a7acc51e3df4e681904",
+		},
+		{
+			name:        "invalid date",
+			templateStr: `{{ date "2006-01-02" .data.project }}`,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New("").Funcs(FuncMap()).Parse(tt.templateStr)
+			require.NoError(t, err)
+			got := &strings.Builder{}
+			err = tmpl.Execute(got, data)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package event_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+const anchorNow = "now"
+
+// anchorRegex matches anchor expressions like 'deployment.finished', 'deployment.finished+2m' or 'test.started - 30m'
+var anchorRegex = regexp.MustCompile(`^([a-zA-Z0-9-]+)\.(triggered|started|finished)\s*(?:([+-])\s*([0-9a-z.]+))?$`)
+
+// anchoredTimeframe defines an evaluation timeframe relative to events of the Keptn context of the evaluation.
+// It is passed in the evaluation.anchoredTimeframe property of the evaluation.triggered event, e.g.:
+//
+//	"anchoredTimeframe": {
+//	  "from": "deployment.finished+2m",
+//	  "to": "now"
+//	}
+//
+// An anchor is either 'now', or the type of a task event (e.g. 'test.started') with an optional offset (e.g. '+2m').
+// If only one of From and To is set, the Timeframe is used to compute the other end of the timeframe
+type anchoredTimeframe struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Timeframe string `json:"timeframe"`
+}
+
+type anchoredTimeframeOptions struct {
+	Evaluation struct {
+		AnchoredTimeframe *anchoredTimeframe `json:"anchoredTimeframe"`
+	} `json:"evaluation"`
+}
+
+// parseAnchoredTimeframe returns the anchored timeframe of the given evaluation.triggered event payload, or nil if none is defined
+func parseAnchoredTimeframe(eventData []byte) (*anchoredTimeframe, error) {
+	options := &anchoredTimeframeOptions{}
+	if err := json.Unmarshal(eventData, options); err != nil {
+		return nil, err
+	}
+	timeframe := options.Evaluation.AnchoredTimeframe
+	if timeframe == nil {
+		return nil, nil
+	}
+	if timeframe.From == "" && timeframe.To == "" {
+		return nil, errors.New("anchored timeframe requires at least one of 'from' and 'to'")
+	}
+	if (timeframe.From == "" || timeframe.To == "") && timeframe.Timeframe == "" {
+		return nil, errors.New("anchored timeframe requires a 'timeframe' if only one of 'from' and 'to' is set")
+	}
+	return timeframe, nil
+}
+
+// resolveAnchoredTimeframe resolves the anchors of the given timeframe using the events of the given Keptn context
+func resolveAnchoredTimeframe(eventStore EventStore, keptnContext string, e *keptnv2.EvaluationTriggeredEventData, timeframe *anchoredTimeframe, now time.Time) (string, string, error) {
+	var start, end time.Time
+	var err error
+
+	if timeframe.From != "" {
+		start, err = resolveAnchor(eventStore, keptnContext, e, timeframe.From, now)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	if timeframe.To != "" {
+		end, err = resolveAnchor(eventStore, keptnContext, e, timeframe.To, now)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	if timeframe.From == "" || timeframe.To == "" {
+		duration, err := time.ParseDuration(timeframe.Timeframe)
+		if err != nil {
+			return "", "", fmt.Errorf("could not parse timeframe '%s': %w", timeframe.Timeframe, err)
+		}
+		if timeframe.From == "" {
+			start = end.Add(-duration)
+		} else {
+			end = start.Add(duration)
+		}
+	}
+
+	if !start.Before(end) {
+		return "", "", fmt.Errorf("start of anchored timeframe (%s) must be before its end (%s)", timeutils.GetKeptnTimeStamp(start), timeutils.GetKeptnTimeStamp(end))
+	}
+	return timeutils.GetKeptnTimeStamp(start), timeutils.GetKeptnTimeStamp(end), nil
+}
+
+func resolveAnchor(eventStore EventStore, keptnContext string, e *keptnv2.EvaluationTriggeredEventData, anchor string, now time.Time) (time.Time, error) {
+	anchor = strings.TrimSpace(anchor)
+	if anchor == anchorNow {
+		return now.UTC(), nil
+	}
+
+	matches := anchorRegex.FindStringSubmatch(anchor)
+	if matches == nil {
+		return time.Time{}, fmt.Errorf("invalid anchor '%s'", anchor)
+	}
+	task, phase := matches[1], matches[2]
+	var offset time.Duration
+	if matches[3] != "" {
+		var err error
+		offset, err = time.ParseDuration(matches[4])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("could not parse offset of anchor '%s': %w", anchor, err)
+		}
+		if matches[3] == "-" {
+			offset = -offset
+		}
+	}
+
+	var eventType string
+	switch phase {
+	case "triggered":
+		eventType = keptnv2.GetTriggeredEventType(task)
+	case "started":
+		eventType = keptnv2.GetStartedEventType(task)
+	default:
+		eventType = keptnv2.GetFinishedEventType(task)
+	}
+
+	events, errObj := eventStore.GetEvents(&keptnapi.EventFilter{
+		Project:      e.Project,
+		Stage:        e.Stage,
+		Service:      e.Service,
+		EventType:    eventType,
+		KeptnContext: keptnContext,
+	})
+	if errObj != nil {
+		return time.Time{}, fmt.Errorf("could not retrieve %s event for anchor '%s': %s", eventType, anchor, errObj.GetMessage())
+	}
+
+	// use the most recent event if the task has been executed several times in the sequence
+	var anchorTime time.Time
+	for _, event := range events {
+		if event.Time.After(anchorTime) {
+			anchorTime = event.Time
+		}
+	}
+	if anchorTime.IsZero() {
+		return time.Time{}, fmt.Errorf("no %s event found in context %s for anchor '%s'", eventType, keptnContext, anchor)
+	}
+	return anchorTime.UTC().Add(offset), nil
+}
@@ -0,0 +1,146 @@
+package event_handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/go-utils/pkg/common/strutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
+)
+
+func Test_parseAnchoredTimeframe(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventData string
+		want      *anchoredTimeframe
+		wantErr   bool
+	}{
+		{
+			name:      "no anchored timeframe",
+			eventData: `{"evaluation":{"timeframe":"5m"}}`,
+			want:      nil,
+		},
+		{
+			name:      "from and to",
+			eventData: `{"evaluation":{"anchoredTimeframe":{"from":"deployment.finished+2m","to":"now"}}}`,
+			want:      &anchoredTimeframe{From: "deployment.finished+2m", To: "now"},
+		},
+		{
+			name:      "to with timeframe",
+			eventData: `{"evaluation":{"anchoredTimeframe":{"to":"test.started","timeframe":"30m"}}}`,
+			want:      &anchoredTimeframe{To: "test.started", Timeframe: "30m"},
+		},
+		{
+			name:      "from without timeframe",
+			eventData: `{"evaluation":{"anchoredTimeframe":{"from":"test.started"}}}`,
+			wantErr:   true,
+		},
+		{
+			name:      "empty anchored timeframe",
+			eventData: `{"evaluation":{"anchoredTimeframe":{"timeframe":"30m"}}}`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAnchoredTimeframe([]byte(tt.eventData))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_resolveAnchoredTimeframe(t *testing.T) {
+	now := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	deploymentFinished := time.Date(2022, 9, 1, 11, 30, 0, 0, time.UTC)
+	testStarted := time.Date(2022, 9, 1, 11, 40, 0, 0, time.UTC)
+
+	var receivedFilters []*keptnapi.EventFilter
+	eventStore := &event_handler_mock.EventStoreMock{
+		GetEventsFunc: func(filter *keptnapi.EventFilter) ([]*models.KeptnContextExtendedCE, *models.Error) {
+			receivedFilters = append(receivedFilters, filter)
+			switch filter.EventType {
+			case keptnv2.GetFinishedEventType(keptnv2.DeploymentTaskName):
+				return []*models.KeptnContextExtendedCE{
+					{Time: deploymentFinished.Add(-10 * time.Minute)},
+					{Time: deploymentFinished},
+				}, nil
+			case keptnv2.GetStartedEventType(keptnv2.TestTaskName):
+				return []*models.KeptnContextExtendedCE{{Time: testStarted}}, nil
+			}
+			return nil, &models.Error{Code: 404, Message: strutils.Stringp("no events found")}
+		},
+	}
+	e := &keptnv2.EvaluationTriggeredEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "dev", Service: "carts"},
+	}
+
+	tests := []struct {
+		name      string
+		timeframe *anchoredTimeframe
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{
+			name:      "from deployment.finished + 2m to now",
+			timeframe: &anchoredTimeframe{From: "deployment.finished + 2m", To: "now"},
+			wantStart: "2022-09-01T11:32:00.000Z",
+			wantEnd:   "2022-09-01T12:00:00.000Z",
+		},
+		{
+			name:      "last 30m before test.started",
+			timeframe: &anchoredTimeframe{To: "test.started", Timeframe: "30m"},
+			wantStart: "2022-09-01T11:10:00.000Z",
+			wantEnd:   "2022-09-01T11:40:00.000Z",
+		},
+		{
+			name:      "from deployment.finished with timeframe",
+			timeframe: &anchoredTimeframe{From: "deployment.finished", Timeframe: "5m"},
+			wantStart: "2022-09-01T11:30:00.000Z",
+			wantEnd:   "2022-09-01T11:35:00.000Z",
+		},
+		{
+			name:      "start after end",
+			timeframe: &anchoredTimeframe{From: "test.started", To: "deployment.finished"},
+			wantErr:   true,
+		},
+		{
+			name:      "event not available",
+			timeframe: &anchoredTimeframe{From: "release.finished", To: "now"},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid anchor",
+			timeframe: &anchoredTimeframe{From: "yesterday", To: "now"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedFilters = nil
+			start, end, err := resolveAnchoredTimeframe(eventStore, "my-context", e, tt.timeframe, now)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+			for _, filter := range receivedFilters {
+				assert.Equal(t, "my-context", filter.KeptnContext)
+				assert.Equal(t, "dev", filter.Stage)
+			}
+		})
+	}
+}
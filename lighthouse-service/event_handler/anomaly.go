@@ -0,0 +1,148 @@
+package event_handler
+
+import (
+	"math"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultAnomalyDetectionResults   = 10
+	defaultAnomalyDetectionThreshold = 3.0
+	// minAnomalyDetectionResults is the minimum number of historical values required to annotate an SLI value
+	minAnomalyDetectionResults = 3
+)
+
+// sloAnomalyDetection configures the detection of anomalous SLI values. It is configured via the anomaly_detection section of the slo.yaml, e.g.:
+//
+//	anomaly_detection:
+//	  number_of_results: 10
+//	  threshold: 3
+//	  escalate_to_warning: true
+type sloAnomalyDetection struct {
+	// NumberOfResults is the number of previous evaluations the historical distribution of an SLI is computed from
+	NumberOfResults int `yaml:"number_of_results"`
+	// Threshold is the number of standard deviations a value has to deviate from the historical mean to be considered an anomaly
+	Threshold float64 `yaml:"threshold"`
+	// EscalateToWarning turns passed SLIs with anomalous values into warnings
+	EscalateToWarning bool `yaml:"escalate_to_warning"`
+}
+
+// sliAnomaly annotates an SLI value with its deviation from the historical distribution of the SLI
+type sliAnomaly struct {
+	IsAnomaly bool `json:"isAnomaly"`
+	// Score is the absolute deviation of the value from the historical mean, in standard deviations
+	Score           float64 `json:"score"`
+	Mean            float64 `json:"mean"`
+	StdDev          float64 `json:"stdDev"`
+	NumberOfResults int     `json:"numberOfResults"`
+}
+
+type sloAnomalyConfig struct {
+	AnomalyDetection *sloAnomalyDetection `yaml:"anomaly_detection"`
+}
+
+// parseAnomalyDetection returns the anomaly detection settings of the given SLO file, or nil if anomaly detection is not configured
+func parseAnomalyDetection(sloFileContent []byte) (*sloAnomalyDetection, error) {
+	config := &sloAnomalyConfig{}
+	if err := yaml.Unmarshal(sloFileContent, config); err != nil {
+		return nil, err
+	}
+	if config.AnomalyDetection == nil {
+		return nil, nil
+	}
+	if config.AnomalyDetection.NumberOfResults <= 0 {
+		config.AnomalyDetection.NumberOfResults = defaultAnomalyDetectionResults
+	}
+	if config.AnomalyDetection.Threshold <= 0 {
+		config.AnomalyDetection.Threshold = defaultAnomalyDetectionThreshold
+	}
+	return config.AnomalyDetection, nil
+}
+
+// detectAnomalies compares each successfully retrieved SLI value with the values of the same SLI in the previous evaluations.
+// SLIs with less than minAnomalyDetectionResults historical values are not annotated
+func detectAnomalies(results []*keptnv2.SLIEvaluationResult, previousEvaluations []*keptnv2.EvaluationFinishedEventData, config *sloAnomalyDetection) map[string]*sliAnomaly {
+	anomalies := map[string]*sliAnomaly{}
+	for _, result := range results {
+		if result.Value == nil || !result.Value.Success {
+			continue
+		}
+		var history []float64
+		for _, evaluation := range previousEvaluations {
+			for _, previousResult := range evaluation.Evaluation.IndicatorResults {
+				if previousResult.Value != nil && previousResult.Value.Success && previousResult.Value.Metric == result.Value.Metric {
+					history = append(history, previousResult.Value.Value)
+				}
+			}
+		}
+		if len(history) < minAnomalyDetectionResults {
+			continue
+		}
+
+		mean := calculateAverage(history)
+		stdDev := calculateStdDev(history, mean)
+		deviation := math.Abs(result.Value.Value - mean)
+
+		anomaly := &sliAnomaly{
+			Mean:            mean,
+			StdDev:          stdDev,
+			NumberOfResults: len(history),
+		}
+		if stdDev == 0 {
+			// all historical values are identical - any deviation is considered an anomaly
+			if deviation > 0 {
+				anomaly.Score = config.Threshold
+				anomaly.IsAnomaly = true
+			}
+		} else {
+			anomaly.Score = deviation / stdDev
+			anomaly.IsAnomaly = anomaly.Score >= config.Threshold
+		}
+		anomalies[result.Value.Metric] = anomaly
+	}
+	return anomalies
+}
+
+// escalateAnomalies turns passed and info SLIs with anomalous values into warnings. Passed SLIs only achieve half of their weight,
+// as if they had met their warning criteria
+func escalateAnomalies(results []*keptnv2.SLIEvaluationResult, anomalies map[string]*sliAnomaly, sloConfig *keptn.ServiceLevelObjectives) {
+	for _, result := range results {
+		if result.Value == nil {
+			continue
+		}
+		anomaly, ok := anomalies[result.Value.Metric]
+		if !ok || !anomaly.IsAnomaly {
+			continue
+		}
+		switch result.Status {
+		case "pass":
+			result.Status = "warning"
+			result.Score = 0.5 * float64(getObjectiveWeight(sloConfig, result.Value.Metric))
+		case "info":
+			result.Status = "warning"
+		}
+	}
+}
+
+func getObjectiveWeight(sloConfig *keptn.ServiceLevelObjectives, sli string) int {
+	for _, objective := range sloConfig.Objectives {
+		if objective.SLI == sli {
+			return objective.Weight
+		}
+	}
+	return 1
+}
+
+func calculateStdDev(values []float64, mean float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
@@ -0,0 +1,94 @@
+package event_handler
+
+import (
+	"testing"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseAnomalyDetection(t *testing.T) {
+	got, err := parseAnomalyDetection([]byte("objectives: []"))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = parseAnomalyDetection([]byte("anomaly_detection: {}"))
+	require.NoError(t, err)
+	assert.Equal(t, &sloAnomalyDetection{NumberOfResults: defaultAnomalyDetectionResults, Threshold: defaultAnomalyDetectionThreshold}, got)
+
+	got, err = parseAnomalyDetection([]byte("anomaly_detection:\n  number_of_results: 5\n  threshold: 2\n  escalate_to_warning: true"))
+	require.NoError(t, err)
+	assert.Equal(t, &sloAnomalyDetection{NumberOfResults: 5, Threshold: 2, EscalateToWarning: true}, got)
+}
+
+func Test_detectAnomalies(t *testing.T) {
+	newPreviousEvaluation := func(responseTime, errorRate float64) *keptnv2.EvaluationFinishedEventData {
+		return &keptnv2.EvaluationFinishedEventData{
+			Evaluation: keptnv2.EvaluationDetails{
+				IndicatorResults: []*keptnv2.SLIEvaluationResult{
+					{Value: &keptnv2.SLIResult{Metric: "response_time", Value: responseTime, Success: true}},
+					{Value: &keptnv2.SLIResult{Metric: "error_rate", Value: errorRate, Success: true}},
+				},
+			},
+		}
+	}
+	previousEvaluations := []*keptnv2.EvaluationFinishedEventData{
+		newPreviousEvaluation(100, 0),
+		newPreviousEvaluation(110, 0),
+		newPreviousEvaluation(90, 0),
+		newPreviousEvaluation(100, 0),
+	}
+
+	results := []*keptnv2.SLIEvaluationResult{
+		{Value: &keptnv2.SLIResult{Metric: "response_time", Value: 200, Success: true}, Status: "pass", Score: 2},
+		{Value: &keptnv2.SLIResult{Metric: "error_rate", Value: 0, Success: true}, Status: "info"},
+		{Value: &keptnv2.SLIResult{Metric: "throughput", Value: 10, Success: true}, Status: "info"},
+	}
+
+	anomalies := detectAnomalies(results, previousEvaluations, &sloAnomalyDetection{Threshold: 3})
+
+	require.Len(t, anomalies, 2)
+	assert.True(t, anomalies["response_time"].IsAnomaly)
+	assert.Equal(t, 100.0, anomalies["response_time"].Mean)
+	assert.InDelta(t, 14.14, anomalies["response_time"].Score, 0.01)
+	assert.Equal(t, 4, anomalies["response_time"].NumberOfResults)
+	assert.False(t, anomalies["error_rate"].IsAnomaly)
+	assert.Equal(t, 0.0, anomalies["error_rate"].Score)
+	// not enough historical values for throughput
+	assert.Nil(t, anomalies["throughput"])
+
+	escalateAnomalies(results, anomalies, &keptn.ServiceLevelObjectives{
+		Objectives: []*keptn.SLO{{SLI: "response_time", Weight: 2}, {SLI: "error_rate", Weight: 1}},
+	})
+	assert.Equal(t, "warning", results[0].Status)
+	assert.Equal(t, 1.0, results[0].Score)
+	assert.Equal(t, "info", results[1].Status)
+}
+
+func Test_detectAnomaliesWithConstantHistory(t *testing.T) {
+	previousEvaluations := []*keptnv2.EvaluationFinishedEventData{}
+	for i := 0; i < 3; i++ {
+		previousEvaluations = append(previousEvaluations, &keptnv2.EvaluationFinishedEventData{
+			Evaluation: keptnv2.EvaluationDetails{
+				IndicatorResults: []*keptnv2.SLIEvaluationResult{
+					{Value: &keptnv2.SLIResult{Metric: "error_rate", Value: 0, Success: true}},
+				},
+			},
+		})
+	}
+	results := []*keptnv2.SLIEvaluationResult{
+		{Value: &keptnv2.SLIResult{Metric: "error_rate", Value: 1, Success: true}, Status: "info"},
+	}
+
+	anomalies := detectAnomalies(results, previousEvaluations, &sloAnomalyDetection{Threshold: 3})
+
+	require.NotNil(t, anomalies["error_rate"])
+	assert.True(t, anomalies["error_rate"].IsAnomaly)
+	assert.Equal(t, 3.0, anomalies["error_rate"].Score)
+
+	escalateAnomalies(results, anomalies, &keptn.ServiceLevelObjectives{})
+	assert.Equal(t, "warning", results[0].Status)
+	assert.Equal(t, 0.0, results[0].Score)
+}
@@ -0,0 +1,176 @@
+package event_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+// maxBaselineDeploymentCandidates is the number of recent deployments that are inspected when looking up the baseline for an artifact version
+const maxBaselineDeploymentCandidates = 50
+
+// comparisonBaseline pins the evaluation(s) the SLI results are compared with, instead of using the last N evaluations.
+// It is configured via the baseline property of the comparison section in the slo.yaml, e.g.:
+//
+//	comparison:
+//	  baseline:
+//	    labels:
+//	      baseline: "true"
+//
+// Exactly one of KeptnContext, Labels or Version has to be set
+type comparisonBaseline struct {
+	// KeptnContext pins the baseline to the evaluation of a specific Keptn context
+	KeptnContext string `yaml:"keptn_context"`
+	// Labels pins the baseline to the latest evaluations carrying all the given labels
+	Labels map[string]string `yaml:"labels"`
+	// Version pins the baseline to the latest evaluation of the given artifact version
+	Version string `yaml:"version"`
+	// Stage is the stage the baseline is taken from. If empty, the stage of the current evaluation is used
+	Stage string `yaml:"stage"`
+}
+
+// usedBaseline describes the baseline the lighthouse-service has used for an evaluation
+type usedBaseline struct {
+	KeptnContext string            `json:"keptnContext,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Version      string            `json:"version,omitempty"`
+	Stage        string            `json:"stage"`
+	// KeptnContexts contains the Keptn contexts of the evaluations the results have been compared with
+	KeptnContexts []string `json:"keptnContexts"`
+	// Found indicates whether an evaluation matching the baseline could be found
+	Found bool `json:"found"`
+}
+
+type sloBaselineConfig struct {
+	Comparison *struct {
+		Baseline *comparisonBaseline `yaml:"baseline"`
+	} `yaml:"comparison"`
+}
+
+// parseComparisonBaseline returns the baseline configured in the comparison section of the given SLO file, or nil if no baseline is configured
+func parseComparisonBaseline(sloFileContent []byte) (*comparisonBaseline, error) {
+	config := &sloBaselineConfig{}
+	if err := yaml.Unmarshal(sloFileContent, config); err != nil {
+		return nil, err
+	}
+	if config.Comparison == nil || config.Comparison.Baseline == nil {
+		return nil, nil
+	}
+	baseline := config.Comparison.Baseline
+	if err := baseline.validate(); err != nil {
+		return nil, err
+	}
+	return baseline, nil
+}
+
+func (b *comparisonBaseline) validate() error {
+	configured := 0
+	if b.KeptnContext != "" {
+		configured++
+	}
+	if len(b.Labels) > 0 {
+		configured++
+	}
+	if b.Version != "" {
+		configured++
+	}
+	if configured != 1 {
+		return errors.New("invalid comparison baseline: exactly one of keptn_context, labels or version must be set")
+	}
+	return nil
+}
+
+// getBaselineEvaluations gets the evaluation.finished events matching the given baseline from mongodb-datastore
+func (eh *EvaluateSLIHandler) getBaselineEvaluations(e *keptnv2.GetSLIFinishedEventData, baseline *comparisonBaseline, numberOfResults int) ([]*keptnv2.EvaluationFinishedEventData, []string, *usedBaseline, error) {
+	used := &usedBaseline{
+		KeptnContext: baseline.KeptnContext,
+		Labels:       baseline.Labels,
+		Version:      baseline.Version,
+		Stage:        baseline.Stage,
+	}
+	if used.Stage == "" {
+		used.Stage = e.Stage
+	}
+
+	filter := []string{
+		"data.project:" + url.QueryEscape(e.Project),
+		"data.stage:" + url.QueryEscape(used.Stage),
+		"data.service:" + url.QueryEscape(e.Service),
+	}
+
+	switch {
+	case baseline.Version != "":
+		keptnContext, err := eh.getKeptnContextOfVersion(e.Project, used.Stage, e.Service, baseline.Version)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if keptnContext == "" {
+			return nil, nil, used, nil
+		}
+		used.KeptnContext = keptnContext
+		filter = append(filter, "shkeptncontext:"+url.QueryEscape(keptnContext))
+		numberOfResults = 1
+	case baseline.KeptnContext != "":
+		filter = append(filter, "shkeptncontext:"+url.QueryEscape(baseline.KeptnContext))
+		numberOfResults = 1
+	default:
+		// sort the label keys to get a stable query
+		keys := make([]string, 0, len(baseline.Labels))
+		for key := range baseline.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			filter = append(filter, "data.labels."+url.QueryEscape(key)+":"+url.QueryEscape(baseline.Labels[key]))
+		}
+	}
+
+	queryString := fmt.Sprintf("source=%s&limit=%d&excludeInvalidated=true&filter=%s",
+		"lighthouse-service", numberOfResults, strings.Join(filter, "%20AND%20"))
+
+	result, err := queryDatastore(eh.HTTPClient, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), queryString)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	evaluations, eventIDs, keptnContexts := parseEvaluationFinishedEvents(result, numberOfResults)
+	used.KeptnContexts = keptnContexts
+	used.Found = len(evaluations) > 0
+	return evaluations, eventIDs, used, nil
+}
+
+// getKeptnContextOfVersion returns the Keptn context of the latest deployment of the given artifact version.
+// The version either matches the complete image of the deployment, or its tag
+func (eh *EvaluateSLIHandler) getKeptnContextOfVersion(project, stage, service, version string) (string, error) {
+	queryString := fmt.Sprintf("limit=%d&filter=data.project:%s%%20AND%%20data.stage:%s%%20AND%%20data.service:%s",
+		maxBaselineDeploymentCandidates, url.QueryEscape(project), url.QueryEscape(stage), url.QueryEscape(service))
+
+	result, err := queryDatastore(eh.HTTPClient, keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName), queryString)
+	if err != nil {
+		return "", err
+	}
+
+	for _, event := range result.Events {
+		bytes, err := json.Marshal(event.Data)
+		if err != nil {
+			continue
+		}
+		deploymentData := &keptnv2.DeploymentTriggeredEventData{}
+		if err := json.Unmarshal(bytes, deploymentData); err != nil {
+			continue
+		}
+		image, ok := deploymentData.ConfigurationChange.Values["image"].(string)
+		if !ok {
+			continue
+		}
+		if image == version || strings.HasSuffix(image, ":"+version) {
+			return event.Shkeptncontext, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,180 @@
+package event_handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseComparisonBaseline(t *testing.T) {
+	tests := []struct {
+		name    string
+		slo     string
+		want    *comparisonBaseline
+		wantErr bool
+	}{
+		{
+			name: "no baseline",
+			slo: `comparison:
+  compare_with: single_result`,
+			want: nil,
+		},
+		{
+			name: "no comparison",
+			slo:  `objectives: []`,
+			want: nil,
+		},
+		{
+			name: "label baseline",
+			slo: `comparison:
+  baseline:
+    labels:
+      baseline: "true"`,
+			want: &comparisonBaseline{Labels: map[string]string{"baseline": "true"}},
+		},
+		{
+			name: "version baseline in other stage",
+			slo: `comparison:
+  baseline:
+    version: 0.13.1
+    stage: hardening`,
+			want: &comparisonBaseline{Version: "0.13.1", Stage: "hardening"},
+		},
+		{
+			name: "multiple baselines",
+			slo: `comparison:
+  baseline:
+    keptn_context: my-context
+    version: 0.13.1`,
+			wantErr: true,
+		},
+		{
+			name: "empty baseline",
+			slo: `comparison:
+  baseline:
+    stage: hardening`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseComparisonBaseline([]byte(tt.slo))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluateSLIHandler_getBaselineEvaluations(t *testing.T) {
+	var receivedQueries []string
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedQueries = append(receivedQueries, r.URL.Path+"?"+r.URL.Query().Get("filter"))
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(200)
+
+			result := datastoreResult{}
+			if strings.HasSuffix(r.URL.Path, keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName)) {
+				result.Events = append(result.Events, datastoreEvent{
+					Data: keptnv2.DeploymentTriggeredEventData{
+						ConfigurationChange: keptnv2.ConfigurationChange{Values: map[string]interface{}{"image": "carts:0.13.2"}},
+					},
+					ID:             "deployment-2",
+					Shkeptncontext: "context-2",
+				}, datastoreEvent{
+					Data: keptnv2.DeploymentTriggeredEventData{
+						ConfigurationChange: keptnv2.ConfigurationChange{Values: map[string]interface{}{"image": "carts:0.13.1"}},
+					},
+					ID:             "deployment-1",
+					Shkeptncontext: "context-1",
+				})
+			} else {
+				result.Events = append(result.Events, datastoreEvent{
+					Data: keptnv2.EvaluationFinishedEventData{
+						EventData: keptnv2.EventData{Project: "sockshop", Stage: "hardening", Service: "carts"},
+					},
+					ID:             "evaluation-1",
+					Shkeptncontext: "context-1",
+				})
+			}
+			marshal, _ := json.Marshal(&result)
+			w.Write(marshal)
+		}),
+	)
+	defer ts.Close()
+
+	t.Setenv("MONGODB_DATASTORE", strings.TrimPrefix(ts.URL, "http://"))
+
+	e := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "production", Service: "carts"},
+	}
+
+	tests := []struct {
+		name        string
+		baseline    *comparisonBaseline
+		wantQueries []string
+		wantUsed    *usedBaseline
+	}{
+		{
+			name:     "keptn context",
+			baseline: &comparisonBaseline{KeptnContext: "context-1"},
+			wantQueries: []string{
+				"/event/type/sh.keptn.event.evaluation.finished?data.project:sockshop AND data.stage:production AND data.service:carts AND shkeptncontext:context-1",
+			},
+			wantUsed: &usedBaseline{KeptnContext: "context-1", Stage: "production", KeptnContexts: []string{"context-1"}, Found: true},
+		},
+		{
+			name:     "labels",
+			baseline: &comparisonBaseline{Labels: map[string]string{"baseline": "true", "approved": "yes"}},
+			wantQueries: []string{
+				"/event/type/sh.keptn.event.evaluation.finished?data.project:sockshop AND data.stage:production AND data.service:carts AND data.labels.approved:yes AND data.labels.baseline:true",
+			},
+			wantUsed: &usedBaseline{Labels: map[string]string{"baseline": "true", "approved": "yes"}, Stage: "production", KeptnContexts: []string{"context-1"}, Found: true},
+		},
+		{
+			name:     "version from other stage",
+			baseline: &comparisonBaseline{Version: "0.13.1", Stage: "hardening"},
+			wantQueries: []string{
+				"/event/type/sh.keptn.event.deployment.triggered?data.project:sockshop AND data.stage:hardening AND data.service:carts",
+				"/event/type/sh.keptn.event.evaluation.finished?data.project:sockshop AND data.stage:hardening AND data.service:carts AND shkeptncontext:context-1",
+			},
+			wantUsed: &usedBaseline{KeptnContext: "context-1", Version: "0.13.1", Stage: "hardening", KeptnContexts: []string{"context-1"}, Found: true},
+		},
+		{
+			name:     "unknown version",
+			baseline: &comparisonBaseline{Version: "0.14.0"},
+			wantQueries: []string{
+				"/event/type/sh.keptn.event.deployment.triggered?data.project:sockshop AND data.stage:production AND data.service:carts",
+			},
+			wantUsed: &usedBaseline{Version: "0.14.0", Stage: "production", Found: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedQueries = nil
+			eh := &EvaluateSLIHandler{HTTPClient: &http.Client{}}
+
+			evaluations, eventIDs, used, err := eh.getBaselineEvaluations(e, tt.baseline, 3)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantQueries, receivedQueries)
+			assert.Equal(t, tt.wantUsed, used)
+			if tt.wantUsed.Found {
+				require.Len(t, evaluations, 1)
+				assert.Equal(t, []string{"evaluation-1"}, eventIDs)
+			} else {
+				assert.Empty(t, evaluations)
+			}
+		})
+	}
+}
@@ -75,29 +75,91 @@ type SLOFileRetriever struct {
 }
 
 func (sr *SLOFileRetriever) GetSLOs(project, stage, service, commitID string) (*keptn.ServiceLevelObjectives, []byte, error) {
+	slo, sloFileContent, _, err := sr.GetSLOsWithSources(project, stage, service, commitID)
+	return slo, sloFileContent, err
+}
+
+// GetSLOsWithSources retrieves the slo.yaml files of the project, stage and service and merges them, with the more specific levels
+// overriding the less specific ones (see mergeSLOFiles). Besides the merged SLOs, it returns the SLO files that have been merged.
+// The commitID only applies to the stage and service level, since the project level files are not part of the stage's history
+func (sr *SLOFileRetriever) GetSLOsWithSources(project, stage, service, commitID string) (*keptn.ServiceLevelObjectives, []byte, []sloSource, error) {
 	commitOption := url.Values{}
 	if commitID != "" {
 		commitOption.Add("gitCommitID", commitID)
 	}
+
+	var sloFiles [][]byte
+	var sources []sloSource
+
+	inheritedScopes := []struct {
+		level   string
+		scope   *utils.ResourceScope
+		options []utils.URIOption
+	}{
+		{level: sloLevelProject, scope: utils.NewResourceScope().Project(project).Resource("slo.yaml")},
+		{level: sloLevelStage, scope: utils.NewResourceScope().Project(project).Stage(stage).Resource("slo.yaml"), options: []utils.URIOption{utils.AppendQuery(commitOption)}},
+	}
+	for _, inherited := range inheritedScopes {
+		// SLO files on project and stage level are optional, but any other error must not be ignored
+		sloFile, err := sr.ResourceHandler.GetResource(*inherited.scope, inherited.options...)
+		if err != nil {
+			if errors.Is(err, utils.ResourceNotFoundError) {
+				continue
+			}
+			if strings.Contains(strings.ToLower(err.Error()), "could not check out ") {
+				return nil, nil, nil, ErrConfigService
+			}
+			return nil, nil, nil, fmt.Errorf("could not retrieve SLO file on %s level: %w", inherited.level, err)
+		}
+		if sloFile == nil || sloFile.ResourceContent == "" {
+			continue
+		}
+		sloFiles = append(sloFiles, []byte(sloFile.ResourceContent))
+		sources = append(sources, newSLOSource(inherited.level, sloFile))
+	}
+
 	resourceScope := *utils.NewResourceScope().Project(project).Stage(stage).Service(service).Resource("slo.yaml")
 	sloFile, err := sr.ResourceHandler.GetResource(resourceScope, utils.AppendQuery(commitOption))
 	if err != nil {
 		_, serviceErr := sr.ServiceHandler.GetService(project, stage, service)
 		if serviceErr != nil {
-			return nil, nil, checkNotFound(serviceErr, err)
+			return nil, nil, nil, checkNotFound(serviceErr, err)
 		}
 	}
-	if sloFile == nil || sloFile.ResourceContent == "" {
-		return nil, nil, ErrSLOFileNotFound
+	if sloFile != nil && sloFile.ResourceContent != "" {
+		sloFiles = append(sloFiles, []byte(sloFile.ResourceContent))
+		sources = append(sources, newSLOSource(sloLevelService, sloFile))
+	}
+	if len(sloFiles) == 0 {
+		return nil, nil, nil, ErrSLOFileNotFound
 	}
 
-	slo, err := parseSLO([]byte(sloFile.ResourceContent))
+	sloFileContent, err := mergeSLOFiles(sloFiles)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Could not merge SLO files for service %s in stage %s in project %s: %w", service, stage, project, err)
+	}
+
+	slo, err := parseSLO(sloFileContent)
 
 	if err != nil {
-		return nil, nil, errors.New("Could not parse SLO file for service " + service + " in stage " + stage + " in project " + project)
+		return nil, nil, nil, errors.New("Could not parse SLO file for service " + service + " in stage " + stage + " in project " + project)
 	}
 	// return also slo.yaml as a plain file to avoid confusion due to defaulted values (see https://github.com/keptn/keptn/issues/1495)
-	return slo, []byte(sloFile.ResourceContent), nil
+	return slo, sloFileContent, sources, nil
+}
+
+func newSLOSource(level string, sloFile *apimodels.Resource) sloSource {
+	source := sloSource{
+		Level:       level,
+		ResourceURI: "slo.yaml",
+	}
+	if sloFile.ResourceURI != nil {
+		source.ResourceURI = *sloFile.ResourceURI
+	}
+	if sloFile.Metadata != nil {
+		source.CommitID = sloFile.Metadata.Version
+	}
+	return source
 }
 
 func checkNotFound(notFound, checkOut error) error {
@@ -25,13 +25,17 @@ import (
 )
 
 type datastoreResult struct {
-	NextPageKey string `json:"nextPageKey"`
-	TotalCount  int    `json:"totalCount"`
-	PageSize    int    `json:"pageSize"`
-	Events      []struct {
-		Data interface{} `json:"data"`
-		ID   string      `json:"id"`
-	}
+	NextPageKey string           `json:"nextPageKey"`
+	TotalCount  int              `json:"totalCount"`
+	PageSize    int              `json:"pageSize"`
+	Events      []datastoreEvent `json:"events"`
+}
+
+type datastoreEvent struct {
+	Data           interface{} `json:"data"`
+	ID             string      `json:"id"`
+	Shkeptncontext string      `json:"shkeptncontext"`
+	Gitcommitid    string      `json:"gitcommitid"`
 }
 
 type criteriaObject struct {
@@ -147,7 +151,7 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 	}
 
 	// compare the results based on the evaluation strategy
-	sloConfig, sloFileContent, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
+	sloConfig, sloFileContent, sloSources, err := eh.SLOFileRetriever.GetSLOsWithSources(e.Project, e.Stage, e.Service, commitID)
 
 	if err != nil {
 		if err == ErrSLOFileNotFound {
@@ -167,7 +171,19 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 		numberOfPreviousResults = sloConfig.Comparison.NumberOfComparisonResults
 	}
 
-	previousEvaluationEvents, comparisonEventIDs, err := eh.getPreviousEvaluations(e, numberOfPreviousResults, sloConfig.Comparison.IncludeResultWithScore)
+	baseline, err := parseComparisonBaseline(sloFileContent)
+	if err != nil {
+		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
+	}
+
+	var previousEvaluationEvents []*keptnv2.EvaluationFinishedEventData
+	var comparisonEventIDs []string
+	var baselineUsed *usedBaseline
+	if baseline != nil {
+		previousEvaluationEvents, comparisonEventIDs, baselineUsed, err = eh.getBaselineEvaluations(e, baseline, numberOfPreviousResults)
+	} else {
+		previousEvaluationEvents, comparisonEventIDs, err = eh.getPreviousEvaluations(e, numberOfPreviousResults, sloConfig.Comparison.IncludeResultWithScore)
+	}
 	if err != nil {
 		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
 	}
@@ -183,6 +199,11 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 	evaluationResult.Labels = e.Labels
 	evaluationResult.Evaluation.ComparedEvents = comparisonEventIDs
 
+	anomalies, err := eh.getAnomalies(e, evaluationResult, sloConfig, sloFileContent)
+	if err != nil {
+		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
+	}
+
 	// calculate the total score
 	err = calculateScore(maximumAchievableScore, evaluationResult, sloConfig, keySLIFailed)
 	if err != nil {
@@ -192,7 +213,12 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 
 	evaluationResult.Evaluation.SLOFileContent = base64.StdEncoding.EncodeToString(sloFileContent)
 
-	return sendEvent(shkeptncontext, triggeredEvents[0].ID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, evaluationResult)
+	evaluationFinishedData := newEvaluationFinishedEventData(evaluationResult)
+	evaluationFinishedData.Evaluation.Baseline = baselineUsed
+	evaluationFinishedData.Evaluation.setAnomalies(anomalies)
+	evaluationFinishedData.Evaluation.SLOSources = sloSources
+
+	return sendEvent(shkeptncontext, triggeredEvents[0].ID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, evaluationFinishedData)
 }
 
 func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, previousEvaluationEvents []*keptnv2.EvaluationFinishedEventData) (*keptnv2.EvaluationFinishedEventData, float64, bool) {
@@ -619,11 +645,26 @@ func parseCriteriaString(criteria string) (*criteriaObject, error) {
 	return c, nil
 }
 
+// getAnomalies detects anomalous SLI values based on the previous evaluations, if anomaly detection is configured in the SLO file.
+// If configured, SLIs with anomalous values are escalated to warnings
+func (eh *EvaluateSLIHandler) getAnomalies(e *keptnv2.GetSLIFinishedEventData, evaluationResult *keptnv2.EvaluationFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, sloFileContent []byte) (map[string]*sliAnomaly, error) {
+	anomalyDetection, err := parseAnomalyDetection(sloFileContent)
+	if err != nil || anomalyDetection == nil {
+		return nil, err
+	}
+	previousEvaluations, _, err := eh.getPreviousEvaluations(e, anomalyDetection.NumberOfResults, "all")
+	if err != nil {
+		return nil, err
+	}
+	anomalies := detectAnomalies(evaluationResult.Evaluation.IndicatorResults, previousEvaluations, anomalyDetection)
+	if anomalyDetection.EscalateToWarning {
+		escalateAnomalies(evaluationResult.Evaluation.IndicatorResults, anomalies, sloConfig)
+	}
+	return anomalies, nil
+}
+
 // gets previous evaluation.finished events from mongodb-datastore
 func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEventData, numberOfPreviousResults int, includeResult string) ([]*keptnv2.EvaluationFinishedEventData, []string, error) {
-	var evaluationDoneEvents []*keptnv2.EvaluationFinishedEventData
-	var eventIDs []string
-
 	// previous results are fetched from mongodb datastore with source=lighthouse-service
 	queryString := fmt.Sprintf("source=%s&limit=%d&excludeInvalidated=true&",
 		"lighthouse-service", numberOfPreviousResults)
@@ -644,26 +685,47 @@ func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEv
 
 	queryString = queryString + filter
 
-	req, err := http.NewRequest("GET", getDatastoreURL()+"/event/type/"+keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)+"?"+queryString, nil)
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := eh.HTTPClient.Do(req)
+	previousEvents, err := queryDatastore(eh.HTTPClient, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), queryString)
 	if err != nil {
 		return nil, nil, err
 	}
+	evaluationDoneEvents, eventIDs, _ := parseEvaluationFinishedEvents(previousEvents, numberOfPreviousResults)
+	return evaluationDoneEvents, eventIDs, nil
+}
+
+// queryDatastore gets the events of the given type matching the query from mongodb-datastore
+func queryDatastore(httpClient *http.Client, eventType string, queryString string) (*datastoreResult, error) {
+	req, err := http.NewRequest("GET", getDatastoreURL()+"/event/type/"+eventType+"?"+queryString, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 	body, _ := ioutil.ReadAll(resp.Body)
 
 	if resp.StatusCode != 200 {
-		return nil, nil, errors.New("could not retrieve previous evaluation.finished events")
+		return nil, fmt.Errorf("could not retrieve previous %s events", eventType)
 	}
-	previousEvents := &datastoreResult{}
-	err = json.Unmarshal(body, previousEvents)
+	result := &datastoreResult{}
+	err = json.Unmarshal(body, result)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
+	return result, nil
+}
+
+// parseEvaluationFinishedEvents returns at most limit evaluation.finished event payloads of the given datastore result, together with their event IDs and Keptn contexts
+func parseEvaluationFinishedEvents(result *datastoreResult, limit int) ([]*keptnv2.EvaluationFinishedEventData, []string, []string) {
+	var evaluationDoneEvents []*keptnv2.EvaluationFinishedEventData
+	var eventIDs []string
+	var keptnContexts []string
 
 	// iterate over previous events
-	for _, event := range previousEvents.Events {
+	for _, event := range result.Events {
 		bytes, err := json.Marshal(event.Data)
 		if err != nil {
 			continue
@@ -676,10 +738,11 @@ func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEv
 		}
 		evaluationDoneEvents = append(evaluationDoneEvents, &evaluationDoneEvent)
 		eventIDs = append(eventIDs, event.ID)
-		if len(evaluationDoneEvents) == numberOfPreviousResults {
-			return evaluationDoneEvents, eventIDs, nil
+		keptnContexts = append(keptnContexts, event.Shkeptncontext)
+		if len(evaluationDoneEvents) == limit {
+			break
 		}
 	}
 
-	return evaluationDoneEvents, eventIDs, nil
+	return evaluationDoneEvents, eventIDs, keptnContexts
 }
@@ -3221,10 +3221,7 @@ func TestEvaluateSLIHandler_getPreviousEvaluations(t *testing.T) {
 				NextPageKey: "",
 				TotalCount:  1,
 				PageSize:    1,
-				Events: []struct {
-					Data interface{} `json:"data"`
-					ID   string      `json:"id"`
-				}{
+				Events: []datastoreEvent{
 					{
 						Data: &keptnv2.EvaluationFinishedEventData{
 							EventData: keptnv2.EventData{
@@ -3414,7 +3411,11 @@ func TestEvaluateSLIHandler_HandleEvent(t *testing.T) {
 				SLOFileRetriever: SLOFileRetriever{
 					ResourceHandler: &event_handler_mock.ResourceHandlerMock{
 						GetResourceFunc: func(scope keptnapi.ResourceScope, options ...keptnapi.URIOption) (*models.Resource, error) {
-							commitID = strings.TrimPrefix(options[0](commitID), "?gitCommitID=")
+							if len(options) == 0 {
+								// no project level SLO file
+								return nil, nil
+							}
+							commitID = strings.TrimPrefix(options[0](""), "?gitCommitID=")
 							myres := models.Resource{Metadata: &models.Version{Version: commitID}}
 							return &myres, nil
 						},
@@ -0,0 +1,138 @@
+package event_handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+// maxCachedEvaluationCandidates is the number of recent evaluations that are inspected when looking for an evaluation whose results can be reused
+const maxCachedEvaluationCandidates = 20
+
+// derivedEvaluation references the evaluation whose results have been reused for an evaluation
+type derivedEvaluation struct {
+	EventID      string `json:"eventId"`
+	KeptnContext string `json:"keptnContext"`
+}
+
+type sloCacheConfig struct {
+	ReuseResults bool `yaml:"reuse_results"`
+}
+
+// evaluationTriggeredOptions contains lighthouse specific options of an evaluation.triggered event
+type evaluationTriggeredOptions struct {
+	Evaluation struct {
+		ReuseResults *bool `json:"reuseResults"`
+	} `json:"evaluation"`
+}
+
+// isResultReuseEnabled determines whether the results of an existing evaluation may be reused.
+// The reuseResults property of the evaluation.triggered event takes precedence over the reuse_results property of the SLO file
+func isResultReuseEnabled(eventData []byte, sloFileContent []byte) bool {
+	options := &evaluationTriggeredOptions{}
+	if err := json.Unmarshal(eventData, options); err == nil && options.Evaluation.ReuseResults != nil {
+		return *options.Evaluation.ReuseResults
+	}
+	if len(sloFileContent) == 0 {
+		return false
+	}
+	config := &sloCacheConfig{}
+	if err := yaml.Unmarshal(sloFileContent, config); err != nil {
+		return false
+	}
+	return config.ReuseResults
+}
+
+// findCachedEvaluation looks for a finished evaluation of the same service that has been conducted for an identical timeframe,
+// using the same SLO file (and commit ID, if available) and indicators. It returns nil if there is no such evaluation
+func findCachedEvaluation(httpClient *http.Client, e *keptnv2.EvaluationTriggeredEventData, start, end, commitID string, sloFileContent []byte, indicators []string) (*evaluationFinishedEventData, *derivedEvaluation, error) {
+	// the timestamps can not be part of the datastore filter since it does not support values containing colons,
+	// therefore the most recent evaluations are fetched and compared here
+	queryString := fmt.Sprintf("source=%s&limit=%d&excludeInvalidated=true&filter=data.project:%s%%20AND%%20data.stage:%s%%20AND%%20data.service:%s",
+		"lighthouse-service", maxCachedEvaluationCandidates, url.QueryEscape(e.Project), url.QueryEscape(e.Stage), url.QueryEscape(e.Service))
+
+	result, err := queryDatastore(httpClient, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), queryString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encodedSLOFileContent := base64.StdEncoding.EncodeToString(sloFileContent)
+	for _, event := range result.Events {
+		bytes, err := json.Marshal(event.Data)
+		if err != nil {
+			continue
+		}
+		evaluation := &evaluationFinishedEventData{}
+		if err := json.Unmarshal(bytes, evaluation); err != nil {
+			continue
+		}
+		if evaluation.Status != keptnv2.StatusSucceeded ||
+			evaluation.Evaluation.TimeStart != start ||
+			evaluation.Evaluation.TimeEnd != end ||
+			evaluation.Evaluation.SLOFileContent != encodedSLOFileContent {
+			continue
+		}
+		if commitID != "" && event.Gitcommitid != "" && commitID != event.Gitcommitid {
+			continue
+		}
+		if !equalIndicators(evaluation.Evaluation.IndicatorResults, indicators) {
+			continue
+		}
+		derivedFrom := &derivedEvaluation{
+			EventID:      event.ID,
+			KeptnContext: event.Shkeptncontext,
+		}
+		if evaluation.Evaluation.DerivedFrom != nil {
+			// always reference the evaluation that has actually retrieved the SLIs
+			derivedFrom = evaluation.Evaluation.DerivedFrom
+		}
+		return evaluation, derivedFrom, nil
+	}
+	return nil, nil, nil
+}
+
+func equalIndicators(results []*sliEvaluationResult, indicators []string) bool {
+	if len(results) != len(indicators) {
+		return false
+	}
+	evaluated := make([]string, 0, len(results))
+	for _, result := range results {
+		if result == nil || result.Value == nil {
+			return false
+		}
+		evaluated = append(evaluated, result.Value.Metric)
+	}
+	expected := append([]string{}, indicators...)
+	sort.Strings(evaluated)
+	sort.Strings(expected)
+	for i := range evaluated {
+		if evaluated[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newDerivedEvaluationFinishedEventData creates the evaluation.finished payload of an evaluation whose results are taken from the given cached evaluation
+func newDerivedEvaluationFinishedEventData(e *keptnv2.EvaluationTriggeredEventData, cached *evaluationFinishedEventData, derivedFrom *derivedEvaluation) *evaluationFinishedEventData {
+	data := &evaluationFinishedEventData{
+		EventData: keptnv2.EventData{
+			Project: e.Project,
+			Stage:   e.Stage,
+			Service: e.Service,
+			Labels:  e.Labels,
+			Status:  cached.Status,
+			Result:  cached.Result,
+			Message: cached.Message,
+		},
+		Evaluation: cached.Evaluation,
+	}
+	data.Evaluation.DerivedFrom = derivedFrom
+	return data
+}
@@ -0,0 +1,145 @@
+package event_handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_isResultReuseEnabled(t *testing.T) {
+	tests := []struct {
+		name           string
+		eventData      string
+		sloFileContent string
+		want           bool
+	}{
+		{
+			name:      "not configured",
+			eventData: `{"project":"sockshop"}`,
+			want:      false,
+		},
+		{
+			name:           "enabled in SLO file",
+			eventData:      `{"project":"sockshop"}`,
+			sloFileContent: "reuse_results: true",
+			want:           true,
+		},
+		{
+			name:      "enabled in event",
+			eventData: `{"evaluation":{"reuseResults":true}}`,
+			want:      true,
+		},
+		{
+			name:           "event overrides SLO file",
+			eventData:      `{"evaluation":{"reuseResults":false}}`,
+			sloFileContent: "reuse_results: true",
+			want:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isResultReuseEnabled([]byte(tt.eventData), []byte(tt.sloFileContent)))
+		})
+	}
+}
+
+func Test_findCachedEvaluation(t *testing.T) {
+	sloFileContent := []byte("reuse_results: true")
+	newEvaluation := func(start, end string, status keptnv2.StatusType, metrics ...string) evaluationFinishedEventData {
+		evaluation := evaluationFinishedEventData{
+			EventData: keptnv2.EventData{Project: "sockshop", Stage: "dev", Service: "carts", Status: status, Result: keptnv2.ResultPass},
+		}
+		evaluation.Evaluation.TimeStart = start
+		evaluation.Evaluation.TimeEnd = end
+		evaluation.Evaluation.SLOFileContent = base64.StdEncoding.EncodeToString(sloFileContent)
+		for _, metric := range metrics {
+			evaluation.Evaluation.IndicatorResults = append(evaluation.Evaluation.IndicatorResults, &sliEvaluationResult{
+				SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+					Value: &keptnv2.SLIResult{Metric: metric, Value: 1, Success: true},
+				},
+			})
+		}
+		return evaluation
+	}
+
+	var returnedResult datastoreResult
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(200)
+			marshal, _ := json.Marshal(&returnedResult)
+			w.Write(marshal)
+		}),
+	)
+	defer ts.Close()
+
+	t.Setenv("MONGODB_DATASTORE", strings.TrimPrefix(ts.URL, "http://"))
+
+	e := &keptnv2.EvaluationTriggeredEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "dev", Service: "carts"},
+	}
+
+	tests := []struct {
+		name            string
+		events          []datastoreEvent
+		commitID        string
+		wantDerivedFrom *derivedEvaluation
+	}{
+		{
+			name: "identical evaluation available",
+			events: []datastoreEvent{
+				{Data: newEvaluation("start", "other-end", keptnv2.StatusSucceeded, "response_time", "error_rate"), ID: "id-1", Shkeptncontext: "context-1"},
+				{Data: newEvaluation("start", "end", keptnv2.StatusSucceeded, "error_rate", "response_time"), ID: "id-2", Shkeptncontext: "context-2"},
+			},
+			wantDerivedFrom: &derivedEvaluation{EventID: "id-2", KeptnContext: "context-2"},
+		},
+		{
+			name: "errored evaluation is not reused",
+			events: []datastoreEvent{
+				{Data: newEvaluation("start", "end", keptnv2.StatusErrored, "error_rate", "response_time"), ID: "id-1", Shkeptncontext: "context-1"},
+			},
+			wantDerivedFrom: nil,
+		},
+		{
+			name: "different indicators",
+			events: []datastoreEvent{
+				{Data: newEvaluation("start", "end", keptnv2.StatusSucceeded, "error_rate"), ID: "id-1", Shkeptncontext: "context-1"},
+			},
+			wantDerivedFrom: nil,
+		},
+		{
+			name: "different commit ID",
+			events: []datastoreEvent{
+				{Data: newEvaluation("start", "end", keptnv2.StatusSucceeded, "error_rate", "response_time"), ID: "id-1", Shkeptncontext: "context-1", Gitcommitid: "other-commit"},
+			},
+			commitID:        "my-commit",
+			wantDerivedFrom: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returnedResult = datastoreResult{Events: tt.events}
+
+			cached, derivedFrom, err := findCachedEvaluation(&http.Client{}, e, "start", "end", tt.commitID, sloFileContent, []string{"response_time", "error_rate"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDerivedFrom, derivedFrom)
+			if tt.wantDerivedFrom == nil {
+				assert.Nil(t, cached)
+				return
+			}
+			require.NotNil(t, cached)
+
+			derived := newDerivedEvaluationFinishedEventData(e, cached, derivedFrom)
+			assert.Equal(t, keptnv2.ResultPass, derived.Result)
+			assert.Equal(t, tt.wantDerivedFrom, derived.Evaluation.DerivedFrom)
+			assert.Len(t, derived.Evaluation.IndicatorResults, 2)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package event_handler
+
+import (
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// evaluationFinishedEventData is the payload of the evaluation.finished events sent by the lighthouse-service.
+// It extends keptnv2.EvaluationFinishedEventData with properties that are specific to the lighthouse-service.
+// Consumers that decode the payload into keptnv2.EvaluationFinishedEventData are not affected by the additional properties
+type evaluationFinishedEventData struct {
+	keptnv2.EventData
+	Evaluation evaluationDetails `json:"evaluation,omitempty"`
+}
+
+// evaluationDetails extends keptnv2.EvaluationDetails with lighthouse specific properties
+type evaluationDetails struct {
+	keptnv2.EvaluationDetails
+	// IndicatorResults replaces keptnv2.EvaluationDetails.IndicatorResults in the serialized payload
+	IndicatorResults []*sliEvaluationResult `json:"indicatorResults"`
+	// Baseline contains the baseline that has been used for comparing the SLI results
+	Baseline *usedBaseline `json:"baseline,omitempty"`
+	// DerivedFrom references the evaluation whose results have been reused, if the SLIs have not been retrieved for this evaluation
+	DerivedFrom *derivedEvaluation `json:"derivedFrom,omitempty"`
+	// SLOSources contains the SLO files that have been merged into the SLOs of the evaluation
+	SLOSources []sloSource `json:"sloSources,omitempty"`
+}
+
+// sliEvaluationResult extends keptnv2.SLIEvaluationResult with lighthouse specific properties
+type sliEvaluationResult struct {
+	keptnv2.SLIEvaluationResult
+	// Anomaly describes the deviation of the SLI value from its historical distribution, if anomaly detection is enabled
+	Anomaly *sliAnomaly `json:"anomaly,omitempty"`
+}
+
+func newEvaluationFinishedEventData(data *keptnv2.EvaluationFinishedEventData) *evaluationFinishedEventData {
+	result := &evaluationFinishedEventData{
+		EventData: data.EventData,
+		Evaluation: evaluationDetails{
+			EvaluationDetails: data.Evaluation,
+		},
+	}
+	for _, indicatorResult := range data.Evaluation.IndicatorResults {
+		if indicatorResult == nil {
+			continue
+		}
+		result.Evaluation.IndicatorResults = append(result.Evaluation.IndicatorResults, &sliEvaluationResult{SLIEvaluationResult: *indicatorResult})
+	}
+	result.Evaluation.EvaluationDetails.IndicatorResults = nil
+	return result
+}
+
+// setAnomalies annotates the indicator results with the given anomalies, using the metric name as key
+func (d *evaluationDetails) setAnomalies(anomalies map[string]*sliAnomaly) {
+	for _, indicatorResult := range d.IndicatorResults {
+		if indicatorResult.Value == nil {
+			continue
+		}
+		indicatorResult.Anomaly = anomalies[indicatorResult.Value.Metric]
+	}
+}
@@ -54,6 +54,8 @@ func NewEventHandler(ctx context.Context, event cloudevents.Event, kubeAPI kuber
 				ResourceHandler: resourceHandler,
 				ServiceHandler:  serviceHandler,
 			},
+			HTTPClient: &http.Client{},
+			EventStore: es(keptnHandler),
 		}, nil
 	case keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName):
 		return &EvaluateSLIHandler{
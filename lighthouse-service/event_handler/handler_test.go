@@ -54,6 +54,8 @@ func TestNewEventHandler(t *testing.T) {
 				Event:             incomingEvent,
 				KeptnHandler:      keptnHandler,
 				SLIProviderConfig: K8sSLIProviderConfig{KubeAPI: fake.NewSimpleClientset()},
+				HTTPClient:        &http.Client{},
+				EventStore:        keptnHandler.EventHandler,
 			},
 			wantErr: false,
 		},
@@ -0,0 +1,116 @@
+package event_handler
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	sloLevelProject = "project"
+	sloLevelStage   = "stage"
+	sloLevelService = "service"
+)
+
+// sloSource describes an SLO file that has been merged into the SLOs used for an evaluation
+type sloSource struct {
+	// Level is the level the SLO file has been defined on, i.e. project, stage or service
+	Level       string `json:"level"`
+	ResourceURI string `json:"resourceURI"`
+	CommitID    string `json:"commitID,omitempty"`
+}
+
+// mergeSLOFiles merges the given SLO files, which are ordered from the least to the most specific level.
+// The properties of a more specific SLO file override those of a less specific one:
+//   - objectives are merged by their sli; an objective replaces the objective with the same sli of a less specific level
+//   - filter entries are merged by their key
+//   - the properties of the comparison section are overridden one by one
+//   - all other properties, e.g. total_score, are replaced as a whole
+//
+// If only one SLO file is given, its content is returned unchanged
+func mergeSLOFiles(sloFiles [][]byte) ([]byte, error) {
+	if len(sloFiles) == 1 {
+		return sloFiles[0], nil
+	}
+
+	merged := map[string]interface{}{}
+	for _, sloFile := range sloFiles {
+		slo := map[string]interface{}{}
+		if err := yaml.Unmarshal(sloFile, &slo); err != nil {
+			return nil, err
+		}
+		for key, value := range slo {
+			switch key {
+			case "objectives":
+				objectives, err := mergeObjectives(merged[key], value)
+				if err != nil {
+					return nil, err
+				}
+				merged[key] = objectives
+			case "filter", "comparison":
+				merged[key] = mergeMaps(merged[key], value)
+			default:
+				merged[key] = value
+			}
+		}
+	}
+	return yaml.Marshal(merged)
+}
+
+func mergeMaps(base, override interface{}) interface{} {
+	baseMap, baseOK := base.(map[string]interface{})
+	overrideMap, overrideOK := override.(map[string]interface{})
+	if !baseOK || !overrideOK {
+		if override == nil {
+			return base
+		}
+		return override
+	}
+	result := map[string]interface{}{}
+	for key, value := range baseMap {
+		result[key] = value
+	}
+	for key, value := range overrideMap {
+		result[key] = value
+	}
+	return result
+}
+
+func mergeObjectives(base, override interface{}) ([]interface{}, error) {
+	baseObjectives, _ := base.([]interface{})
+	overrideObjectives, ok := override.([]interface{})
+	if !ok {
+		if override == nil {
+			return baseObjectives, nil
+		}
+		return nil, fmt.Errorf("objectives must be a list")
+	}
+
+	result := append([]interface{}{}, baseObjectives...)
+	for _, objective := range overrideObjectives {
+		sli := getObjectiveSLI(objective)
+		replaced := false
+		if sli != "" {
+			for i, existing := range result {
+				if getObjectiveSLI(existing) == sli {
+					result[i] = objective
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced {
+			result = append(result, objective)
+		}
+	}
+	return result, nil
+}
+
+func getObjectiveSLI(objective interface{}) string {
+	objectiveMap, ok := objective.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	sli, _ := objectiveMap["sli"].(string)
+	return sli
+}
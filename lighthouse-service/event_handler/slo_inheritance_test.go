@@ -0,0 +1,186 @@
+package event_handler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/go-utils/pkg/common/strutils"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
+)
+
+const projectSLO = `spec_version: "1.0"
+filter:
+  handler: "ItemsController.addToCart"
+comparison:
+  compare_with: "several_results"
+  number_of_comparison_results: 5
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<600"
+  - sli: error_rate
+    pass:
+      - criteria:
+          - "<=1"
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+
+const stageSLO = `comparison:
+  aggregate_function: p90
+objectives:
+  - sli: error_rate
+    pass:
+      - criteria:
+          - "=0"
+`
+
+const serviceSLO = `filter:
+  service: "carts-primary"
+objectives:
+  - sli: throughput
+total_score:
+  pass: "80%"
+`
+
+func Test_mergeSLOFiles(t *testing.T) {
+	merged, err := mergeSLOFiles([][]byte{[]byte(projectSLO), []byte(stageSLO), []byte(serviceSLO)})
+	require.NoError(t, err)
+
+	slo, err := parseSLO(merged)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"handler": "ItemsController.addToCart", "service": "carts-primary"}, slo.Filter)
+	assert.Equal(t, &keptn.SLOComparison{
+		CompareWith:               "several_results",
+		NumberOfComparisonResults: 5,
+		AggregateFunction:         "p90",
+		IncludeResultWithScore:    "all",
+	}, slo.Comparison)
+	assert.Equal(t, &keptn.SLOScore{Pass: "80%"}, slo.TotalScore)
+
+	require.Len(t, slo.Objectives, 3)
+	assert.Equal(t, "response_time_p95", slo.Objectives[0].SLI)
+	assert.Equal(t, "error_rate", slo.Objectives[1].SLI)
+	assert.Equal(t, []string{"=0"}, slo.Objectives[1].Pass[0].Criteria)
+	assert.Equal(t, "throughput", slo.Objectives[2].SLI)
+}
+
+func Test_mergeSLOFilesSingleFile(t *testing.T) {
+	merged, err := mergeSLOFiles([][]byte{[]byte(serviceSLO)})
+	require.NoError(t, err)
+	assert.Equal(t, serviceSLO, string(merged))
+}
+
+var errSLOResourceUnavailable = errors.New("503 service unavailable")
+
+func TestSLOFileRetriever_GetSLOsWithSources(t *testing.T) {
+	newResource := func(content, commitID string) *models.Resource {
+		return &models.Resource{
+			ResourceURI:     strutils.Stringp("slo.yaml"),
+			ResourceContent: content,
+			Metadata:        &models.Version{Version: commitID},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		resources    map[string]*models.Resource
+		resourceErrs map[string]error
+		wantSources  []sloSource
+		wantErr      error
+	}{
+		{
+			name: "all levels",
+			resources: map[string]*models.Resource{
+				sloLevelProject: newResource(projectSLO, "project-commit"),
+				sloLevelStage:   newResource(stageSLO, "stage-commit"),
+				sloLevelService: newResource(serviceSLO, "stage-commit"),
+			},
+			wantSources: []sloSource{
+				{Level: sloLevelProject, ResourceURI: "slo.yaml", CommitID: "project-commit"},
+				{Level: sloLevelStage, ResourceURI: "slo.yaml", CommitID: "stage-commit"},
+				{Level: sloLevelService, ResourceURI: "slo.yaml", CommitID: "stage-commit"},
+			},
+		},
+		{
+			name: "project level only",
+			resources: map[string]*models.Resource{
+				sloLevelProject: newResource(projectSLO, "project-commit"),
+			},
+			wantSources: []sloSource{
+				{Level: sloLevelProject, ResourceURI: "slo.yaml", CommitID: "project-commit"},
+			},
+		},
+		{
+			name:      "no SLO file",
+			resources: map[string]*models.Resource{},
+			wantErr:   ErrSLOFileNotFound,
+		},
+		{
+			name: "project level not available",
+			resources: map[string]*models.Resource{
+				sloLevelService: newResource(serviceSLO, "stage-commit"),
+			},
+			resourceErrs: map[string]error{
+				sloLevelProject: errSLOResourceUnavailable,
+			},
+			wantErr: errSLOResourceUnavailable,
+		},
+		{
+			name: "stage level not checked out",
+			resources: map[string]*models.Resource{
+				sloLevelService: newResource(serviceSLO, "stage-commit"),
+			},
+			resourceErrs: map[string]error{
+				sloLevelStage: errors.New("Could not check out branch containing stage config"),
+			},
+			wantErr: ErrConfigService,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := &SLOFileRetriever{
+				ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+					GetResourceFunc: func(scope keptnapi.ResourceScope, options ...keptnapi.URIOption) (*models.Resource, error) {
+						level := sloLevelService
+						if scope.GetServicePath() == "" && scope.GetStagePath() == "" {
+							level = sloLevelProject
+						} else if scope.GetServicePath() == "" {
+							level = sloLevelStage
+						}
+						if resource, ok := tt.resources[level]; ok {
+							return resource, nil
+						}
+						if err, ok := tt.resourceErrs[level]; ok {
+							return nil, err
+						}
+						return nil, keptnapi.ResourceNotFoundError
+					},
+				},
+				ServiceHandler: &event_handler_mock.ServiceHandlerMock{
+					GetServiceFunc: func(project string, stage string, service string) (*models.Service, error) {
+						return &models.Service{}, nil
+					},
+				},
+			}
+
+			slo, _, sources, err := sr.GetSLOsWithSources("sockshop", "dev", "carts", "stage-commit")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, slo)
+			assert.Equal(t, tt.wantSources, sources)
+		})
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/keptn/go-utils/pkg/common/timeutils"
 	logger "github.com/sirupsen/logrus"
+	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 
@@ -20,6 +22,8 @@ type StartEvaluationHandler struct {
 	KeptnHandler      *keptnv2.Keptn
 	SLIProviderConfig SLIProviderConfig
 	SLOFileRetriever  SLOFileRetriever `deep:"-"`
+	HTTPClient        *http.Client
+	EventStore        EventStore
 }
 
 func (eh *StartEvaluationHandler) HandleEvent(ctx context.Context) error {
@@ -51,7 +55,7 @@ func (eh *StartEvaluationHandler) HandleEvent(ctx context.Context) error {
 	}
 
 	// try to parse timestamps
-	evaluationStartTimestamp, evaluationEndTimestamp, err := getEvaluationTimestamps(e)
+	evaluationStartTimestamp, evaluationEndTimestamp, err := eh.getEvaluationTimestamps(keptnContext, e)
 	if err != nil {
 		return eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, err.Error())
 	}
@@ -82,10 +86,22 @@ func (eh *StartEvaluationHandler) sendGetSliCloudEvent(ctx context.Context, kept
 	indicators := []string{}
 	var filters = []*keptnv2.SLIFilter{}
 
-	if err2, end := eh.computeObjectives(e, commitID, &indicators, &filters, evaluationStartTimestamp, evaluationEndTimestamp); end {
+	sloFileContent, err2, end := eh.computeObjectives(e, commitID, &indicators, &filters, evaluationStartTimestamp, evaluationEndTimestamp)
+	if end {
 		return err2
 	}
 
+	if isResultReuseEnabled(eh.Event.Data(), sloFileContent) {
+		cached, derivedFrom, err := findCachedEvaluation(eh.HTTPClient, e, evaluationStartTimestamp, evaluationEndTimestamp, commitID, sloFileContent, indicators)
+		if err != nil {
+			// the evaluation can still be conducted without the cached results
+			logger.Errorf("Could not look up previous evaluations with identical timeframe: %v", err)
+		} else if cached != nil {
+			logger.Infof("Reusing results of evaluation %s for identical timeframe", derivedFrom.EventID)
+			return sendEvent(keptnContext, eh.Event.ID(), keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, newDerivedEvaluationFinishedEventData(e, cached, derivedFrom))
+		}
+	}
+
 	// get the SLI provider that has been configured for the project (e.g. 'dynatrace' or 'prometheus') from the respective configmap
 	var sliProvider string
 	sliProvider, err := eh.SLIProviderConfig.GetSLIProvider(e.Project)
@@ -124,8 +140,8 @@ func (eh *StartEvaluationHandler) sendGetSliCloudEvent(ctx context.Context, kept
 	return nil
 }
 
-func (eh *StartEvaluationHandler) computeObjectives(e *keptnv2.EvaluationTriggeredEventData, commitID string, indicators *[]string, filters *[]*keptnv2.SLIFilter, evaluationStartTimestamp string, evaluationEndTimestamp string) (error, bool) {
-	objectives, _, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
+func (eh *StartEvaluationHandler) computeObjectives(e *keptnv2.EvaluationTriggeredEventData, commitID string, indicators *[]string, filters *[]*keptnv2.SLIFilter, evaluationStartTimestamp string, evaluationEndTimestamp string) ([]byte, error, bool) {
+	objectives, sloFileContent, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
 	if err == nil && objectives != nil {
 		logger.Info("SLO file found")
 		for _, objective := range objectives.Objectives {
@@ -153,11 +169,11 @@ func (eh *StartEvaluationHandler) computeObjectives(e *keptnv2.EvaluationTrigger
 			message = fmt.Sprintf("error retrieving SLO file: %s", err.Error())
 		}
 		logger.Error(message)
-		return eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, message), true
+		return nil, eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, message), true
 	} else if err != nil && err == ErrSLOFileNotFound {
 		logger.Error("no SLO file found")
 	}
-	return nil, false
+	return sloFileContent, nil, false
 }
 
 func (eh *StartEvaluationHandler) sendEvaluationFinishedWithErrorEvent(start, end string, e *keptnv2.EvaluationTriggeredEventData, message string) error {
@@ -185,6 +201,18 @@ func (eh *StartEvaluationHandler) sendEvaluationFinishedWithErrorEvent(start, en
 	return err
 }
 
+// getEvaluationTimestamps resolves the anchored timeframe of the event, if available. Otherwise, the timeframe is taken from the event data
+func (eh *StartEvaluationHandler) getEvaluationTimestamps(keptnContext string, e *keptnv2.EvaluationTriggeredEventData) (string, string, error) {
+	anchoredTimeframe, err := parseAnchoredTimeframe(eh.Event.Data())
+	if err != nil {
+		return "", "", err
+	}
+	if anchoredTimeframe != nil {
+		return resolveAnchoredTimeframe(eh.EventStore, keptnContext, e, anchoredTimeframe, time.Now())
+	}
+	return getEvaluationTimestamps(e)
+}
+
 func getEvaluationTimestamps(e *keptnv2.EvaluationTriggeredEventData) (string, string, error) {
 	if (e.Evaluation.Start != "" && e.Evaluation.End != "") || (e.Evaluation.Timeframe != "") {
 		params := timeutils.GetStartEndTimeParams{
@@ -0,0 +1,107 @@
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+// MaxArchiveSize is the maximum size of a compressed archive accepted for import
+const MaxArchiveSize = 64 << 20
+
+// maxArchiveContentSize is the maximum total size of the decompressed files of an archive
+var maxArchiveContentSize int64 = 256 << 20
+
+// maxArchiveEntries is the maximum number of entries of an archive, including directories
+var maxArchiveEntries = 10000
+
+// ArchiveFile is a file of a tar.gz archive. The path is relative to the root of the archive
+type ArchiveFile struct {
+	Path    string
+	Content []byte
+}
+
+// WriteTarGz creates a gzip compressed tar archive containing the given files
+func WriteTarGz(files []ArchiveFile) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	modTime := time.Now()
+	for _, file := range files {
+		header := &tar.Header{
+			Name:     file.Path,
+			Mode:     0600,
+			Size:     int64(len(file.Content)),
+			ModTime:  modTime,
+			Typeflag: tar.TypeReg,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("could not write archive header of file %s: %w", file.Path, err)
+		}
+		if _, err := tarWriter.Write(file.Content); err != nil {
+			return nil, fmt.Errorf("could not write file %s to archive: %w", file.Path, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadTarGz returns the regular files of a gzip compressed tar archive. Directory entries are omitted. Archives containing paths that
+// point outside the root of the archive, more than maxArchiveEntries entries or more than maxArchiveContentSize bytes of decompressed
+// content are rejected
+func ReadTarGz(data []byte) ([]ArchiveFile, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", kerrors.ErrInvalidArchive, err)
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	files := []ArchiveFile{}
+	entries := 0
+	remainingSize := maxArchiveContentSize
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", kerrors.ErrInvalidArchive, err)
+		}
+		entries++
+		if entries > maxArchiveEntries {
+			return nil, fmt.Errorf("%w: archive contains more than %d entries", kerrors.ErrInvalidArchive, maxArchiveEntries)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		filePath := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if path.IsAbs(filePath) || filePath == ".." || strings.HasPrefix(filePath, "../") {
+			return nil, fmt.Errorf("%w: invalid path %s", kerrors.ErrInvalidArchive, header.Name)
+		}
+		// the size in the header is not trusted, so the content is read with a limit
+		content, err := io.ReadAll(io.LimitReader(tarReader, remainingSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", kerrors.ErrInvalidArchive, err)
+		}
+		remainingSize -= int64(len(content))
+		if remainingSize < 0 {
+			return nil, fmt.Errorf("%w: decompressed content exceeds %d bytes", kerrors.ErrInvalidArchive, maxArchiveContentSize)
+		}
+		files = append(files, ArchiveFile{Path: filePath, Content: content})
+	}
+}
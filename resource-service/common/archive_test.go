@@ -0,0 +1,116 @@
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTarGz_ReadTarGz(t *testing.T) {
+	files := []ArchiveFile{
+		{Path: "metadata.yaml", Content: []byte("projectName: my-project")},
+		{Path: "project/shipyard.yaml", Content: []byte("apiVersion: spec.keptn.sh/0.2.3")},
+		{Path: "stages/dev/my-service/slo.yaml", Content: []byte{}},
+	}
+
+	archive, err := WriteTarGz(files)
+	require.Nil(t, err)
+
+	result, err := ReadTarGz(archive)
+	require.Nil(t, err)
+	require.Equal(t, files, result)
+}
+
+func TestReadTarGz(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tar.Header
+		want    []ArchiveFile
+		wantErr bool
+	}{
+		{
+			name: "directories are omitted",
+			entries: []tar.Header{
+				{Name: "./project/", Typeflag: tar.TypeDir},
+				{Name: "./project/shipyard.yaml", Typeflag: tar.TypeReg},
+			},
+			want: []ArchiveFile{{Path: "project/shipyard.yaml", Content: []byte{}}},
+		},
+		{
+			name: "path outside of archive",
+			entries: []tar.Header{
+				{Name: "project/../../shipyard.yaml", Typeflag: tar.TypeReg},
+			},
+			wantErr: true,
+		},
+		{
+			name: "absolute path",
+			entries: []tar.Header{
+				{Name: "/etc/passwd", Typeflag: tar.TypeReg},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			gzipWriter := gzip.NewWriter(buf)
+			tarWriter := tar.NewWriter(gzipWriter)
+			for _, entry := range tt.entries {
+				header := entry
+				header.Mode = 0600
+				require.Nil(t, tarWriter.WriteHeader(&header))
+			}
+			require.Nil(t, tarWriter.Close())
+			require.Nil(t, gzipWriter.Close())
+
+			got, err := ReadTarGz(buf.Bytes())
+			if tt.wantErr {
+				require.ErrorIs(t, err, kerrors.ErrInvalidArchive)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadTarGz_Limits(t *testing.T) {
+	defer func(size int64, entries int) {
+		maxArchiveContentSize = size
+		maxArchiveEntries = entries
+	}(maxArchiveContentSize, maxArchiveEntries)
+	maxArchiveContentSize = 10
+	maxArchiveEntries = 3
+
+	archive, err := WriteTarGz([]ArchiveFile{
+		{Path: "a.yaml", Content: []byte("12345")},
+		{Path: "b.yaml", Content: []byte("12345")},
+	})
+	require.Nil(t, err)
+	files, err := ReadTarGz(archive)
+	require.Nil(t, err)
+	require.Len(t, files, 2)
+
+	archive, err = WriteTarGz([]ArchiveFile{
+		{Path: "a.yaml", Content: []byte("12345")},
+		{Path: "b.yaml", Content: []byte("123456")},
+	})
+	require.Nil(t, err)
+	_, err = ReadTarGz(archive)
+	require.ErrorIs(t, err, kerrors.ErrInvalidArchive)
+
+	archive, err = WriteTarGz([]ArchiveFile{{Path: "a.yaml"}, {Path: "b.yaml"}, {Path: "c.yaml"}, {Path: "d.yaml"}})
+	require.Nil(t, err)
+	_, err = ReadTarGz(archive)
+	require.ErrorIs(t, err, kerrors.ErrInvalidArchive)
+}
+
+func TestReadTarGz_NoArchive(t *testing.T) {
+	_, err := ReadTarGz([]byte("no-archive"))
+	require.ErrorIs(t, err, kerrors.ErrInvalidArchive)
+}
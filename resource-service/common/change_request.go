@@ -0,0 +1,349 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+const changeRequestTimeout = 30 * time.Second
+
+// ChangeRequestProvider opens change requests on the upstream repository of a project, using the review settings of the git context
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/change_request_provider_mock.go . ChangeRequestProvider
+type ChangeRequestProvider interface {
+	// CreateChangeRequest opens a change request for merging the source branch into the target branch and returns it
+	// together with its ID and URL
+	CreateChangeRequest(gitContext common_models.GitContext, changeRequest common_models.ChangeRequest) (*common_models.ChangeRequest, error)
+}
+
+// ChangeRequestProviders selects the provider configured in the review settings of a project
+type ChangeRequestProviders map[string]ChangeRequestProvider
+
+// NewChangeRequestProviders returns the providers for the REST APIs of GitHub, GitLab and Gitea
+func NewChangeRequestProviders() ChangeRequestProviders {
+	return ChangeRequestProviders{
+		common_models.ChangeRequestProviderGitHub: GitHubProvider{},
+		common_models.ChangeRequestProviderGitLab: GitLabProvider{},
+		common_models.ChangeRequestProviderGitea:  GiteaProvider{},
+	}
+}
+
+func (cp ChangeRequestProviders) CreateChangeRequest(gitContext common_models.GitContext, changeRequest common_models.ChangeRequest) (*common_models.ChangeRequest, error) {
+	if gitContext.Review == nil {
+		return nil, kerrors.ErrInvalidChangeRequestProvider
+	}
+	provider, ok := cp[gitContext.Review.Provider]
+	if !ok {
+		return nil, kerrors.ErrInvalidChangeRequestProvider
+	}
+	return provider.CreateChangeRequest(gitContext, changeRequest)
+}
+
+func isChangeRequestProvider(provider string) bool {
+	switch provider {
+	case common_models.ChangeRequestProviderGitHub, common_models.ChangeRequestProviderGitLab, common_models.ChangeRequestProviderGitea:
+		return true
+	}
+	return false
+}
+
+// GitHubProvider opens pull requests using the REST API of GitHub. If no API URL is configured, the API of github.com or the
+// API of the GitHub Enterprise server hosting the repository is used
+type GitHubProvider struct{}
+
+func (p GitHubProvider) CreateChangeRequest(gitContext common_models.GitContext, changeRequest common_models.ChangeRequest) (*common_models.ChangeRequest, error) {
+	repository, err := parseRepositoryURL(gitContext)
+	if err != nil {
+		return nil, err
+	}
+	apiURL := gitContext.Review.APIURL
+	if apiURL == "" && repository.Host == "github.com" {
+		apiURL = "https://api.github.com"
+	} else if apiURL == "" {
+		apiURL = repository.Scheme + "://" + repository.Host + "/api/v3"
+	}
+	owner, name, err := repository.ownerAndName()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}{}
+	err = sendChangeRequest(gitContext, fmt.Sprintf("%s/repos/%s/%s/pulls", apiURL, owner, name), map[string]string{
+		"Accept":        "application/vnd.github+json",
+		"Authorization": "Bearer " + getChangeRequestToken(gitContext),
+	}, map[string]string{
+		"title": changeRequest.Title,
+		"body":  changeRequest.Description,
+		"head":  changeRequest.SourceBranch,
+		"base":  changeRequest.TargetBranch,
+	}, response)
+	if err != nil {
+		return nil, err
+	}
+	changeRequest.ID = strconv.Itoa(response.Number)
+	changeRequest.URL = response.HTMLURL
+	return &changeRequest, nil
+}
+
+// GitLabProvider opens merge requests using the REST API v4 of GitLab. If no API URL is configured, the API of the server
+// hosting the repository is used
+type GitLabProvider struct{}
+
+func (p GitLabProvider) CreateChangeRequest(gitContext common_models.GitContext, changeRequest common_models.ChangeRequest) (*common_models.ChangeRequest, error) {
+	repository, err := parseRepositoryURL(gitContext)
+	if err != nil {
+		return nil, err
+	}
+	apiURL := gitContext.Review.APIURL
+	if apiURL == "" {
+		apiURL = repository.Scheme + "://" + repository.Host + "/api/v4"
+	}
+
+	response := &struct {
+		IID    int    `json:"iid"`
+		WebURL string `json:"web_url"`
+	}{}
+	err = sendChangeRequest(gitContext, fmt.Sprintf("%s/projects/%s/merge_requests", apiURL, url.PathEscape(repository.Path)), map[string]string{
+		"PRIVATE-TOKEN": getChangeRequestToken(gitContext),
+	}, map[string]string{
+		"title":         changeRequest.Title,
+		"description":   changeRequest.Description,
+		"source_branch": changeRequest.SourceBranch,
+		"target_branch": changeRequest.TargetBranch,
+	}, response)
+	if err != nil {
+		return nil, err
+	}
+	changeRequest.ID = strconv.Itoa(response.IID)
+	changeRequest.URL = response.WebURL
+	return &changeRequest, nil
+}
+
+// GiteaProvider opens pull requests using the REST API v1 of Gitea. If no API URL is configured, the API of the server hosting
+// the repository is used
+type GiteaProvider struct{}
+
+func (p GiteaProvider) CreateChangeRequest(gitContext common_models.GitContext, changeRequest common_models.ChangeRequest) (*common_models.ChangeRequest, error) {
+	repository, err := parseRepositoryURL(gitContext)
+	if err != nil {
+		return nil, err
+	}
+	apiURL := gitContext.Review.APIURL
+	if apiURL == "" {
+		apiURL = repository.Scheme + "://" + repository.Host + "/api/v1"
+	}
+	owner, name, err := repository.ownerAndName()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}{}
+	err = sendChangeRequest(gitContext, fmt.Sprintf("%s/repos/%s/%s/pulls", apiURL, owner, name), map[string]string{
+		"Authorization": "token " + getChangeRequestToken(gitContext),
+	}, map[string]string{
+		"title": changeRequest.Title,
+		"body":  changeRequest.Description,
+		"head":  changeRequest.SourceBranch,
+		"base":  changeRequest.TargetBranch,
+	}, response)
+	if err != nil {
+		return nil, err
+	}
+	changeRequest.ID = strconv.Itoa(response.Number)
+	changeRequest.URL = response.HTMLURL
+	return &changeRequest, nil
+}
+
+// LocalChangeRequestProvider keeps change requests in memory and merges them into upstream repositories located on the local
+// file system. It is meant for tests only, and therefore is not one of the providers that can be configured in the review settings
+type LocalChangeRequestProvider struct {
+	mutex          sync.Mutex
+	changeRequests []localChangeRequest
+}
+
+type localChangeRequest struct {
+	common_models.ChangeRequest
+	upstreamPath string
+	merged       bool
+}
+
+func NewLocalChangeRequestProvider() *LocalChangeRequestProvider {
+	return &LocalChangeRequestProvider{}
+}
+
+func (p *LocalChangeRequestProvider) CreateChangeRequest(gitContext common_models.GitContext, changeRequest common_models.ChangeRequest) (*common_models.ChangeRequest, error) {
+	if gitContext.Credentials == nil {
+		return nil, kerrors.ErrCredentialsNotFound
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	changeRequest.ID = strconv.Itoa(len(p.changeRequests) + 1)
+	upstreamPath := strings.TrimPrefix(gitContext.Credentials.RemoteURL, "file://")
+	changeRequest.URL = "file://" + upstreamPath + "#" + changeRequest.ID
+	p.changeRequests = append(p.changeRequests, localChangeRequest{ChangeRequest: changeRequest, upstreamPath: upstreamPath})
+	return &changeRequest, nil
+}
+
+// GetChangeRequests returns the change requests that have been created, including the merged ones
+func (p *LocalChangeRequestProvider) GetChangeRequests() []common_models.ChangeRequest {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	changeRequests := make([]common_models.ChangeRequest, 0, len(p.changeRequests))
+	for _, changeRequest := range p.changeRequests {
+		changeRequests = append(changeRequests, changeRequest.ChangeRequest)
+	}
+	return changeRequests
+}
+
+// Merge fast-forwards the target branch of the change request in the upstream repository to the source branch and returns the
+// new revision of the target branch
+func (p *LocalChangeRequestProvider) Merge(id string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for i := range p.changeRequests {
+		changeRequest := &p.changeRequests[i]
+		if changeRequest.ID != id || changeRequest.merged {
+			continue
+		}
+		revision, err := fastForwardBranch(changeRequest.upstreamPath, changeRequest.TargetBranch, changeRequest.SourceBranch)
+		if err != nil {
+			return "", err
+		}
+		changeRequest.merged = true
+		return revision, nil
+	}
+	return "", kerrors.ErrChangeRequestNotFound
+}
+
+// fastForwardBranch moves the target branch of the repository to the source branch, if the source branch contains the target branch
+func fastForwardBranch(repositoryPath string, targetBranch string, sourceBranch string) (string, error) {
+	r, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return "", err
+	}
+	target, err := r.Reference(plumbing.NewBranchReferenceName(targetBranch), true)
+	if err != nil {
+		return "", err
+	}
+	source, err := r.Reference(plumbing.NewBranchReferenceName(sourceBranch), true)
+	if err != nil {
+		return "", err
+	}
+	targetCommit, err := r.CommitObject(target.Hash())
+	if err != nil {
+		return "", err
+	}
+	sourceCommit, err := r.CommitObject(source.Hash())
+	if err != nil {
+		return "", err
+	}
+	isAncestor, err := targetCommit.IsAncestor(sourceCommit)
+	if err != nil {
+		return "", err
+	}
+	if !isAncestor {
+		return "", kerrors.ErrChangeRequestNotMergeable
+	}
+	if err := r.Storer.SetReference(plumbing.NewHashReference(target.Name(), source.Hash())); err != nil {
+		return "", err
+	}
+	return source.Hash().String(), nil
+}
+
+// repositoryURL is the URL of an upstream repository, with the path of the repository on the server without the .git suffix
+type repositoryURL struct {
+	Scheme string
+	Host   string
+	Path   string
+}
+
+func parseRepositoryURL(gitContext common_models.GitContext) (*repositoryURL, error) {
+	if gitContext.Credentials == nil {
+		return nil, kerrors.ErrCredentialsNotFound
+	}
+	parsed, err := url.Parse(gitContext.Credentials.RemoteURL)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: %s", kerrors.ErrCredentialsInvalidRemoteURL, gitContext.Credentials.RemoteURL)
+	}
+	repository := &repositoryURL{
+		Scheme: parsed.Scheme,
+		Host:   parsed.Host,
+		Path:   strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git"),
+	}
+	if repository.Scheme == "ssh" {
+		// the API of repositories accessed via ssh is expected to be served via https
+		repository.Scheme = "https"
+		repository.Host = parsed.Hostname()
+	}
+	return repository, nil
+}
+
+// ownerAndName returns the owner and the name of the repository from the last two segments of its path
+func (r repositoryURL) ownerAndName() (string, string, error) {
+	segments := strings.Split(r.Path, "/")
+	if len(segments) < 2 {
+		return "", "", fmt.Errorf("%w: %s", kerrors.ErrCredentialsInvalidRemoteURL, r.Path)
+	}
+	return segments[len(segments)-2], segments[len(segments)-1], nil
+}
+
+// getChangeRequestToken returns the token configured in the review settings, or the token of the https credentials
+func getChangeRequestToken(gitContext common_models.GitContext) string {
+	if gitContext.Review != nil && gitContext.Review.Token != "" {
+		return gitContext.Review.Token
+	}
+	if gitContext.Credentials != nil && gitContext.Credentials.HttpsAuth != nil {
+		return gitContext.Credentials.HttpsAuth.Token
+	}
+	return ""
+}
+
+// sendChangeRequest posts the JSON encoded payload to the REST API of a provider and decodes the response into the given value
+func sendChangeRequest(gitContext common_models.GitContext, endpoint string, header map[string]string, payload interface{}, response interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := nethttp.NewRequest(nethttp.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", kerrors.ErrChangeRequestFailed, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := newUpstreamHTTPClient(gitContext, changeRequestTimeout).Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", kerrors.ErrChangeRequestFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: unexpected status code %d from %s", kerrors.ErrChangeRequestFailed, resp.StatusCode, req.URL.Redacted())
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("%w: %v", kerrors.ErrChangeRequestFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeRequestProviders_CreateChangeRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		provider      string
+		token         string
+		wantPath      string
+		wantHeader    [2]string
+		wantPayload   map[string]string
+		response      string
+		wantID        string
+		wantURL       string
+		wantErr       error
+		responseCode  int
+		missingReview bool
+	}{
+		{
+			name:       "github",
+			provider:   common_models.ChangeRequestProviderGitHub,
+			wantPath:   "/repos/my-org/my-repo/pulls",
+			wantHeader: [2]string{"Authorization", "Bearer my-token"},
+			wantPayload: map[string]string{
+				"title": "Updated resource",
+				"body":  "Updated resource\n\nIncrease the number of replicas",
+				"head":  "keptn-review/my-branch",
+				"base":  "dev",
+			},
+			response: `{"number": 42, "html_url": "https://github.com/my-org/my-repo/pull/42"}`,
+			wantID:   "42",
+			wantURL:  "https://github.com/my-org/my-repo/pull/42",
+		},
+		{
+			name:       "gitlab",
+			provider:   common_models.ChangeRequestProviderGitLab,
+			token:      "my-api-token",
+			wantPath:   "/projects/my-org%2Fmy-repo/merge_requests",
+			wantHeader: [2]string{"PRIVATE-TOKEN", "my-api-token"},
+			wantPayload: map[string]string{
+				"title":         "Updated resource",
+				"description":   "Updated resource\n\nIncrease the number of replicas",
+				"source_branch": "keptn-review/my-branch",
+				"target_branch": "dev",
+			},
+			response: `{"id": 1234, "iid": 7, "web_url": "https://gitlab.com/my-org/my-repo/-/merge_requests/7"}`,
+			wantID:   "7",
+			wantURL:  "https://gitlab.com/my-org/my-repo/-/merge_requests/7",
+		},
+		{
+			name:       "gitea",
+			provider:   common_models.ChangeRequestProviderGitea,
+			wantPath:   "/repos/my-org/my-repo/pulls",
+			wantHeader: [2]string{"Authorization", "token my-token"},
+			wantPayload: map[string]string{
+				"title": "Updated resource",
+				"body":  "Updated resource\n\nIncrease the number of replicas",
+				"head":  "keptn-review/my-branch",
+				"base":  "dev",
+			},
+			response: `{"number": 3, "html_url": "https://gitea.example.com/my-org/my-repo/pulls/3"}`,
+			wantID:   "3",
+			wantURL:  "https://gitea.example.com/my-org/my-repo/pulls/3",
+		},
+		{
+			name:         "request rejected",
+			provider:     common_models.ChangeRequestProviderGitHub,
+			wantPath:     "/repos/my-org/my-repo/pulls",
+			wantHeader:   [2]string{"Authorization", "Bearer my-token"},
+			responseCode: http.StatusUnprocessableEntity,
+			response:     `{"message": "Validation Failed"}`,
+			wantErr:      kerrors.ErrChangeRequestFailed,
+		},
+		{
+			name:     "unknown provider",
+			provider: "bitbucket",
+			wantErr:  kerrors.ErrInvalidChangeRequestProvider,
+		},
+		{
+			name:     "local provider is not available",
+			provider: "local",
+			wantErr:  kerrors.ErrInvalidChangeRequestProvider,
+		},
+		{
+			name:          "no review settings",
+			missingReview: true,
+			wantErr:       kerrors.ErrInvalidChangeRequestProvider,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, tt.wantPath, r.URL.EscapedPath())
+				require.Equal(t, tt.wantHeader[1], r.Header.Get(tt.wantHeader[0]))
+				if tt.wantPayload != nil {
+					payload := map[string]string{}
+					require.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+					require.Equal(t, tt.wantPayload, payload)
+				}
+				if tt.responseCode != 0 {
+					w.WriteHeader(tt.responseCode)
+				} else {
+					w.WriteHeader(http.StatusCreated)
+				}
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			gitContext := getTestChangeRequestGitContext(common_models.ReviewConfig{Provider: tt.provider, APIURL: server.URL, Token: tt.token})
+			if tt.missingReview {
+				gitContext.Review = nil
+			}
+			changeRequest, err := NewChangeRequestProviders().CreateChangeRequest(gitContext, common_models.ChangeRequest{
+				Title:        "Updated resource",
+				Description:  "Updated resource\n\nIncrease the number of replicas",
+				SourceBranch: "keptn-review/my-branch",
+				TargetBranch: "dev",
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, changeRequest)
+				require.Equal(t, tt.wantPath != "", called)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, &common_models.ChangeRequest{
+				ID:           tt.wantID,
+				URL:          tt.wantURL,
+				Title:        "Updated resource",
+				Description:  "Updated resource\n\nIncrease the number of replicas",
+				SourceBranch: "keptn-review/my-branch",
+				TargetBranch: "dev",
+			}, changeRequest)
+		})
+	}
+}
+
+func TestParseRepositoryURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		remoteURL string
+		want      *repositoryURL
+		wantErr   bool
+	}{
+		{
+			name:      "https",
+			remoteURL: "https://github.com/my-org/my-repo.git",
+			want:      &repositoryURL{Scheme: "https", Host: "github.com", Path: "my-org/my-repo"},
+		},
+		{
+			name:      "https with port",
+			remoteURL: "https://gitlab.example.com:8443/my-group/my-repo.git",
+			want:      &repositoryURL{Scheme: "https", Host: "gitlab.example.com:8443", Path: "my-group/my-repo"},
+		},
+		{
+			name:      "http with port",
+			remoteURL: "http://gitea.example.com:3000/my-org/my-repo",
+			want:      &repositoryURL{Scheme: "http", Host: "gitea.example.com:3000", Path: "my-org/my-repo"},
+		},
+		{
+			name:      "ssh",
+			remoteURL: "ssh://git@gitlab.com:22/my-group/my-subgroup/my-repo.git",
+			want:      &repositoryURL{Scheme: "https", Host: "gitlab.com", Path: "my-group/my-subgroup/my-repo"},
+		},
+		{
+			name:      "local path",
+			remoteURL: "/data/my-repo",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, err := parseRepositoryURL(common_models.GitContext{Credentials: &common_models.GitCredentials{RemoteURL: tt.remoteURL}})
+			if tt.wantErr {
+				require.ErrorIs(t, err, kerrors.ErrCredentialsInvalidRemoteURL)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, repository)
+		})
+	}
+}
+
+func TestRepositoryURL_OwnerAndName(t *testing.T) {
+	owner, name, err := repositoryURL{Path: "my-group/my-subgroup/my-repo"}.ownerAndName()
+	require.Nil(t, err)
+	require.Equal(t, "my-subgroup", owner)
+	require.Equal(t, "my-repo", name)
+
+	_, _, err = repositoryURL{Path: "my-repo"}.ownerAndName()
+	require.ErrorIs(t, err, kerrors.ErrCredentialsInvalidRemoteURL)
+}
+
+func TestGetChangeRequestToken(t *testing.T) {
+	gitContext := getTestChangeRequestGitContext(common_models.ReviewConfig{Provider: common_models.ChangeRequestProviderGitHub})
+	require.Equal(t, "my-token", getChangeRequestToken(gitContext))
+
+	gitContext.Review.Token = "my-api-token"
+	require.Equal(t, "my-api-token", getChangeRequestToken(gitContext))
+}
+
+func TestChangeRequestProviders_InjectedLocalProvider(t *testing.T) {
+	provider := NewLocalChangeRequestProvider()
+	providers := NewChangeRequestProviders()
+	providers["local"] = provider
+
+	gitContext := getTestChangeRequestGitContext(common_models.ReviewConfig{Provider: "local"})
+	changeRequest, err := providers.CreateChangeRequest(gitContext, common_models.ChangeRequest{SourceBranch: "keptn-review/my-branch", TargetBranch: "dev"})
+	require.Nil(t, err)
+	require.Equal(t, "1", changeRequest.ID)
+	require.Len(t, provider.GetChangeRequests(), 1)
+}
+
+func TestLocalChangeRequestProvider_Merge_NotFound(t *testing.T) {
+	provider := NewLocalChangeRequestProvider()
+
+	changeRequest, err := provider.CreateChangeRequest(common_models.GitContext{
+		Credentials: &common_models.GitCredentials{RemoteURL: t.TempDir()},
+	}, common_models.ChangeRequest{SourceBranch: "keptn-review/my-branch", TargetBranch: "master"})
+	require.Nil(t, err)
+	require.Equal(t, "1", changeRequest.ID)
+	require.Len(t, provider.GetChangeRequests(), 1)
+
+	_, err = provider.Merge("2")
+	require.ErrorIs(t, err, kerrors.ErrChangeRequestNotFound)
+}
+
+func getTestChangeRequestGitContext(reviewConfig common_models.ReviewConfig) common_models.GitContext {
+	return common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			User:      "my-user",
+			RemoteURL: "https://github.com/my-org/my-repo.git",
+			HttpsAuth: &apimodels.HttpsGitAuth{Token: "my-token"},
+		},
+		Review: &reviewConfig,
+	}
+}
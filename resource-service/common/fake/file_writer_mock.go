@@ -4,6 +4,7 @@
 package common_mock
 
 import (
+	"io"
 	"path/filepath"
 	"sync"
 )
@@ -14,15 +15,24 @@ import (
 //
 // 		// make and configure a mocked common.IFileSystem
 // 		mockedIFileSystem := &IFileSystemMock{
+// 			CreateTempDirFunc: func() (string, error) {
+// 				panic("mock out the CreateTempDir method")
+// 			},
 // 			DeleteFileFunc: func(path string) error {
 // 				panic("mock out the DeleteFile method")
 // 			},
 // 			FileExistsFunc: func(path string) bool {
 // 				panic("mock out the FileExists method")
 // 			},
+// 			GetFileChecksumFunc: func(filename string) (string, int64, error) {
+// 				panic("mock out the GetFileChecksum method")
+// 			},
 // 			MakeDirFunc: func(path string) error {
 // 				panic("mock out the MakeDir method")
 // 			},
+// 			OpenFileFunc: func(filename string) (io.ReadCloser, error) {
+// 				panic("mock out the OpenFile method")
+// 			},
 // 			ReadFileFunc: func(filename string) ([]byte, error) {
 // 				panic("mock out the ReadFile method")
 // 			},
@@ -35,6 +45,9 @@ import (
 // 			WriteFileFunc: func(path string, content []byte) error {
 // 				panic("mock out the WriteFile method")
 // 			},
+// 			WriteFileFromReaderFunc: func(path string, content io.Reader) error {
+// 				panic("mock out the WriteFileFromReader method")
+// 			},
 // 			WriteHelmChartFunc: func(path string) error {
 // 				panic("mock out the WriteHelmChart method")
 // 			},
@@ -45,15 +58,24 @@ import (
 //
 // 	}
 type IFileSystemMock struct {
+	// CreateTempDirFunc mocks the CreateTempDir method.
+	CreateTempDirFunc func() (string, error)
+
 	// DeleteFileFunc mocks the DeleteFile method.
 	DeleteFileFunc func(path string) error
 
 	// FileExistsFunc mocks the FileExists method.
 	FileExistsFunc func(path string) bool
 
+	// GetFileChecksumFunc mocks the GetFileChecksum method.
+	GetFileChecksumFunc func(filename string) (string, int64, error)
+
 	// MakeDirFunc mocks the MakeDir method.
 	MakeDirFunc func(path string) error
 
+	// OpenFileFunc mocks the OpenFile method.
+	OpenFileFunc func(filename string) (io.ReadCloser, error)
+
 	// ReadFileFunc mocks the ReadFile method.
 	ReadFileFunc func(filename string) ([]byte, error)
 
@@ -66,11 +88,17 @@ type IFileSystemMock struct {
 	// WriteFileFunc mocks the WriteFile method.
 	WriteFileFunc func(path string, content []byte) error
 
+	// WriteFileFromReaderFunc mocks the WriteFileFromReader method.
+	WriteFileFromReaderFunc func(path string, content io.Reader) error
+
 	// WriteHelmChartFunc mocks the WriteHelmChart method.
 	WriteHelmChartFunc func(path string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CreateTempDir holds details about calls to the CreateTempDir method.
+		CreateTempDir []struct {
+		}
 		// DeleteFile holds details about calls to the DeleteFile method.
 		DeleteFile []struct {
 			// Path is the path argument value.
@@ -81,11 +109,21 @@ type IFileSystemMock struct {
 			// Path is the path argument value.
 			Path string
 		}
+		// GetFileChecksum holds details about calls to the GetFileChecksum method.
+		GetFileChecksum []struct {
+			// Filename is the filename argument value.
+			Filename string
+		}
 		// MakeDir holds details about calls to the MakeDir method.
 		MakeDir []struct {
 			// Path is the path argument value.
 			Path string
 		}
+		// OpenFile holds details about calls to the OpenFile method.
+		OpenFile []struct {
+			// Filename is the filename argument value.
+			Filename string
+		}
 		// ReadFile holds details about calls to the ReadFile method.
 		ReadFile []struct {
 			// Filename is the filename argument value.
@@ -112,22 +150,60 @@ type IFileSystemMock struct {
 			// Content is the content argument value.
 			Content []byte
 		}
+		// WriteFileFromReader holds details about calls to the WriteFileFromReader method.
+		WriteFileFromReader []struct {
+			// Path is the path argument value.
+			Path string
+			// Content is the content argument value.
+			Content io.Reader
+		}
 		// WriteHelmChart holds details about calls to the WriteHelmChart method.
 		WriteHelmChart []struct {
 			// Path is the path argument value.
 			Path string
 		}
 	}
+	lockCreateTempDir          sync.RWMutex
 	lockDeleteFile             sync.RWMutex
 	lockFileExists             sync.RWMutex
+	lockGetFileChecksum        sync.RWMutex
 	lockMakeDir                sync.RWMutex
+	lockOpenFile               sync.RWMutex
 	lockReadFile               sync.RWMutex
 	lockWalkPath               sync.RWMutex
 	lockWriteBase64EncodedFile sync.RWMutex
 	lockWriteFile              sync.RWMutex
+	lockWriteFileFromReader    sync.RWMutex
 	lockWriteHelmChart         sync.RWMutex
 }
 
+// CreateTempDir calls CreateTempDirFunc.
+func (mock *IFileSystemMock) CreateTempDir() (string, error) {
+	if mock.CreateTempDirFunc == nil {
+		panic("IFileSystemMock.CreateTempDirFunc: method is nil but IFileSystem.CreateTempDir was just called")
+	}
+	callInfo := struct {
+	}{
+	}
+	mock.lockCreateTempDir.Lock()
+	mock.calls.CreateTempDir = append(mock.calls.CreateTempDir, callInfo)
+	mock.lockCreateTempDir.Unlock()
+	return mock.CreateTempDirFunc()
+}
+
+// CreateTempDirCalls gets all the calls that were made to CreateTempDir.
+// Check the length with:
+//     len(mockedIFileSystem.CreateTempDirCalls())
+func (mock *IFileSystemMock) CreateTempDirCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCreateTempDir.RLock()
+	calls = mock.calls.CreateTempDir
+	mock.lockCreateTempDir.RUnlock()
+	return calls
+}
+
 // DeleteFile calls DeleteFileFunc.
 func (mock *IFileSystemMock) DeleteFile(path string) error {
 	if mock.DeleteFileFunc == nil {
@@ -190,6 +266,37 @@ func (mock *IFileSystemMock) FileExistsCalls() []struct {
 	return calls
 }
 
+// GetFileChecksum calls GetFileChecksumFunc.
+func (mock *IFileSystemMock) GetFileChecksum(filename string) (string, int64, error) {
+	if mock.GetFileChecksumFunc == nil {
+		panic("IFileSystemMock.GetFileChecksumFunc: method is nil but IFileSystem.GetFileChecksum was just called")
+	}
+	callInfo := struct {
+		Filename string
+	}{
+		Filename: filename,
+	}
+	mock.lockGetFileChecksum.Lock()
+	mock.calls.GetFileChecksum = append(mock.calls.GetFileChecksum, callInfo)
+	mock.lockGetFileChecksum.Unlock()
+	return mock.GetFileChecksumFunc(filename)
+}
+
+// GetFileChecksumCalls gets all the calls that were made to GetFileChecksum.
+// Check the length with:
+//     len(mockedIFileSystem.GetFileChecksumCalls())
+func (mock *IFileSystemMock) GetFileChecksumCalls() []struct {
+	Filename string
+} {
+	var calls []struct {
+		Filename string
+	}
+	mock.lockGetFileChecksum.RLock()
+	calls = mock.calls.GetFileChecksum
+	mock.lockGetFileChecksum.RUnlock()
+	return calls
+}
+
 // MakeDir calls MakeDirFunc.
 func (mock *IFileSystemMock) MakeDir(path string) error {
 	if mock.MakeDirFunc == nil {
@@ -221,6 +328,37 @@ func (mock *IFileSystemMock) MakeDirCalls() []struct {
 	return calls
 }
 
+// OpenFile calls OpenFileFunc.
+func (mock *IFileSystemMock) OpenFile(filename string) (io.ReadCloser, error) {
+	if mock.OpenFileFunc == nil {
+		panic("IFileSystemMock.OpenFileFunc: method is nil but IFileSystem.OpenFile was just called")
+	}
+	callInfo := struct {
+		Filename string
+	}{
+		Filename: filename,
+	}
+	mock.lockOpenFile.Lock()
+	mock.calls.OpenFile = append(mock.calls.OpenFile, callInfo)
+	mock.lockOpenFile.Unlock()
+	return mock.OpenFileFunc(filename)
+}
+
+// OpenFileCalls gets all the calls that were made to OpenFile.
+// Check the length with:
+//     len(mockedIFileSystem.OpenFileCalls())
+func (mock *IFileSystemMock) OpenFileCalls() []struct {
+	Filename string
+} {
+	var calls []struct {
+		Filename string
+	}
+	mock.lockOpenFile.RLock()
+	calls = mock.calls.OpenFile
+	mock.lockOpenFile.RUnlock()
+	return calls
+}
+
 // ReadFile calls ReadFileFunc.
 func (mock *IFileSystemMock) ReadFile(filename string) ([]byte, error) {
 	if mock.ReadFileFunc == nil {
@@ -357,6 +495,41 @@ func (mock *IFileSystemMock) WriteFileCalls() []struct {
 	return calls
 }
 
+// WriteFileFromReader calls WriteFileFromReaderFunc.
+func (mock *IFileSystemMock) WriteFileFromReader(path string, content io.Reader) error {
+	if mock.WriteFileFromReaderFunc == nil {
+		panic("IFileSystemMock.WriteFileFromReaderFunc: method is nil but IFileSystem.WriteFileFromReader was just called")
+	}
+	callInfo := struct {
+		Path    string
+		Content io.Reader
+	}{
+		Path:    path,
+		Content: content,
+	}
+	mock.lockWriteFileFromReader.Lock()
+	mock.calls.WriteFileFromReader = append(mock.calls.WriteFileFromReader, callInfo)
+	mock.lockWriteFileFromReader.Unlock()
+	return mock.WriteFileFromReaderFunc(path, content)
+}
+
+// WriteFileFromReaderCalls gets all the calls that were made to WriteFileFromReader.
+// Check the length with:
+//     len(mockedIFileSystem.WriteFileFromReaderCalls())
+func (mock *IFileSystemMock) WriteFileFromReaderCalls() []struct {
+	Path    string
+	Content io.Reader
+} {
+	var calls []struct {
+		Path    string
+		Content io.Reader
+	}
+	mock.lockWriteFileFromReader.RLock()
+	calls = mock.calls.WriteFileFromReader
+	mock.lockWriteFileFromReader.RUnlock()
+	return calls
+}
+
 // WriteHelmChart calls WriteHelmChartFunc.
 func (mock *IFileSystemMock) WriteHelmChart(path string) error {
 	if mock.WriteHelmChartFunc == nil {
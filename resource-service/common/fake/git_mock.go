@@ -23,18 +23,36 @@ import (
 // 			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
 // 				panic("mock out the CreateBranch method")
 // 			},
+// 			DeleteBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+// 				panic("mock out the DeleteBranch method")
+// 			},
+// 			FetchFunc: func(gitContext common_models.GitContext) error {
+// 				panic("mock out the Fetch method")
+// 			},
+// 			GetBranchesFunc: func(gitContext common_models.GitContext) ([]string, error) {
+// 				panic("mock out the GetBranches method")
+// 			},
 // 			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
 // 				panic("mock out the GetCurrentRevision method")
 // 			},
 // 			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) {
 // 				panic("mock out the GetDefaultBranch method")
 // 			},
+// 			GetFileDiffFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+// 				panic("mock out the GetFileDiff method")
+// 			},
+// 			GetFileHistoryFunc: func(gitContext common_models.GitContext, file string) ([]common_models.Commit, error) {
+// 				panic("mock out the GetFileHistory method")
+// 			},
 // 			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 // 				panic("mock out the GetFileRevision method")
 // 			},
 // 			MigrateProjectFunc: func(gitContext common_models.GitContext, newMetadatacontent []byte) error {
 // 				panic("mock out the MigrateProject method")
 // 			},
+// 			OpenFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) (*common_models.FileContent, error) {
+// 				panic("mock out the OpenFileRevision method")
+// 			},
 // 			ProjectExistsFunc: func(gitContext common_models.GitContext) bool {
 // 				panic("mock out the ProjectExists method")
 // 			},
@@ -53,6 +71,9 @@ import (
 // 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
 // 				panic("mock out the StageAndCommitAll method")
 // 			},
+// 			StageAndCommitToBranchFunc: func(gitContext common_models.GitContext, message string, branch string) (string, string, error) {
+// 				panic("mock out the StageAndCommitToBranch method")
+// 			},
 // 		}
 //
 // 		// use mockedIGit in code that requires common.IGit
@@ -69,18 +90,36 @@ type IGitMock struct {
 	// CreateBranchFunc mocks the CreateBranch method.
 	CreateBranchFunc func(gitContext common_models.GitContext, branch string, sourceBranch string) error
 
+	// DeleteBranchFunc mocks the DeleteBranch method.
+	DeleteBranchFunc func(gitContext common_models.GitContext, branch string) error
+
+	// FetchFunc mocks the Fetch method.
+	FetchFunc func(gitContext common_models.GitContext) error
+
+	// GetBranchesFunc mocks the GetBranches method.
+	GetBranchesFunc func(gitContext common_models.GitContext) ([]string, error)
+
 	// GetCurrentRevisionFunc mocks the GetCurrentRevision method.
 	GetCurrentRevisionFunc func(gitContext common_models.GitContext) (string, error)
 
 	// GetDefaultBranchFunc mocks the GetDefaultBranch method.
 	GetDefaultBranchFunc func(gitContext common_models.GitContext) (string, error)
 
+	// GetFileDiffFunc mocks the GetFileDiff method.
+	GetFileDiffFunc func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error)
+
+	// GetFileHistoryFunc mocks the GetFileHistory method.
+	GetFileHistoryFunc func(gitContext common_models.GitContext, file string) ([]common_models.Commit, error)
+
 	// GetFileRevisionFunc mocks the GetFileRevision method.
 	GetFileRevisionFunc func(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
 
 	// MigrateProjectFunc mocks the MigrateProject method.
 	MigrateProjectFunc func(gitContext common_models.GitContext, newMetadatacontent []byte) error
 
+	// OpenFileRevisionFunc mocks the OpenFileRevision method.
+	OpenFileRevisionFunc func(gitContext common_models.GitContext, revision string, file string) (*common_models.FileContent, error)
+
 	// ProjectExistsFunc mocks the ProjectExists method.
 	ProjectExistsFunc func(gitContext common_models.GitContext) bool
 
@@ -99,6 +138,9 @@ type IGitMock struct {
 	// StageAndCommitAllFunc mocks the StageAndCommitAll method.
 	StageAndCommitAllFunc func(gitContext common_models.GitContext, message string) (string, error)
 
+	// StageAndCommitToBranchFunc mocks the StageAndCommitToBranch method.
+	StageAndCommitToBranchFunc func(gitContext common_models.GitContext, message string, branch string) (string, string, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CheckoutBranch holds details about calls to the CheckoutBranch method.
@@ -122,6 +164,23 @@ type IGitMock struct {
 			// SourceBranch is the sourceBranch argument value.
 			SourceBranch string
 		}
+		// DeleteBranch holds details about calls to the DeleteBranch method.
+		DeleteBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// Fetch holds details about calls to the Fetch method.
+		Fetch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
+		// GetBranches holds details about calls to the GetBranches method.
+		GetBranches []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
 		// GetCurrentRevision holds details about calls to the GetCurrentRevision method.
 		GetCurrentRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -132,6 +191,24 @@ type IGitMock struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
 		}
+		// GetFileDiff holds details about calls to the GetFileDiff method.
+		GetFileDiff []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// FromRevision is the fromRevision argument value.
+			FromRevision string
+			// ToRevision is the toRevision argument value.
+			ToRevision string
+			// File is the file argument value.
+			File string
+		}
+		// GetFileHistory holds details about calls to the GetFileHistory method.
+		GetFileHistory []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// File is the file argument value.
+			File string
+		}
 		// GetFileRevision holds details about calls to the GetFileRevision method.
 		GetFileRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -148,6 +225,15 @@ type IGitMock struct {
 			// NewMetadatacontent is the newMetadatacontent argument value.
 			NewMetadatacontent []byte
 		}
+		// OpenFileRevision holds details about calls to the OpenFileRevision method.
+		OpenFileRevision []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
+			// File is the file argument value.
+			File string
+		}
 		// ProjectExists holds details about calls to the ProjectExists method.
 		ProjectExists []struct {
 			// GitContext is the gitContext argument value.
@@ -180,20 +266,36 @@ type IGitMock struct {
 			// Message is the message argument value.
 			Message string
 		}
+		// StageAndCommitToBranch holds details about calls to the StageAndCommitToBranch method.
+		StageAndCommitToBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Message is the message argument value.
+			Message string
+			// Branch is the branch argument value.
+			Branch string
+		}
 	}
-	lockCheckoutBranch     sync.RWMutex
-	lockCloneRepo          sync.RWMutex
-	lockCreateBranch       sync.RWMutex
-	lockGetCurrentRevision sync.RWMutex
-	lockGetDefaultBranch   sync.RWMutex
-	lockGetFileRevision    sync.RWMutex
-	lockMigrateProject     sync.RWMutex
-	lockProjectExists      sync.RWMutex
-	lockProjectRepoExists  sync.RWMutex
-	lockPull               sync.RWMutex
-	lockPush               sync.RWMutex
-	lockResetHard          sync.RWMutex
-	lockStageAndCommitAll  sync.RWMutex
+	lockCheckoutBranch         sync.RWMutex
+	lockCloneRepo              sync.RWMutex
+	lockCreateBranch           sync.RWMutex
+	lockDeleteBranch           sync.RWMutex
+	lockFetch                  sync.RWMutex
+	lockGetBranches            sync.RWMutex
+	lockGetCurrentRevision     sync.RWMutex
+	lockGetDefaultBranch       sync.RWMutex
+	lockGetFileDiff            sync.RWMutex
+	lockGetFileHistory         sync.RWMutex
+	lockGetFileRevision        sync.RWMutex
+	lockMigrateProject         sync.RWMutex
+	lockOpenFileRevision       sync.RWMutex
+	lockProjectExists          sync.RWMutex
+	lockProjectRepoExists      sync.RWMutex
+	lockPull                   sync.RWMutex
+	lockPush                   sync.RWMutex
+	lockResetHard              sync.RWMutex
+	lockStageAndCommitAll      sync.RWMutex
+	lockStageAndCommitToBranch sync.RWMutex
 }
 
 // CheckoutBranch calls CheckoutBranchFunc.
@@ -301,6 +403,103 @@ func (mock *IGitMock) CreateBranchCalls() []struct {
 	return calls
 }
 
+// DeleteBranch calls DeleteBranchFunc.
+func (mock *IGitMock) DeleteBranch(gitContext common_models.GitContext, branch string) error {
+	if mock.DeleteBranchFunc == nil {
+		panic("IGitMock.DeleteBranchFunc: method is nil but IGit.DeleteBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}{
+		GitContext: gitContext,
+		Branch:     branch,
+	}
+	mock.lockDeleteBranch.Lock()
+	mock.calls.DeleteBranch = append(mock.calls.DeleteBranch, callInfo)
+	mock.lockDeleteBranch.Unlock()
+	return mock.DeleteBranchFunc(gitContext, branch)
+}
+
+// DeleteBranchCalls gets all the calls that were made to DeleteBranch.
+// Check the length with:
+//     len(mockedIGit.DeleteBranchCalls())
+func (mock *IGitMock) DeleteBranchCalls() []struct {
+	GitContext common_models.GitContext
+	Branch     string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}
+	mock.lockDeleteBranch.RLock()
+	calls = mock.calls.DeleteBranch
+	mock.lockDeleteBranch.RUnlock()
+	return calls
+}
+
+// Fetch calls FetchFunc.
+func (mock *IGitMock) Fetch(gitContext common_models.GitContext) error {
+	if mock.FetchFunc == nil {
+		panic("IGitMock.FetchFunc: method is nil but IGit.Fetch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockFetch.Lock()
+	mock.calls.Fetch = append(mock.calls.Fetch, callInfo)
+	mock.lockFetch.Unlock()
+	return mock.FetchFunc(gitContext)
+}
+
+// FetchCalls gets all the calls that were made to Fetch.
+// Check the length with:
+//     len(mockedIGit.FetchCalls())
+func (mock *IGitMock) FetchCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockFetch.RLock()
+	calls = mock.calls.Fetch
+	mock.lockFetch.RUnlock()
+	return calls
+}
+
+// GetBranches calls GetBranchesFunc.
+func (mock *IGitMock) GetBranches(gitContext common_models.GitContext) ([]string, error) {
+	if mock.GetBranchesFunc == nil {
+		panic("IGitMock.GetBranchesFunc: method is nil but IGit.GetBranches was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockGetBranches.Lock()
+	mock.calls.GetBranches = append(mock.calls.GetBranches, callInfo)
+	mock.lockGetBranches.Unlock()
+	return mock.GetBranchesFunc(gitContext)
+}
+
+// GetBranchesCalls gets all the calls that were made to GetBranches.
+// Check the length with:
+//     len(mockedIGit.GetBranchesCalls())
+func (mock *IGitMock) GetBranchesCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockGetBranches.RLock()
+	calls = mock.calls.GetBranches
+	mock.lockGetBranches.RUnlock()
+	return calls
+}
+
 // GetCurrentRevision calls GetCurrentRevisionFunc.
 func (mock *IGitMock) GetCurrentRevision(gitContext common_models.GitContext) (string, error) {
 	if mock.GetCurrentRevisionFunc == nil {
@@ -363,6 +562,84 @@ func (mock *IGitMock) GetDefaultBranchCalls() []struct {
 	return calls
 }
 
+// GetFileDiff calls GetFileDiffFunc.
+func (mock *IGitMock) GetFileDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+	if mock.GetFileDiffFunc == nil {
+		panic("IGitMock.GetFileDiffFunc: method is nil but IGit.GetFileDiff was just called")
+	}
+	callInfo := struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+		File         string
+	}{
+		GitContext:   gitContext,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+		File:         file,
+	}
+	mock.lockGetFileDiff.Lock()
+	mock.calls.GetFileDiff = append(mock.calls.GetFileDiff, callInfo)
+	mock.lockGetFileDiff.Unlock()
+	return mock.GetFileDiffFunc(gitContext, fromRevision, toRevision, file)
+}
+
+// GetFileDiffCalls gets all the calls that were made to GetFileDiff.
+// Check the length with:
+//     len(mockedIGit.GetFileDiffCalls())
+func (mock *IGitMock) GetFileDiffCalls() []struct {
+	GitContext   common_models.GitContext
+	FromRevision string
+	ToRevision   string
+	File         string
+} {
+	var calls []struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+		File         string
+	}
+	mock.lockGetFileDiff.RLock()
+	calls = mock.calls.GetFileDiff
+	mock.lockGetFileDiff.RUnlock()
+	return calls
+}
+
+// GetFileHistory calls GetFileHistoryFunc.
+func (mock *IGitMock) GetFileHistory(gitContext common_models.GitContext, file string) ([]common_models.Commit, error) {
+	if mock.GetFileHistoryFunc == nil {
+		panic("IGitMock.GetFileHistoryFunc: method is nil but IGit.GetFileHistory was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		File       string
+	}{
+		GitContext: gitContext,
+		File:       file,
+	}
+	mock.lockGetFileHistory.Lock()
+	mock.calls.GetFileHistory = append(mock.calls.GetFileHistory, callInfo)
+	mock.lockGetFileHistory.Unlock()
+	return mock.GetFileHistoryFunc(gitContext, file)
+}
+
+// GetFileHistoryCalls gets all the calls that were made to GetFileHistory.
+// Check the length with:
+//     len(mockedIGit.GetFileHistoryCalls())
+func (mock *IGitMock) GetFileHistoryCalls() []struct {
+	GitContext common_models.GitContext
+	File       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		File       string
+	}
+	mock.lockGetFileHistory.RLock()
+	calls = mock.calls.GetFileHistory
+	mock.lockGetFileHistory.RUnlock()
+	return calls
+}
+
 // GetFileRevision calls GetFileRevisionFunc.
 func (mock *IGitMock) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 	if mock.GetFileRevisionFunc == nil {
@@ -437,6 +714,45 @@ func (mock *IGitMock) MigrateProjectCalls() []struct {
 	return calls
 }
 
+// OpenFileRevision calls OpenFileRevisionFunc.
+func (mock *IGitMock) OpenFileRevision(gitContext common_models.GitContext, revision string, file string) (*common_models.FileContent, error) {
+	if mock.OpenFileRevisionFunc == nil {
+		panic("IGitMock.OpenFileRevisionFunc: method is nil but IGit.OpenFileRevision was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Revision   string
+		File       string
+	}{
+		GitContext: gitContext,
+		Revision:   revision,
+		File:       file,
+	}
+	mock.lockOpenFileRevision.Lock()
+	mock.calls.OpenFileRevision = append(mock.calls.OpenFileRevision, callInfo)
+	mock.lockOpenFileRevision.Unlock()
+	return mock.OpenFileRevisionFunc(gitContext, revision, file)
+}
+
+// OpenFileRevisionCalls gets all the calls that were made to OpenFileRevision.
+// Check the length with:
+//     len(mockedIGit.OpenFileRevisionCalls())
+func (mock *IGitMock) OpenFileRevisionCalls() []struct {
+	GitContext common_models.GitContext
+	Revision   string
+	File       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Revision   string
+		File       string
+	}
+	mock.lockOpenFileRevision.RLock()
+	calls = mock.calls.OpenFileRevision
+	mock.lockOpenFileRevision.RUnlock()
+	return calls
+}
+
 // ProjectExists calls ProjectExistsFunc.
 func (mock *IGitMock) ProjectExists(gitContext common_models.GitContext) bool {
 	if mock.ProjectExistsFunc == nil {
@@ -626,3 +942,42 @@ func (mock *IGitMock) StageAndCommitAllCalls() []struct {
 	mock.lockStageAndCommitAll.RUnlock()
 	return calls
 }
+
+// StageAndCommitToBranch calls StageAndCommitToBranchFunc.
+func (mock *IGitMock) StageAndCommitToBranch(gitContext common_models.GitContext, message string, branch string) (string, string, error) {
+	if mock.StageAndCommitToBranchFunc == nil {
+		panic("IGitMock.StageAndCommitToBranchFunc: method is nil but IGit.StageAndCommitToBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Message    string
+		Branch     string
+	}{
+		GitContext: gitContext,
+		Message:    message,
+		Branch:     branch,
+	}
+	mock.lockStageAndCommitToBranch.Lock()
+	mock.calls.StageAndCommitToBranch = append(mock.calls.StageAndCommitToBranch, callInfo)
+	mock.lockStageAndCommitToBranch.Unlock()
+	return mock.StageAndCommitToBranchFunc(gitContext, message, branch)
+}
+
+// StageAndCommitToBranchCalls gets all the calls that were made to StageAndCommitToBranch.
+// Check the length with:
+//     len(mockedIGit.StageAndCommitToBranchCalls())
+func (mock *IGitMock) StageAndCommitToBranchCalls() []struct {
+	GitContext common_models.GitContext
+	Message    string
+	Branch     string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Message    string
+		Branch     string
+	}
+	mock.lockStageAndCommitToBranch.RLock()
+	calls = mock.calls.StageAndCommitToBranch
+	mock.lockStageAndCommitToBranch.RUnlock()
+	return calls
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+// FileProjectLocker locks projects using advisory file locks on lock files in the given directory.
+// If the directory is located on a volume that is shared between the replicas of the resource-service, a project is only modified by one replica at a time.
+// The locks are released by the operating system if the process holding them terminates
+type FileProjectLocker struct {
+	lockDir string
+	mu      sync.Mutex
+	files   map[string]*os.File
+}
+
+func NewFileProjectLocker(lockDir string) *FileProjectLocker {
+	return &FileProjectLocker{
+		lockDir: lockDir,
+		files:   map[string]*os.File{},
+	}
+}
+
+// Lock blocks until the lock file of the given project could be locked exclusively
+func (l *FileProjectLocker) Lock(project string) error {
+	if err := ensureDirectoryExists(l.lockDir); err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotCreatePath, l.lockDir, err)
+	}
+	file, err := os.OpenFile(l.getLockFilePath(project), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open lock file of project %s: %w", project, err)
+	}
+	if err := flock(file, syscall.LOCK_EX); err != nil {
+		file.Close()
+		return fmt.Errorf("could not lock project %s: %w", project, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.files[project] = file
+	return nil
+}
+
+// Unlock releases the lock file of the given project
+func (l *FileProjectLocker) Unlock(project string) error {
+	l.mu.Lock()
+	file, ok := l.files[project]
+	delete(l.files, project)
+	l.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("project %s is not locked", project)
+	}
+	defer file.Close()
+	if err := flock(file, syscall.LOCK_UN); err != nil {
+		return fmt.Errorf("could not unlock project %s: %w", project, err)
+	}
+	return nil
+}
+
+func (l *FileProjectLocker) getLockFilePath(project string) string {
+	return filepath.Join(l.lockDir, project+".lock")
+}
+
+func flock(file *os.File, how int) error {
+	for {
+		err := syscall.Flock(int(file.Fd()), how)
+		// the call is interrupted if the process receives a signal while waiting for the lock
+		if !errors.Is(err, syscall.EINTR) {
+			return err
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProjectLocker(t *testing.T) {
+	lockDir := t.TempDir() + "/locks"
+
+	// two lockers on the same directory behave like two replicas sharing a volume
+	replica1 := NewFileProjectLocker(lockDir)
+	replica2 := NewFileProjectLocker(lockDir)
+
+	require.Nil(t, replica1.Lock("my-project"))
+	require.FileExists(t, lockDir+"/my-project.lock")
+
+	// other projects are not affected
+	require.Nil(t, replica2.Lock("other-project"))
+	require.Nil(t, replica2.Unlock("other-project"))
+
+	locked := make(chan struct{})
+	go func() {
+		require.Nil(t, replica2.Lock("my-project"))
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("project has been locked by two replicas at once")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.Nil(t, replica1.Unlock("my-project"))
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("project has not been locked after it has been released")
+	}
+	require.Nil(t, replica2.Unlock("my-project"))
+}
+
+func TestFileProjectLocker_UnlockNotLockedProject(t *testing.T) {
+	locker := NewFileProjectLocker(t.TempDir())
+
+	require.NotNil(t, locker.Unlock("my-project"))
+}
@@ -1,7 +1,10 @@
 package common
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
@@ -21,7 +24,11 @@ type IFileSystem interface {
 	WriteBase64EncodedFile(path string, content string) error
 	WriteHelmChart(path string) error
 	WriteFile(path string, content []byte) error
+	WriteFileFromReader(path string, content io.Reader) error
 	ReadFile(filename string) ([]byte, error)
+	OpenFile(filename string) (io.ReadCloser, error)
+	GetFileChecksum(filename string) (string, int64, error)
+	CreateTempDir() (string, error)
 	DeleteFile(path string) error
 	FileExists(path string) bool
 	MakeDir(path string) error
@@ -89,6 +96,23 @@ func (fw FileSystem) WriteFile(path string, content []byte) error {
 	return err
 }
 
+// WriteFileFromReader streams the content into the file, without holding the whole content in memory
+func (fw FileSystem) WriteFileFromReader(path string, content io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, content); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
 func (fw FileSystem) WriteHelmChart(path string) error {
 	// remove previous helm/resourceURI folder
 	targetFolderPath := strings.TrimSuffix(path, ".tgz")
@@ -132,6 +156,40 @@ func (fw FileSystem) ReadFile(filename string) ([]byte, error) {
 	return ioutil.ReadFile(filename)
 }
 
+func (fw FileSystem) OpenFile(filename string) (io.ReadCloser, error) {
+	file, err := os.Open(filepath.Clean(filename))
+	if os.IsNotExist(err) {
+		return nil, errors2.ErrResourceNotFound
+	}
+	return file, err
+}
+
+// GetFileChecksum returns the checksum and the size of the file content. For Git LFS pointers, the checksum and size of the
+// referenced file are returned
+func (fw FileSystem) GetFileChecksum(filename string) (string, int64, error) {
+	file, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	head := &bytes.Buffer{}
+	size, err := io.Copy(io.MultiWriter(hash, &limitedWriter{w: head, n: lfsPointerMaxSize + 1}), file)
+	if err != nil {
+		return "", 0, err
+	}
+	if pointer, ok := ParseLFSPointer(head.Bytes()); ok {
+		return "sha256:" + pointer.OID, pointer.Size, nil
+	}
+	return FormatChecksum(hash.Sum(nil)), size, nil
+}
+
+// CreateTempDir creates a temporary directory on the same volume as the repositories
+func (fw FileSystem) CreateTempDir() (string, error) {
+	return ioutil.TempDir(fw.tmpDirLocation, ".tmp-*")
+}
+
 func (FileSystem) DeleteFile(path string) error {
 	var err = os.RemoveAll(path)
 	if err != nil {
@@ -207,6 +265,32 @@ func (fw FileSystem) untarHelm(filePath string) error {
 	return nil
 }
 
+// FormatChecksum returns the representation of a SHA-256 hash used for reporting the checksum of resources
+func FormatChecksum(sha256Hash []byte) string {
+	return "sha256:" + hex.EncodeToString(sha256Hash)
+}
+
+// limitedWriter keeps at most n bytes of the written content and discards the rest
+type limitedWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if lw.n > 0 {
+		chunk := p
+		if int64(len(chunk)) > lw.n {
+			chunk = chunk[:lw.n]
+		}
+		written, err := lw.w.Write(chunk)
+		lw.n -= int64(written)
+		if err != nil {
+			return written, err
+		}
+	}
+	return len(p), nil
+}
+
 func IsHelmChartPath(resourcePath string) bool {
 	logger.Debug("Checking for helm chart")
 	resourcePathSlice := strings.Split(resourcePath, "/")
@@ -1,8 +1,12 @@
 package common
 
 import (
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -102,3 +106,45 @@ func TestFileSystem_WriteHelmChart(t *testing.T) {
 
 	require.Equal(t, "test\n", string(res))
 }
+
+func TestFileSystem_WriteFileFromReader(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := FileSystem{}
+
+	filePath := dir + "/helm/my-file"
+
+	err := fs.WriteFileFromReader(filePath, strings.NewReader("file-content"))
+	require.Nil(t, err)
+
+	file, err := fs.OpenFile(filePath)
+	require.Nil(t, err)
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	require.Nil(t, err)
+	require.Equal(t, "file-content", string(content))
+
+	_, err = fs.OpenFile(dir + "/other-file")
+	require.ErrorIs(t, err, errors.ErrResourceNotFound)
+}
+
+func TestFileSystem_GetFileChecksum(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := FileSystem{}
+
+	require.Nil(t, fs.WriteFile(dir+"/my-file", []byte("file-content")))
+	pointer := common_models.LFSPointer{OID: strings.Repeat("a", 64), Size: 1024 * 1024}
+	require.Nil(t, fs.WriteFile(dir+"/my-lfs-file", []byte(pointer.String())))
+
+	checksum, size, err := fs.GetFileChecksum(dir + "/my-file")
+	require.Nil(t, err)
+	require.Equal(t, "sha256:2239ce4df9ee8db012834642ec801b55ba2c92b28bdd11f4d73d9c55d39f3b0a", checksum)
+	require.Equal(t, int64(12), size)
+
+	checksum, size, err = fs.GetFileChecksum(dir + "/my-lfs-file")
+	require.Nil(t, err)
+	require.Equal(t, "sha256:"+pointer.OID, checksum)
+	require.Equal(t, pointer.Size, size)
+}
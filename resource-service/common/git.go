@@ -1,6 +1,8 @@
 package common
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -9,6 +11,8 @@ import (
 	nethttp "net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -33,11 +37,18 @@ type IGit interface {
 	ProjectRepoExists(projectName string) bool
 	CloneRepo(gitContext common_models.GitContext) (bool, error)
 	StageAndCommitAll(gitContext common_models.GitContext, message string) (string, error)
+	StageAndCommitToBranch(gitContext common_models.GitContext, message string, branch string) (string, string, error)
 	Push(gitContext common_models.GitContext) error
 	Pull(gitContext common_models.GitContext) error
 	CreateBranch(gitContext common_models.GitContext, branch string, sourceBranch string) error
+	DeleteBranch(gitContext common_models.GitContext, branch string) error
 	CheckoutBranch(gitContext common_models.GitContext, branch string) error
+	GetBranches(gitContext common_models.GitContext) ([]string, error)
+	Fetch(gitContext common_models.GitContext) error
 	GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
+	OpenFileRevision(gitContext common_models.GitContext, revision string, file string) (*common_models.FileContent, error)
+	GetFileHistory(gitContext common_models.GitContext, file string) ([]common_models.Commit, error)
+	GetFileDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error)
 	GetCurrentRevision(gitContext common_models.GitContext) (string, error)
 	GetDefaultBranch(gitContext common_models.GitContext) (string, error)
 	MigrateProject(gitContext common_models.GitContext, newMetadatacontent []byte) error
@@ -45,13 +56,40 @@ type IGit interface {
 }
 
 type Git struct {
-	git Gogit
+	git              Gogit
+	upstreamMirror   *UpstreamMirror
+	signingKeyReader SigningKeyReader
+	lfsClient        ILFSClient
+	lfsThreshold     int64
 }
 
 func NewGit(git Gogit) *Git {
 	return &Git{git: git}
 }
 
+// SetUpstreamMirror enables serving read-only operations from the local repository, as long as it has been synchronized with
+// the upstream within the freshness bound of the mirror
+func (g *Git) SetUpstreamMirror(upstreamMirror *UpstreamMirror) {
+	g.upstreamMirror = upstreamMirror
+}
+
+// isServedFromMirror returns true if the upstream does not need to be contacted for the operation
+func (g *Git) isServedFromMirror(gitContext common_models.GitContext) bool {
+	return gitContext.ReadOnly && g.upstreamMirror.IsFresh(gitContext.Project)
+}
+
+// SetSigningKeyReader enables signing the commits of projects that have a signing key
+func (g *Git) SetSigningKeyReader(signingKeyReader SigningKeyReader) {
+	g.signingKeyReader = signingKeyReader
+}
+
+// SetLFS enables resolving Git LFS pointers using the given client. Files exceeding the threshold are committed as LFS pointers,
+// unless the threshold is 0
+func (g *Git) SetLFS(lfsClient ILFSClient, threshold int64) {
+	g.lfsClient = lfsClient
+	g.lfsThreshold = threshold
+}
+
 func configureGitUser(repository *git.Repository) error {
 
 	c, err := repository.Config()
@@ -245,7 +283,7 @@ func (g Git) init(gitContext common_models.GitContext, projectPath string) (*git
 }
 
 func (g Git) commitAll(gitContext common_models.GitContext, message string) (string, error) {
-	_, w, err := g.getWorkTree(gitContext)
+	r, w, err := g.getWorkTree(gitContext)
 	if err != nil {
 		return "", err
 	}
@@ -253,20 +291,124 @@ func (g Git) commitAll(gitContext common_models.GitContext, message string) (str
 		message = "commit changes"
 	}
 
+	if err := g.storeLargeFiles(gitContext, w); err != nil {
+		return "", err
+	}
+
 	err = w.AddWithOptions(&git.AddOptions{All: true})
 	if err != nil {
 		return "", err
 	}
-	id, err := w.Commit(message,
-		&git.CommitOptions{
-			All: true,
-			Author: &object.Signature{
-				Name:  getGitKeptnUser(),
-				Email: getGitKeptnEmail(),
-				When:  time.Now(),
-			},
-		})
-	return id.String(), err
+
+	signingKey, err := g.getSigningKey(gitContext.Project)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	committer := &object.Signature{
+		Name:  getGitKeptnUser(),
+		Email: getGitKeptnEmail(),
+		When:  now,
+	}
+	author := committer
+	if gitContext.Author != nil {
+		author = &object.Signature{
+			Name:  gitContext.Author.Name,
+			Email: gitContext.Author.Email,
+			When:  now,
+		}
+	}
+	options := &git.CommitOptions{
+		All:       true,
+		Author:    author,
+		Committer: committer,
+	}
+	if gitContext.MergedRevision != "" {
+		head, err := r.Head()
+		if err != nil {
+			return "", err
+		}
+		options.Parents = []plumbing.Hash{head.Hash(), plumbing.NewHash(gitContext.MergedRevision)}
+	}
+	if signingKey != nil && signingKey.Format == common_models.SigningKeyFormatGPG {
+		options.SignKey, err = readGPGSigningKey(*signingKey)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	id, err := w.Commit(message, options)
+	if err != nil {
+		return "", err
+	}
+	if signingKey != nil && signingKey.Format == common_models.SigningKeyFormatSSH {
+		id, err = g.signCommitSSH(gitContext, *signingKey, id)
+		if err != nil {
+			return "", err
+		}
+	}
+	return id.String(), nil
+}
+
+// getSigningKey returns the key for signing the commits of the project, or nil if the commits are not signed
+func (g Git) getSigningKey(project string) (*common_models.SigningKey, error) {
+	if g.signingKeyReader == nil {
+		return nil, nil
+	}
+	return g.signingKeyReader.GetSigningKey(project)
+}
+
+// signCommitSSH replaces the given commit at the HEAD of the current branch with a copy that is signed using an SSH key,
+// since go-git only supports signing commits with OpenPGP keys
+func (g Git) signCommitSSH(gitContext common_models.GitContext, signingKey common_models.SigningKey, id plumbing.Hash) (plumbing.Hash, error) {
+	signer, err := readSSHSigningKey(signingKey)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	commit, err := r.CommitObject(id)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	payload := r.Storer.NewEncodedObject()
+	if err := commit.EncodeWithoutSignature(payload); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	reader, err := payload.Reader()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	defer reader.Close()
+	message, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	commit.PGPSignature, err = signSSH(signer, message)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	signed := r.Storer.NewEncodedObject()
+	if err := commit.Encode(signed); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	signedID, err := r.Storer.SetEncodedObject(signed)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	head, err := r.Head()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := r.Storer.SetReference(plumbing.NewHashReference(head.Name(), signedID)); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return signedID, nil
 }
 
 func (g Git) StageAndCommitAll(gitContext common_models.GitContext, message string) (string, error) {
@@ -304,6 +446,57 @@ func (g Git) StageAndCommitAll(gitContext common_models.GitContext, message stri
 	return id, nil
 }
 
+// StageAndCommitToBranch commits all changes to a new branch, which is created from the current branch and pushed to the upstream.
+// The current branch is not modified and is checked out again afterwards. Returns the id of the commit and the name of the
+// branch the new branch has been created from
+func (g Git) StageAndCommitToBranch(gitContext common_models.GitContext, message string, branch string) (string, string, error) {
+	if gitContext.Credentials == nil {
+		return "", "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, gitContext.Project, kerrors.ErrCredentialsNotFound)
+	}
+	r, w, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return "", "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, gitContext.Project, err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		return "", "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, gitContext.Project, err)
+	}
+
+	b := plumbing.NewBranchReferenceName(branch)
+	// the changes of the worktree are kept, so that they are committed to the new branch
+	if err := w.Checkout(&git.CheckoutOptions{Branch: b, Create: true, Keep: true}); err != nil {
+		return "", "", fmt.Errorf(kerrors.ErrMsgCouldNotCreate, branch, gitContext.Project, err)
+	}
+	defer func() {
+		if err := w.Checkout(&git.CheckoutOptions{Branch: head.Name(), Force: true}); err != nil {
+			logger.WithError(err).Warnf("could not check out branch %s", head.Name().Short())
+		}
+		if err := r.Storer.RemoveReference(b); err != nil {
+			logger.WithError(err).Warnf("could not remove local branch %s", branch)
+		}
+	}()
+
+	id, err := g.commitAll(gitContext, message)
+	if err != nil {
+		return "", "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, gitContext.Project, err)
+	}
+
+	auth, err := getAuthMethod(gitContext)
+	if err != nil {
+		return "", "", err
+	}
+	err = r.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{config.RefSpec(b.String() + ":" + b.String())},
+		Auth:            auth,
+		InsecureSkipTLS: retrieveInsecureSkipTLS(gitContext.Credentials),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
+	return id, head.Name().Short(), nil
+}
+
 func (g Git) Push(gitContext common_models.GitContext) error {
 	var err error
 	if gitContext.Credentials == nil {
@@ -332,6 +525,9 @@ func (g Git) Push(gitContext common_models.GitContext) error {
 }
 
 func (g *Git) Pull(gitContext common_models.GitContext) error {
+	if g.isServedFromMirror(gitContext) {
+		return nil
+	}
 	if g.ProjectExists(gitContext) {
 		r, w, err := g.getWorkTree(gitContext)
 		if err != nil {
@@ -463,6 +659,58 @@ func (g *Git) CreateBranch(gitContext common_models.GitContext, branch string, s
 	return nil
 }
 
+// DeleteBranch deletes the given branch locally and in the upstream repository. The default branch is checked out beforehand
+func (g *Git) DeleteBranch(gitContext common_models.GitContext, branch string) error {
+	if gitContext.Credentials == nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, kerrors.ErrCredentialsNotFound)
+	}
+	defaultBranch, err := g.GetDefaultBranch(gitContext)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+	// the worktree must not point to the branch that is deleted
+	if err := g.CheckoutBranch(gitContext, defaultBranch); err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+	b := plumbing.NewBranchReferenceName(branch)
+	if _, err := r.Reference(b, false); err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, kerrors.ErrBranchNotFound)
+		}
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+
+	auth, err := getAuthMethod(gitContext)
+	if err != nil {
+		return err
+	}
+	err = r.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{config.RefSpec(":" + b.String())},
+		Auth:            auth,
+		InsecureSkipTLS: retrieveInsecureSkipTLS(gitContext.Credentials),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+
+	if err := r.Storer.RemoveReference(b); err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+	if err := r.Storer.RemoveReference(plumbing.NewRemoteReferenceName("origin", branch)); err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+	if err := r.DeleteBranch(branch); err != nil && !errors.Is(err, git.ErrBranchNotFound) {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+	return nil
+}
+
 func (g *Git) CheckoutBranch(gitContext common_models.GitContext, branch string) error {
 	//  short path
 	b := plumbing.NewBranchReferenceName(branch)
@@ -484,14 +732,42 @@ func (g *Git) CheckoutBranch(gitContext common_models.GitContext, branch string)
 	return nil
 }
 
+// GetBranches returns the short names of all branches of the repository, including the branches that are only available upstream
+func (g *Git) GetBranches(gitContext common_models.GitContext) ([]string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return nil, err
+	}
+	if !g.isServedFromMirror(gitContext) {
+		if err := g.fetch(gitContext, r); err != nil {
+			return nil, err
+		}
+	}
+	refs, err := r.Branches()
+	if err != nil {
+		return nil, err
+	}
+	branches := []string{}
+	err = refs.ForEach(func(branch *plumbing.Reference) error {
+		branches = append(branches, branch.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
 func (g *Git) checkoutBranch(gitContext common_models.GitContext, options *git.CheckoutOptions) error {
 	if g.ProjectExists(gitContext) {
 		r, w, err := g.getWorkTree(gitContext)
 		if err != nil {
 			return err
 		}
-		if err = g.fetch(gitContext, r); err != nil {
-			return err
+		if !g.isServedFromMirror(gitContext) {
+			if err = g.fetch(gitContext, r); err != nil {
+				return err
+			}
 		}
 		return w.Checkout(options)
 	}
@@ -515,57 +791,363 @@ func (g *Git) fetch(gitContext common_models.GitContext, r *git.Repository) erro
 	}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
 		return err
 	}
+	g.upstreamMirror.MarkSynced(gitContext.Project)
+	return nil
+}
+
+// Fetch updates all references of the local repository with the ones of the upstream repository
+func (g *Git) Fetch(gitContext common_models.GitContext) error {
+	if !g.ProjectRepoExists(gitContext.Project) {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "fetch", gitContext.Project, kerrors.ErrProjectNotFound)
+	}
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "fetch", gitContext.Project, err)
+	}
+	if err := g.fetch(gitContext, r); err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "fetch", gitContext.Project, err)
+	}
 	return nil
 }
 
 func (g *Git) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+	blob, err := g.getFileBlob(gitContext, revision, file)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	content, err := readBlob(gitContext, blob)
+	if err != nil {
+		return []byte{}, err
+	}
+	if pointer, ok := ParseLFSPointer(content); ok && g.lfsClient != nil {
+		objectPath, err := g.resolveLFSObject(gitContext, *pointer)
+		if err != nil {
+			return []byte{}, err
+		}
+		return ioutil.ReadFile(objectPath)
+	}
+	return content, nil
+}
+
+// OpenFileRevision returns a reader for the content of the file in the given revision, together with its size and checksum.
+// Files stored in Git LFS are streamed from the local LFS storage, after downloading them if necessary
+func (g *Git) OpenFileRevision(gitContext common_models.GitContext, revision string, file string) (*common_models.FileContent, error) {
+	blob, err := g.getFileBlob(gitContext, revision, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if blob.Size <= lfsPointerMaxSize {
+		content, err := readBlob(gitContext, blob)
+		if err != nil {
+			return nil, err
+		}
+		if pointer, ok := ParseLFSPointer(content); ok && g.lfsClient != nil {
+			return g.openLFSObject(gitContext, *pointer)
+		}
+		checksum := sha256.Sum256(content)
+		return &common_models.FileContent{
+			Content:  ioutil.NopCloser(bytes.NewReader(content)),
+			Size:     int64(len(content)),
+			Checksum: FormatChecksum(checksum[:]),
+		}, nil
+	}
+
+	// blobs are immutable, therefore they can be read twice for calculating the checksum before streaming the content
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+	}
+	hash := sha256.New()
+	_, err = io.Copy(hash, reader)
+	reader.Close()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+	}
+	reader, err = blob.Reader()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+	}
+	return &common_models.FileContent{
+		Content:  reader,
+		Size:     blob.Size,
+		Checksum: FormatChecksum(hash.Sum(nil)),
+	}, nil
+}
+
+// openLFSObject returns a reader for the object of the given pointer. The size and checksum are taken from the pointer,
+// since the object has been verified against it when it has been stored
+func (g *Git) openLFSObject(gitContext common_models.GitContext, pointer common_models.LFSPointer) (*common_models.FileContent, error) {
+	objectPath, err := g.resolveLFSObject(gitContext, pointer)
+	if err != nil {
+		return nil, err
+	}
+	object, err := os.Open(objectPath)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+	}
+	return &common_models.FileContent{
+		Content:  object,
+		Size:     pointer.Size,
+		Checksum: "sha256:" + pointer.OID,
+	}, nil
+}
+
+func readBlob(gitContext common_models.GitContext, blob *object.Blob) ([]byte, error) {
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+	}
+	defer reader.Close()
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+	}
+	return content, nil
+}
+
+func (g *Git) getFileBlob(gitContext common_models.GitContext, revision string, file string) (*object.Blob, error) {
 	path := GetProjectConfigPath(gitContext.Project)
 	r, err := g.git.PlainOpen(path)
 	if err != nil {
 		logger.Debugf("Could not open project %s: %s", file, err)
-		return []byte{},
+		return nil,
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
 	}
 	h, err := r.ResolveRevision(plumbing.Revision(revision))
 	if err != nil {
 		logger.Debugf("Could not resolve revision for %s: %s", revision, err)
-		return []byte{},
-			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+		return nil,
+			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, kerrors.ErrResolveRevision)
 	}
 	if h == nil {
-		return []byte{},
+		return nil,
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, kerrors.ErrResolvedNilHash)
 	}
 
 	obj, err := r.Object(plumbing.CommitObject, *h)
 
 	if err != nil {
-		return []byte{},
+		return nil,
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
 	}
 	if obj == nil {
-		return []byte{}, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, kerrors.ErrResolveRevision)
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, kerrors.ErrResolveRevision)
 	}
 	blob, err := resolve(obj, file)
 
 	if err != nil {
 		if errors.Is(err, object.ErrFileNotFound) {
-			return []byte{},
+			return nil,
 				fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, kerrors.ErrResourceNotFound)
 		}
-		return []byte{},
+		return nil,
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
 	}
+	return blob, nil
+}
+
+// storeLargeFiles replaces the changed files exceeding the LFS threshold with LFS pointers, after uploading them to the LFS
+// storage of the upstream repository. The replaced files are tracked in the .gitattributes file of the repository
+func (g Git) storeLargeFiles(gitContext common_models.GitContext, w *git.Worktree) error {
+	if g.lfsClient == nil || g.lfsThreshold <= 0 {
+		return nil
+	}
+	status, err := w.Status()
+	if err != nil {
+		return err
+	}
+
+	projectPath := GetProjectConfigPath(gitContext.Project)
+	largeFiles := []string{}
+	for file, fileStatus := range status {
+		if fileStatus.Worktree == git.Deleted || (fileStatus.Worktree == git.Unmodified && fileStatus.Staging == git.Unmodified) {
+			continue
+		}
+		filePath := filepath.Join(projectPath, file)
+		info, err := os.Stat(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Size() <= g.lfsThreshold {
+			continue
+		}
+		if err := g.storeLFSObject(gitContext, filePath); err != nil {
+			return err
+		}
+		largeFiles = append(largeFiles, file)
+	}
+	if len(largeFiles) == 0 {
+		return nil
+	}
+	sort.Strings(largeFiles)
+	return trackLFSFiles(projectPath, largeFiles)
+}
 
-	var re (io.Reader)
-	re, err = blob.Reader()
+// storeLFSObject moves the file to the local LFS storage, uploads it to the LFS storage of the upstream repository and
+// replaces it with an LFS pointer
+func (g Git) storeLFSObject(gitContext common_models.GitContext, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	pointer, err := writeLFSObject(gitContext.Project, file)
+	file.Close()
+	if err != nil {
+		return err
+	}
 
+	object, err := os.Open(lfsObjectPath(gitContext.Project, pointer.OID))
 	if err != nil {
-		return []byte{},
-			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+		return err
 	}
+	defer object.Close()
+	if err := g.lfsClient.Upload(gitContext, *pointer, object); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, []byte(pointer.String()), 0644)
+}
 
-	return ioutil.ReadAll(re)
+// resolveLFSObject returns the path of the LFS object within the local LFS storage, after downloading it if necessary
+func (g Git) resolveLFSObject(gitContext common_models.GitContext, pointer common_models.LFSPointer) (string, error) {
+	objectPath := lfsObjectPath(gitContext.Project, pointer.OID)
+	if _, err := os.Stat(objectPath); err == nil {
+		return objectPath, nil
+	}
+
+	content, err := g.lfsClient.Download(gitContext, pointer)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+	downloaded, err := writeLFSObject(gitContext.Project, content)
+	if err != nil {
+		return "", err
+	}
+	if *downloaded != pointer {
+		os.Remove(lfsObjectPath(gitContext.Project, downloaded.OID))
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, pointer.OID, fmt.Errorf("checksum mismatch"))
+	}
+	return objectPath, nil
+}
+
+// trackLFSFiles adds the files to the .gitattributes file of the repository, so that git clients store them in Git LFS as well
+func trackLFSFiles(projectPath string, files []string) error {
+	attributesPath := filepath.Join(projectPath, ".gitattributes")
+	attributes, err := os.ReadFile(attributesPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	existing := map[string]bool{}
+	for _, line := range strings.Split(string(attributes), "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+	if len(attributes) > 0 && !bytes.HasSuffix(attributes, []byte("\n")) {
+		attributes = append(attributes, '\n')
+	}
+	for _, file := range files {
+		// spaces separate the pattern from the attributes, git-lfs escapes them the same way
+		line := "/" + strings.ReplaceAll(file, " ", "[[:space:]]") + " " + lfsAttributes
+		if !existing[line] {
+			attributes = append(attributes, []byte(line+"\n")...)
+		}
+	}
+	return os.WriteFile(attributesPath, attributes, 0644)
+}
+
+// GetFileHistory returns the commits of the current branch that changed the given file, starting with the most recent one
+func (g *Git) GetFileHistory(gitContext common_models.GitContext, file string) ([]common_models.Commit, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve history in", gitContext.Project, err)
+	}
+	commitIter, err := r.Log(&git.LogOptions{From: head.Hash(), FileName: &file})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve history in", gitContext.Project, err)
+	}
+	defer commitIter.Close()
+
+	commits := []common_models.Commit{}
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, common_models.Commit{
+			ID:          c.Hash.String(),
+			Author:      c.Author.Name,
+			AuthorEmail: c.Author.Email,
+			Message:     strings.TrimSpace(c.Message),
+			Time:        c.Author.When,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve history in", gitContext.Project, err)
+	}
+	if len(commits) == 0 {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	return commits, nil
+}
+
+// GetFileDiff returns the changes of the given file between the two revisions as a patch in the unified diff format.
+// If toRevision is empty, the changes up to the HEAD of the current branch are returned
+func (g *Git) GetFileDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	if toRevision == "" {
+		toRevision = "HEAD"
+	}
+	fromTree, err := getRevisionTree(r, fromRevision)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve diff in", gitContext.Project, err)
+	}
+	toTree, err := getRevisionTree(r, toRevision)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve diff in", gitContext.Project, err)
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve diff in", gitContext.Project, err)
+	}
+	fileChanges := object.Changes{}
+	for _, change := range changes {
+		if change.From.Name == file || change.To.Name == file {
+			fileChanges = append(fileChanges, change)
+		}
+	}
+	if len(fileChanges) == 0 {
+		return "", nil
+	}
+	patch, err := fileChanges.Patch()
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve diff in", gitContext.Project, err)
+	}
+	return patch.String(), nil
+}
+
+func getRevisionTree(r *git.Repository, revision string) (*object.Tree, error) {
+	h, err := r.ResolveRevision(plumbing.Revision(revision))
+	if err != nil || h == nil {
+		logger.Debugf("Could not resolve revision %s: %v", revision, err)
+		return nil, kerrors.ErrResolveRevision
+	}
+	commit, err := r.CommitObject(*h)
+	if err != nil {
+		logger.Debugf("Could not retrieve commit of revision %s: %v", revision, err)
+		return nil, kerrors.ErrResolveRevision
+	}
+	return commit.Tree()
 }
 
 func (g *Git) GetDefaultBranch(gitContext common_models.GitContext) (string, error) {
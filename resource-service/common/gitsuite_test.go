@@ -1,17 +1,22 @@
 package common
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
 	"github.com/go-git/go-billy/v5/memfs"
 	fixtures "github.com/go-git/go-git-fixtures/v4"
 	"github.com/go-git/go-git/v5"
@@ -418,6 +423,316 @@ func (s *BaseSuite) TestGit_StageAndCommitAll_OverrideUserAndEmail(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_StageAndCommitAll_Author(c *C) {
+	g := NewGit(GogitReal{})
+	gitContext := s.NewGitContext()
+	gitContext.Author = &common_models.CommitAuthor{Name: "Jane Doe", Email: "jane.doe@example.com"}
+
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/file.txt", "anycontent", c, w)
+	c.Assert(err, IsNil)
+
+	id, err := g.StageAndCommitAll(gitContext, "my commit")
+	c.Assert(err, IsNil)
+	s.checkCommit(c, s.Repository, id, gitKeptnUserDefault, gitKeptnEmailDefault)
+
+	commit, err := s.Repository.CommitObject(plumbing.NewHash(id))
+	c.Assert(err, IsNil)
+	c.Assert(commit.Author.Name, Equals, "Jane Doe")
+	c.Assert(commit.Author.Email, Equals, "jane.doe@example.com")
+	c.Assert(commit.Message, Equals, "my commit")
+}
+
+func (s *BaseSuite) TestGit_StageAndCommitAll_MergedRevision(c *C) {
+	g := NewGit(GogitReal{})
+	gitContext := s.NewGitContext()
+
+	head, err := s.Repository.Head()
+	c.Assert(err, IsNil)
+	headCommit, err := s.Repository.CommitObject(head.Hash())
+	c.Assert(err, IsNil)
+	c.Assert(headCommit.NumParents() > 0, Equals, true)
+	gitContext.MergedRevision = headCommit.ParentHashes[0].String()
+
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/file.txt", "anycontent", c, w)
+	c.Assert(err, IsNil)
+
+	id, err := g.StageAndCommitAll(gitContext, "my merge")
+	c.Assert(err, IsNil)
+
+	commit, err := s.Repository.CommitObject(plumbing.NewHash(id))
+	c.Assert(err, IsNil)
+	c.Assert(commit.ParentHashes, DeepEquals, []plumbing.Hash{head.Hash(), headCommit.ParentHashes[0]})
+}
+
+func (s *BaseSuite) TestGit_StageAndCommitAll_SignedCommit(c *C) {
+	tests := []struct {
+		name       string
+		signingKey common_models.SigningKey
+	}{
+		{
+			name:       "gpg key",
+			signingKey: newGPGSigningKey(c),
+		},
+		{
+			name:       "ssh key",
+			signingKey: newSSHSigningKey(c),
+		},
+	}
+	for _, tt := range tests {
+		c.Log("Test " + tt.name)
+		g := NewGit(GogitReal{})
+		g.SetSigningKeyReader(&common_mock.SigningKeyReaderMock{
+			GetSigningKeyFunc: func(project string) (*common_models.SigningKey, error) {
+				return &tt.signingKey, nil
+			},
+		})
+		gitContext := s.NewGitContext()
+
+		w, err := s.Repository.Worktree()
+		c.Assert(err, IsNil)
+		err = write("foo/file.txt", tt.name, c, w)
+		c.Assert(err, IsNil)
+
+		id, err := g.StageAndCommitAll(gitContext, "signed commit")
+		c.Assert(err, IsNil)
+		s.checkCommit(c, s.Repository, id, "", "")
+
+		commit, err := s.Repository.CommitObject(plumbing.NewHash(id))
+		c.Assert(err, IsNil)
+		c.Assert(commit.Message, Equals, "signed commit")
+		if tt.signingKey.Format == common_models.SigningKeyFormatGPG {
+			entity, err := readGPGSigningKey(tt.signingKey)
+			c.Assert(err, IsNil)
+			publicKey := &strings.Builder{}
+			writer, err := armor.Encode(publicKey, openpgp.PublicKeyType, nil)
+			c.Assert(err, IsNil)
+			c.Assert(entity.Serialize(writer), IsNil)
+			c.Assert(writer.Close(), IsNil)
+			_, err = commit.Verify(publicKey.String())
+			c.Assert(err, IsNil)
+		} else {
+			signer, err := readSSHSigningKey(tt.signingKey)
+			c.Assert(err, IsNil)
+			payload := &plumbing.MemoryObject{}
+			c.Assert(commit.EncodeWithoutSignature(payload), IsNil)
+			reader, err := payload.Reader()
+			c.Assert(err, IsNil)
+			message, err := io.ReadAll(reader)
+			c.Assert(err, IsNil)
+			verifySSHSignature(c, signer.PublicKey(), message, commit.PGPSignature)
+		}
+	}
+}
+
+func (s *BaseSuite) TestGit_StageAndCommitAll_InvalidSigningKey(c *C) {
+	g := NewGit(GogitReal{})
+	g.SetSigningKeyReader(&common_mock.SigningKeyReaderMock{
+		GetSigningKeyFunc: func(project string) (*common_models.SigningKey, error) {
+			return &common_models.SigningKey{Format: common_models.SigningKeyFormatSSH, PrivateKey: []byte("invalid")}, nil
+		},
+	})
+	gitContext := s.NewGitContext()
+
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/file.txt", "anycontent", c, w)
+	c.Assert(err, IsNil)
+
+	_, err = g.StageAndCommitAll(gitContext, "signed commit")
+	c.Assert(errors.Is(err, kerrors.ErrMalformedSigningKey), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_StageAndCommitAll_LFS(c *C) {
+	largeContent := strings.Repeat("large-content", 10)
+	uploaded := map[string]string{}
+	lfsClient := &common_mock.ILFSClientMock{
+		UploadFunc: func(gitContext common_models.GitContext, pointer common_models.LFSPointer, content io.Reader) error {
+			data, err := io.ReadAll(content)
+			uploaded[pointer.OID] = string(data)
+			return err
+		},
+		DownloadFunc: func(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(uploaded[pointer.OID])), nil
+		},
+	}
+	g := NewGit(GogitReal{})
+	g.SetLFS(lfsClient, 100)
+	gitContext := s.NewGitContext()
+
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/large file.bin", largeContent, c, w)
+	c.Assert(err, IsNil)
+	err = write("foo/small.txt", "small-content", c, w)
+	c.Assert(err, IsNil)
+
+	id, err := g.StageAndCommitAll(gitContext, "my commit")
+	c.Assert(err, IsNil)
+
+	c.Assert(lfsClient.UploadCalls(), HasLen, 1)
+	pointer := lfsClient.UploadCalls()[0].Pointer
+	c.Assert(pointer.Size, Equals, int64(len(largeContent)))
+	c.Assert(uploaded[pointer.OID], Equals, largeContent)
+
+	commit, err := s.Repository.CommitObject(plumbing.NewHash(id))
+	c.Assert(err, IsNil)
+	file, err := commit.File("foo/large file.bin")
+	c.Assert(err, IsNil)
+	committedContent, err := file.Contents()
+	c.Assert(err, IsNil)
+	c.Assert(committedContent, Equals, pointer.String())
+	file, err = commit.File(".gitattributes")
+	c.Assert(err, IsNil)
+	attributes, err := file.Contents()
+	c.Assert(err, IsNil)
+	c.Assert(attributes, Equals, "/foo/large[[:space:]]file.bin filter=lfs diff=lfs merge=lfs -text\n")
+
+	// content is resolved from the local LFS storage
+	content, err := g.GetFileRevision(gitContext, id, "foo/large file.bin")
+	c.Assert(err, IsNil)
+	c.Assert(string(content), Equals, largeContent)
+	c.Assert(lfsClient.DownloadCalls(), HasLen, 0)
+
+	content, err = g.GetFileRevision(gitContext, id, "foo/small.txt")
+	c.Assert(err, IsNil)
+	c.Assert(string(content), Equals, "small-content")
+
+	// content is downloaded if it is not available locally
+	err = os.Remove(lfsObjectPath(gitContext.Project, pointer.OID))
+	c.Assert(err, IsNil)
+	fileContent, err := g.OpenFileRevision(gitContext, id, "foo/large file.bin")
+	c.Assert(err, IsNil)
+	defer fileContent.Content.Close()
+	c.Assert(lfsClient.DownloadCalls(), HasLen, 1)
+	// the object is streamed from the local LFS storage instead of being read into memory
+	_, isFile := fileContent.Content.(*os.File)
+	c.Assert(isFile, Equals, true)
+	c.Assert(fileContent.Size, Equals, int64(len(largeContent)))
+	c.Assert(fileContent.Checksum, Equals, "sha256:"+pointer.OID)
+	data, err := io.ReadAll(fileContent.Content)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, largeContent)
+}
+
+func (s *BaseSuite) TestGit_OpenFileRevision(c *C) {
+	g := NewGit(GogitReal{})
+	gitContext := s.NewGitContext()
+
+	largeContent := strings.Repeat("large-content", 100)
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/large.bin", largeContent, c, w)
+	c.Assert(err, IsNil)
+	id, err := g.StageAndCommitAll(gitContext, "my commit")
+	c.Assert(err, IsNil)
+
+	fileContent, err := g.OpenFileRevision(gitContext, id, "foo/large.bin")
+	c.Assert(err, IsNil)
+	defer fileContent.Content.Close()
+	c.Assert(fileContent.Size, Equals, int64(len(largeContent)))
+	checksum := sha256.Sum256([]byte(largeContent))
+	c.Assert(fileContent.Checksum, Equals, FormatChecksum(checksum[:]))
+	data, err := io.ReadAll(fileContent.Content)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, largeContent)
+
+	_, err = g.OpenFileRevision(gitContext, id, "foo/other.bin")
+	c.Assert(err, NotNil)
+}
+
+func (s *BaseSuite) TestGit_StageAndCommitToBranch(c *C) {
+	g := NewGit(GogitReal{})
+	gitContext := s.NewGitContext()
+	r := s.Repository
+
+	head, err := r.Head()
+	c.Assert(err, IsNil)
+	originalID := head.Hash().String()
+
+	w, err := r.Worktree()
+	c.Assert(err, IsNil)
+	err = write("review/file.txt", "reviewed content", c, w)
+	c.Assert(err, IsNil)
+
+	id, baseBranch, err := g.StageAndCommitToBranch(gitContext, "my change", "keptn-review/my-change")
+	c.Assert(err, IsNil)
+	c.Assert(baseBranch, Equals, "master")
+
+	// the current branch is checked out again and does not contain the change
+	head, err = r.Head()
+	c.Assert(err, IsNil)
+	c.Assert(head.Name().Short(), Equals, "master")
+	c.Assert(head.Hash().String(), Equals, originalID)
+	_, err = os.Stat(GetProjectConfigPath("sockshop") + "/review/file.txt")
+	c.Assert(os.IsNotExist(err), Equals, true)
+	_, err = r.Reference(plumbing.NewBranchReferenceName("keptn-review/my-change"), false)
+	c.Assert(errors.Is(err, plumbing.ErrReferenceNotFound), Equals, true)
+
+	// only the new branch has been pushed
+	upstream, err := git.PlainOpen(s.url)
+	c.Assert(err, IsNil)
+	ref, err := upstream.Reference(plumbing.NewBranchReferenceName("keptn-review/my-change"), false)
+	c.Assert(err, IsNil)
+	c.Assert(ref.Hash().String(), Equals, id)
+	ref, err = upstream.Reference(plumbing.NewBranchReferenceName("master"), false)
+	c.Assert(err, IsNil)
+	c.Assert(ref.Hash().String(), Equals, originalID)
+
+	// once the change request is merged, the change is picked up as new revision of the current branch
+	provider := NewLocalChangeRequestProvider()
+	changeRequest, err := provider.CreateChangeRequest(gitContext, common_models.ChangeRequest{
+		Title:        "my change",
+		SourceBranch: "keptn-review/my-change",
+		TargetBranch: baseBranch,
+	})
+	c.Assert(err, IsNil)
+	revision, err := provider.Merge(changeRequest.ID)
+	c.Assert(err, IsNil)
+	c.Assert(revision, Equals, id)
+
+	err = g.Pull(gitContext)
+	c.Assert(err, IsNil)
+	revision, err = g.GetCurrentRevision(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(revision, Equals, id)
+	b, err := g.GetFileRevision(gitContext, revision, "review/file.txt")
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, "reviewed content")
+
+	_, err = provider.Merge(changeRequest.ID)
+	c.Assert(errors.Is(err, kerrors.ErrChangeRequestNotFound), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_StageAndCommitToBranch_NotMergeable(c *C) {
+	g := NewGit(GogitReal{})
+	gitContext := s.NewGitContext()
+
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	err = write("review/file.txt", "reviewed content", c, w)
+	c.Assert(err, IsNil)
+
+	_, baseBranch, err := g.StageAndCommitToBranch(gitContext, "my change", "keptn-review/my-change")
+	c.Assert(err, IsNil)
+
+	provider := NewLocalChangeRequestProvider()
+	changeRequest, err := provider.CreateChangeRequest(gitContext, common_models.ChangeRequest{
+		SourceBranch: "keptn-review/my-change",
+		TargetBranch: baseBranch,
+	})
+	c.Assert(err, IsNil)
+
+	// the target branch has been changed after the change request has been opened
+	s.commitAndPush("other/file.txt", "other content", c)
+
+	_, err = provider.Merge(changeRequest.ID)
+	c.Assert(errors.Is(err, kerrors.ErrChangeRequestNotMergeable), Equals, true)
+}
+
 func (s *BaseSuite) checkCommit(c *C, r *git.Repository, id string, user string, email string) {
 	head, err := r.Head()
 	c.Assert(err, IsNil)
@@ -818,6 +1133,83 @@ func (s *BaseSuite) TestGit_CreateBranch(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_DeleteBranch(c *C) {
+	g := NewGit(GogitReal{})
+	gitContext := s.NewGitContext()
+
+	err := g.CreateBranch(gitContext, "to-be-deleted", "master")
+	c.Assert(err, IsNil)
+	err = g.Push(gitContext)
+	c.Assert(err, IsNil)
+	err = g.CheckoutBranch(gitContext, "master")
+	c.Assert(err, IsNil)
+
+	err = g.DeleteBranch(gitContext, "to-be-deleted")
+	c.Assert(err, IsNil)
+
+	err = g.CheckoutBranch(gitContext, "to-be-deleted")
+	c.Assert(errors.Is(err, kerrors.ErrReferenceNotFound), Equals, true)
+
+	err = g.DeleteBranch(gitContext, "to-be-deleted")
+	c.Assert(errors.Is(err, kerrors.ErrBranchNotFound), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_GetBranches(c *C) {
+	g := NewGit(GogitReal{})
+	gitContext := s.NewGitContext()
+
+	err := g.CreateBranch(gitContext, "dev", "master")
+	c.Assert(err, IsNil)
+	err = g.Push(gitContext)
+	c.Assert(err, IsNil)
+
+	branches, err := g.GetBranches(gitContext)
+	c.Assert(err, IsNil)
+	sort.Strings(branches)
+	c.Assert(branches, DeepEquals, []string{"dev", "master"})
+}
+
+func (s *BaseSuite) TestGit_UpstreamMirror(c *C) {
+	g := NewGit(GogitReal{})
+	g.SetUpstreamMirror(NewUpstreamMirror(time.Hour))
+	gitContext := s.NewGitContext()
+	readOnlyGitContext := s.NewGitContext()
+	readOnlyGitContext.ReadOnly = true
+
+	// the first read contacts the upstream, since the local repository has not been synchronized yet
+	err := g.CheckoutBranch(readOnlyGitContext, "master")
+	c.Assert(err, IsNil)
+
+	// push a change to the upstream using another clone
+	other, err := git.PlainClone(TESTPATH+"/other", false, &git.CloneOptions{URL: s.url})
+	c.Assert(err, IsNil)
+	err = configureGitUser(other)
+	c.Assert(err, IsNil)
+	otherGitContext := s.NewGitContext()
+	otherGitContext.Project = "other"
+	err = os.WriteFile(TESTPATH+"/other/upstream.yaml", []byte("upstream"), 0644)
+	c.Assert(err, IsNil)
+	upstreamRevision, err := g.StageAndCommitAll(otherGitContext, "upstream change")
+	c.Assert(err, IsNil)
+
+	// reads are served from the fresh mirror
+	err = g.CheckoutBranch(readOnlyGitContext, "master")
+	c.Assert(err, IsNil)
+	err = g.Pull(readOnlyGitContext)
+	c.Assert(err, IsNil)
+	c.Assert(FileSystem{}.FileExists(TESTPATH+"/sockshop/upstream.yaml"), Equals, false)
+
+	// fetching the upstream updates the mirror
+	err = g.Fetch(gitContext)
+	c.Assert(err, IsNil)
+	err = g.CheckoutBranch(readOnlyGitContext, "master")
+	c.Assert(err, IsNil)
+	c.Assert(FileSystem{}.FileExists(TESTPATH+"/sockshop/upstream.yaml"), Equals, true)
+	revision, err := g.GetCurrentRevision(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(revision, Equals, upstreamRevision)
+}
+
 func (s *BaseSuite) TestGit_CheckoutBranch(c *C) {
 
 	tests := []struct {
@@ -920,6 +1312,52 @@ func (s *BaseSuite) TestGit_GetFileRevision(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_GetFileHistory(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	first := s.commitAndPush("foo/history.yaml", "first", c)
+	s.commitAndPush("foo/other.yaml", "other", c)
+	second := s.commitAndPush("foo/history.yaml", "second", c)
+
+	commits, err := g.GetFileHistory(gitContext, "foo/history.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(commits, HasLen, 2)
+	c.Assert(commits[0].ID, Equals, second.String())
+	c.Assert(commits[1].ID, Equals, first.String())
+	c.Assert(commits[0].Author, Equals, "Test Create Branch")
+	c.Assert(commits[0].Message, Equals, "added a file")
+
+	_, err = g.GetFileHistory(gitContext, "foo/not-existing.yaml")
+	c.Assert(errors.Is(err, kerrors.ErrResourceNotFound), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_GetFileDiff(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	first := s.commitAndPush("foo/diff.yaml", "first\n", c)
+	second := s.commitAndPush("foo/diff.yaml", "second\n", c)
+	third := s.commitAndPush("foo/other.yaml", "other\n", c)
+
+	diff, err := g.GetFileDiff(gitContext, first.String(), second.String(), "foo/diff.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(diff, "-first"), Equals, true)
+	c.Assert(strings.Contains(diff, "+second"), Equals, true)
+	c.Assert(strings.Contains(diff, "other"), Equals, false)
+
+	diff, err = g.GetFileDiff(gitContext, second.String(), third.String(), "foo/diff.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(diff, Equals, "")
+
+	diff, err = g.GetFileDiff(gitContext, first.String(), "", "foo/diff.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(diff, "+second"), Equals, true)
+
+	_, err = g.GetFileDiff(gitContext, "ciaoWrongId", "", "foo/diff.yaml")
+	c.Assert(errors.Is(err, kerrors.ErrResolveRevision), Equals, true)
+}
+
 func (s *BaseSuite) TestGit_MigrateProject(c *C) {
 	g := NewGit(GogitReal{})
 
@@ -0,0 +1,271 @@
+package common
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+const (
+	lfsPointerMaxSize    = 1024
+	lfsMediaType         = "application/vnd.git-lfs+json"
+	lfsAttributes        = "filter=lfs diff=lfs merge=lfs -text"
+	lfsOperationUpload   = "upload"
+	lfsOperationDownload = "download"
+	lfsActionVerify      = "verify"
+	lfsTimeout           = 10 * time.Minute
+)
+
+// ParseLFSPointer returns the LFS pointer contained in the given file content, or false if the content is not an LFS pointer
+func ParseLFSPointer(content []byte) (*common_models.LFSPointer, bool) {
+	if len(content) > lfsPointerMaxSize || !bytes.HasPrefix(content, []byte("version "+common_models.LFSPointerVersion+"\n")) {
+		return nil, false
+	}
+	pointer := &common_models.LFSPointer{}
+	for _, line := range strings.Split(string(content), "\n") {
+		if oid := strings.TrimPrefix(line, "oid sha256:"); oid != line {
+			pointer.OID = oid
+		} else if size := strings.TrimPrefix(line, "size "); size != line {
+			parsedSize, err := strconv.ParseInt(size, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			pointer.Size = parsedSize
+		}
+	}
+	if _, err := hex.DecodeString(pointer.OID); err != nil || len(pointer.OID) != sha256.Size*2 {
+		return nil, false
+	}
+	return pointer, true
+}
+
+// lfsObjectPath returns the path of the LFS object within the local LFS storage of the project, as used by the git-lfs client
+func lfsObjectPath(project string, oid string) string {
+	return filepath.Join(GetProjectConfigPath(project), ".git", "lfs", "objects", oid[0:2], oid[2:4], oid)
+}
+
+// ILFSClient transfers files to and from the Git LFS storage of the upstream repository of a project
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/lfs_client_mock.go . ILFSClient
+type ILFSClient interface {
+	Upload(gitContext common_models.GitContext, pointer common_models.LFSPointer, content io.Reader) error
+	Download(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error)
+}
+
+// LFSClient implements the basic transfer adapter of the Git LFS batch API. Only repositories using https credentials are supported
+type LFSClient struct{}
+
+func NewLFSClient() *LFSClient {
+	return &LFSClient{}
+}
+
+type lfsBatchRequest struct {
+	Operation string                     `json:"operation"`
+	Transfers []string                   `json:"transfers"`
+	Objects   []common_models.LFSPointer `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchObject struct {
+	common_models.LFSPointer
+	Actions map[string]lfsAction `json:"actions"`
+	Error   *lfsObjectError      `json:"error"`
+}
+
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header"`
+}
+
+type lfsObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (c LFSClient) Upload(gitContext common_models.GitContext, pointer common_models.LFSPointer, content io.Reader) error {
+	object, err := c.batch(gitContext, lfsOperationUpload, pointer)
+	if err != nil {
+		return err
+	}
+	upload, ok := object.Actions[lfsOperationUpload]
+	if !ok {
+		// the object already exists in the LFS storage
+		return nil
+	}
+
+	req, err := newLFSActionRequest(nethttp.MethodPut, upload, content)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = pointer.Size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.do(gitContext, req)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, pointer.OID, err)
+	}
+	resp.Body.Close()
+
+	if verify, ok := object.Actions[lfsActionVerify]; ok {
+		body, err := json.Marshal(pointer)
+		if err != nil {
+			return err
+		}
+		req, err := newLFSActionRequest(nethttp.MethodPost, verify, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", lfsMediaType)
+		resp, err := c.do(gitContext, req)
+		if err != nil {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, pointer.OID, err)
+		}
+		resp.Body.Close()
+	}
+	return nil
+}
+
+func (c LFSClient) Download(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error) {
+	object, err := c.batch(gitContext, lfsOperationDownload, pointer)
+	if err != nil {
+		return nil, err
+	}
+	download, ok := object.Actions[lfsOperationDownload]
+	if !ok {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, pointer.OID, kerrors.ErrResourceNotFound)
+	}
+
+	req, err := newLFSActionRequest(nethttp.MethodGet, download, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(gitContext, req)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, pointer.OID, err)
+	}
+	return resp.Body, nil
+}
+
+// batch requests the actions required for transferring the object from the batch API of the LFS server
+func (c LFSClient) batch(gitContext common_models.GitContext, operation string, pointer common_models.LFSPointer) (*lfsBatchObject, error) {
+	if gitContext.Credentials == nil || gitContext.Credentials.HttpsAuth == nil {
+		return nil, kerrors.ErrLFSRequiresHTTPS
+	}
+	body, err := json.Marshal(lfsBatchRequest{
+		Operation: operation,
+		Transfers: []string{"basic"},
+		Objects:   []common_models.LFSPointer{pointer},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := nethttp.NewRequest(nethttp.MethodPost, getLFSEndpoint(gitContext.Credentials.RemoteURL)+"/objects/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	user := gitContext.Credentials.User
+	if user == "" {
+		user = "keptnuser"
+	}
+	req.SetBasicAuth(user, gitContext.Credentials.HttpsAuth.Token)
+
+	resp, err := c.do(gitContext, req)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, pointer.OID, err)
+	}
+	defer resp.Body.Close()
+
+	batchResponse := &lfsBatchResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(batchResponse); err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, pointer.OID, err)
+	}
+	if len(batchResponse.Objects) != 1 {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, pointer.OID, fmt.Errorf("unexpected number of objects in batch response"))
+	}
+	object := batchResponse.Objects[0]
+	if object.Error != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, pointer.OID, fmt.Errorf("%d: %s", object.Error.Code, object.Error.Message))
+	}
+	return &object, nil
+}
+
+func (c LFSClient) do(gitContext common_models.GitContext, req *nethttp.Request) (*nethttp.Response, error) {
+	resp, err := newUpstreamHTTPClient(gitContext, lfsTimeout).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, req.URL.Redacted())
+	}
+	return resp, nil
+}
+
+func newLFSActionRequest(method string, action lfsAction, body io.Reader) (*nethttp.Request, error) {
+	req, err := nethttp.NewRequest(method, action.Href, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range action.Header {
+		req.Header.Set(key, value)
+	}
+	return req, nil
+}
+
+// getLFSEndpoint returns the URL of the LFS server of a repository, following the conventions of the git-lfs client
+func getLFSEndpoint(remoteURL string) string {
+	endpoint := strings.TrimSuffix(remoteURL, "/")
+	if !strings.HasSuffix(endpoint, ".git") {
+		endpoint += ".git"
+	}
+	return endpoint + "/info/lfs"
+}
+
+// writeLFSObject stores the content in the local LFS storage of the project and returns the pointer referencing it
+func writeLFSObject(project string, content io.Reader) (*common_models.LFSPointer, error) {
+	lfsDir := filepath.Join(GetProjectConfigPath(project), ".git", "lfs")
+	if err := ensureDirectoryExists(lfsDir); err != nil {
+		return nil, err
+	}
+	tmpFile, err := os.CreateTemp(lfsDir, "incomplete-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmpFile, hash), content)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return nil, err
+	}
+
+	pointer := &common_models.LFSPointer{OID: hex.EncodeToString(hash.Sum(nil)), Size: size}
+	objectPath := lfsObjectPath(project, pointer.OID)
+	if err := ensureDirectoryExists(filepath.Dir(objectPath)); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpFile.Name(), objectPath); err != nil {
+		return nil, err
+	}
+	return pointer, nil
+}
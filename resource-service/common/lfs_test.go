@@ -0,0 +1,204 @@
+package common
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// sha256 of "file-content"
+const testLFSObjectID = "2239ce4df9ee8db012834642ec801b55ba2c92b28bdd11f4d73d9c55d39f3b0a"
+
+func TestParseLFSPointer(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantPointer *common_models.LFSPointer
+		wantOK      bool
+	}{
+		{
+			name:        "valid pointer",
+			content:     "version https://git-lfs.github.com/spec/v1\noid sha256:" + testLFSObjectID + "\nsize 12\n",
+			wantPointer: &common_models.LFSPointer{OID: testLFSObjectID, Size: 12},
+			wantOK:      true,
+		},
+		{
+			name:    "regular file",
+			content: "apiVersion: spec.keptn.sh/0.2.2\nkind: Shipyard\n",
+		},
+		{
+			name:    "invalid oid",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:my-oid\nsize 12\n",
+		},
+		{
+			name:    "invalid size",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + testLFSObjectID + "\nsize twelve\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pointer, ok := ParseLFSPointer([]byte(tt.content))
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.wantPointer, pointer)
+		})
+	}
+}
+
+func TestLFSPointer_String(t *testing.T) {
+	pointer := common_models.LFSPointer{OID: testLFSObjectID, Size: 12}
+
+	parsed, ok := ParseLFSPointer([]byte(pointer.String()))
+	require.True(t, ok)
+	require.Equal(t, pointer, *parsed)
+}
+
+func TestGetLFSEndpoint(t *testing.T) {
+	require.Equal(t, "https://github.com/keptn/keptn.git/info/lfs", getLFSEndpoint("https://github.com/keptn/keptn"))
+	require.Equal(t, "https://github.com/keptn/keptn.git/info/lfs", getLFSEndpoint("https://github.com/keptn/keptn.git"))
+	require.Equal(t, "https://github.com/keptn/keptn.git/info/lfs", getLFSEndpoint("https://github.com/keptn/keptn/"))
+}
+
+func TestLFSClient_Upload(t *testing.T) {
+	tests := []struct {
+		name         string
+		objectExists bool
+		wantUploaded string
+	}{
+		{
+			name:         "new object",
+			wantUploaded: "file-content",
+		},
+		{
+			name:         "existing object",
+			objectExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestLFSServer(t, tt.objectExists)
+			defer server.Close()
+
+			err := NewLFSClient().Upload(getTestLFSGitContext(server.URL), common_models.LFSPointer{OID: testLFSObjectID, Size: 12}, strings.NewReader("file-content"))
+			require.Nil(t, err)
+			require.Equal(t, tt.wantUploaded, server.uploaded)
+			require.Equal(t, tt.wantUploaded != "", server.verified)
+		})
+	}
+}
+
+func TestLFSClient_Download(t *testing.T) {
+	server := newTestLFSServer(t, true)
+	defer server.Close()
+
+	content, err := NewLFSClient().Download(getTestLFSGitContext(server.URL), common_models.LFSPointer{OID: testLFSObjectID, Size: 12})
+	require.Nil(t, err)
+	defer content.Close()
+
+	data, err := io.ReadAll(content)
+	require.Nil(t, err)
+	require.Equal(t, "file-content", string(data))
+}
+
+func TestLFSClient_Download_ObjectNotFound(t *testing.T) {
+	server := newTestLFSServer(t, false)
+	defer server.Close()
+
+	content, err := NewLFSClient().Download(getTestLFSGitContext(server.URL), common_models.LFSPointer{OID: testLFSObjectID, Size: 12})
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+	require.Nil(t, content)
+}
+
+func TestLFSClient_RequiresHTTPSCredentials(t *testing.T) {
+	gitContext := common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			RemoteURL: "ssh://git@github.com/keptn/keptn.git",
+			SshAuth:   &apimodels.SshGitAuth{PrivateKey: "my-key"},
+		},
+	}
+
+	err := NewLFSClient().Upload(gitContext, common_models.LFSPointer{OID: testLFSObjectID, Size: 12}, strings.NewReader("file-content"))
+	require.ErrorIs(t, err, kerrors.ErrLFSRequiresHTTPS)
+
+	_, err = NewLFSClient().Download(gitContext, common_models.LFSPointer{OID: testLFSObjectID, Size: 12})
+	require.ErrorIs(t, err, kerrors.ErrLFSRequiresHTTPS)
+}
+
+func TestWriteLFSObject(t *testing.T) {
+	t.Setenv("CONFIG_DIR", t.TempDir())
+
+	pointer, err := writeLFSObject("my-project", strings.NewReader("file-content"))
+	require.Nil(t, err)
+	require.Equal(t, &common_models.LFSPointer{OID: testLFSObjectID, Size: 12}, pointer)
+
+	content, err := os.ReadFile(lfsObjectPath("my-project", testLFSObjectID))
+	require.Nil(t, err)
+	require.Equal(t, "file-content", string(content))
+}
+
+// testLFSServer implements the batch API and the basic transfer adapter of a Git LFS server storing a single object
+type testLFSServer struct {
+	*httptest.Server
+	objectExists bool
+	uploaded     string
+	verified     bool
+}
+
+func newTestLFSServer(t *testing.T, objectExists bool) *testLFSServer {
+	server := &testLFSServer{objectExists: objectExists}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, token, ok := r.BasicAuth()
+		if !ok || user != "my-user" || token != "my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/my-repo.git/info/lfs/objects/batch":
+			batchRequest := &lfsBatchRequest{}
+			require.Nil(t, json.NewDecoder(r.Body).Decode(batchRequest))
+			object := lfsBatchObject{LFSPointer: batchRequest.Objects[0], Actions: map[string]lfsAction{}}
+			if batchRequest.Operation == lfsOperationUpload && !server.objectExists {
+				object.Actions[lfsOperationUpload] = lfsAction{Href: server.URL + "/objects/" + object.OID, Header: map[string]string{"Authorization": r.Header.Get("Authorization")}}
+				object.Actions[lfsActionVerify] = lfsAction{Href: server.URL + "/verify", Header: map[string]string{"Authorization": r.Header.Get("Authorization")}}
+			}
+			if batchRequest.Operation == lfsOperationDownload && server.objectExists {
+				object.Actions[lfsOperationDownload] = lfsAction{Href: server.URL + "/objects/" + object.OID, Header: map[string]string{"Authorization": r.Header.Get("Authorization")}}
+			}
+			w.Header().Set("Content-Type", lfsMediaType)
+			require.Nil(t, json.NewEncoder(w).Encode(lfsBatchResponse{Objects: []lfsBatchObject{object}}))
+		case "/objects/" + testLFSObjectID:
+			if r.Method == http.MethodPut {
+				content, err := io.ReadAll(r.Body)
+				require.Nil(t, err)
+				server.uploaded = string(content)
+				return
+			}
+			_, _ = w.Write([]byte("file-content"))
+		case "/verify":
+			server.verified = true
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func getTestLFSGitContext(serverURL string) common_models.GitContext {
+	return common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			User:      "my-user",
+			RemoteURL: serverURL + "/my-repo",
+			HttpsAuth: &apimodels.HttpsGitAuth{Token: "my-token"},
+		},
+	}
+}
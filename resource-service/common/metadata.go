@@ -15,3 +15,10 @@ type ServiceMetadata struct {
 	ServiceName       string
 	CreationTimestamp string
 }
+
+// ExportMetadata describes the content of an archive containing the exported resources of a project
+type ExportMetadata struct {
+	ProjectName     string   `yaml:"projectName"`
+	ExportTimestamp string   `yaml:"exportTimestamp"`
+	Stages          []string `yaml:"stages"`
+}
@@ -1,11 +1,42 @@
 package common
 
-import "sync"
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/retry"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	logger "github.com/sirupsen/logrus"
+)
+
+// projectLockRetries is the number of attempts to acquire the shared lock of a project before LockProject fails
+const projectLockRetries = 3
+
+// projectLockRetryDelay is the delay between the attempts to acquire the shared lock of a project
+var projectLockRetryDelay = 1 * time.Second
 
 var mutex = &sync.Mutex{}
 
 var projectLocks = map[string]*sync.Mutex{}
 
+// projectLocker synchronizes access to projects across multiple replicas of the resource-service. If not set, projects are only locked within this process
+var projectLocker ProjectLocker
+
+// ProjectLocker provides locks on projects that are shared with other instances of the resource-service
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/project_locker_mock.go . ProjectLocker
+type ProjectLocker interface {
+	Lock(project string) error
+	Unlock(project string) error
+}
+
+// SetProjectLocker sets the ProjectLocker that is used in addition to the in-process locks by LockProject and UnlockProject
+func SetProjectLocker(locker ProjectLocker) {
+	Lock()
+	defer Unlock()
+	projectLocker = locker
+}
+
 // Lock locks the mutex
 func Lock() {
 	mutex.Lock()
@@ -16,21 +47,51 @@ func Unlock() {
 	mutex.Unlock()
 }
 
-// LockProject locks the given project
-func LockProject(project string) {
-	if projectLocks[project] == nil {
-		Lock()
-		projectLocks[project] = &sync.Mutex{}
-		Unlock()
+// LockProject locks the given project. If a ProjectLocker has been set, the project is locked for other replicas as well.
+// If the shared lock can not be acquired, the project is unlocked again and an error is returned, so that the project is never
+// modified without holding the lock. UnlockProject must only be called if LockProject succeeded
+func LockProject(project string) error {
+	getProjectLock(project).Lock()
+	locker := getProjectLocker()
+	if locker == nil {
+		return nil
+	}
+
+	var lockErr error
+	_ = retry.Retry(func() error {
+		lockErr = locker.Lock(project)
+		if lockErr != nil {
+			logger.Warnf("Could not acquire shared lock for project %s: %v", project, lockErr)
+		}
+		return lockErr
+	}, retry.NumberOfRetries(projectLockRetries), retry.DelayBetweenRetries(projectLockRetryDelay))
+	if lockErr != nil {
+		getProjectLock(project).Unlock()
+		return fmt.Errorf("%w %s: %v", kerrors.ErrProjectLockFailed, project, lockErr)
 	}
-	projectLocks[project].Lock()
+	return nil
 }
 
 func UnlockProject(project string) {
+	if locker := getProjectLocker(); locker != nil {
+		if err := locker.Unlock(project); err != nil {
+			logger.Errorf("Could not release shared lock for project %s: %v", project, err)
+		}
+	}
+	getProjectLock(project).Unlock()
+}
+
+func getProjectLock(project string) *sync.Mutex {
+	Lock()
+	defer Unlock()
 	if projectLocks[project] == nil {
-		Lock()
 		projectLocks[project] = &sync.Mutex{}
-		Unlock()
 	}
-	projectLocks[project].Unlock()
+	return projectLocks[project]
+}
+
+func getProjectLocker() ProjectLocker {
+	Lock()
+	defer Unlock()
+	return projectLocker
 }
@@ -1,12 +1,91 @@
 package common
 
 import (
-	"github.com/stretchr/testify/require"
+	"errors"
 	"testing"
+	"time"
+
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLockProject(t *testing.T) {
-	LockProject("my-project")
+	require.Nil(t, LockProject("my-project"))
 	require.NotNil(t, projectLocks["my-project"])
 	UnlockProject("my-project")
 }
+
+func TestLockProject_WithProjectLocker(t *testing.T) {
+	locker := &common_mock.ProjectLockerMock{
+		LockFunc: func(project string) error {
+			return nil
+		},
+		UnlockFunc: func(project string) error {
+			return nil
+		},
+	}
+	SetProjectLocker(locker)
+	defer SetProjectLocker(nil)
+
+	require.Nil(t, LockProject("my-project"))
+	UnlockProject("my-project")
+
+	require.Len(t, locker.LockCalls(), 1)
+	require.Equal(t, "my-project", locker.LockCalls()[0].Project)
+	require.Len(t, locker.UnlockCalls(), 1)
+	require.Equal(t, "my-project", locker.UnlockCalls()[0].Project)
+}
+
+func TestLockProject_ProjectLockerFails(t *testing.T) {
+	projectLockRetryDelay = 0
+	defer func() { projectLockRetryDelay = 1 * time.Second }()
+
+	locker := &common_mock.ProjectLockerMock{
+		LockFunc: func(project string) error {
+			return errors.New("oops")
+		},
+		UnlockFunc: func(project string) error {
+			return nil
+		},
+	}
+	SetProjectLocker(locker)
+	defer SetProjectLocker(nil)
+
+	err := LockProject("my-project")
+	require.ErrorIs(t, err, kerrors.ErrProjectLockFailed)
+	require.Len(t, locker.LockCalls(), projectLockRetries)
+	require.Empty(t, locker.UnlockCalls())
+
+	// the in-process lock must have been released
+	SetProjectLocker(nil)
+	require.Nil(t, LockProject("my-project"))
+	UnlockProject("my-project")
+}
+
+func TestLockProject_ProjectLockerSucceedsAfterRetry(t *testing.T) {
+	projectLockRetryDelay = 0
+	defer func() { projectLockRetryDelay = 1 * time.Second }()
+
+	attempts := 0
+	locker := &common_mock.ProjectLockerMock{
+		LockFunc: func(project string) error {
+			attempts++
+			if attempts < 2 {
+				return errors.New("oops")
+			}
+			return nil
+		},
+		UnlockFunc: func(project string) error {
+			return nil
+		},
+	}
+	SetProjectLocker(locker)
+	defer SetProjectLocker(nil)
+
+	require.Nil(t, LockProject("my-project"))
+	UnlockProject("my-project")
+
+	require.Len(t, locker.LockCalls(), 2)
+	require.Len(t, locker.UnlockCalls(), 1)
+}
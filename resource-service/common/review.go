@@ -0,0 +1,72 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	logger "github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ReviewBranchPrefix is the prefix of the branches containing the changes proposed as change requests
+const ReviewBranchPrefix = "keptn-review/"
+
+// ReviewConfigReader provides the settings of projects whose changes are proposed as change requests
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/review_config_reader_mock.go . ReviewConfigReader
+type ReviewConfigReader interface {
+	// GetReviewConfig returns the review settings of the project, or nil if the changes of the project are pushed directly
+	GetReviewConfig(project string) (*common_models.ReviewConfig, error)
+}
+
+type K8sReviewConfigReader struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewK8sReviewConfigReader(k8sClient kubernetes.Interface) *K8sReviewConfigReader {
+	return &K8sReviewConfigReader{k8sClient: k8sClient}
+}
+
+// GetReviewConfig reads the review settings of the project from the secret git-review-<project>. The secret contains the
+// provider (github, gitlab, gitea or local), and optionally the URL of the API, the token used for the API and a comma separated
+// list of the stages whose changes are reviewed
+func (rr K8sReviewConfigReader) GetReviewConfig(project string) (*common_models.ReviewConfig, error) {
+	secretName := fmt.Sprintf("git-review-%s", project)
+
+	secret, err := rr.k8sClient.CoreV1().Secrets(GetKeptnNamespace()).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		logger.Debug("No review settings found for project ", project)
+		return nil, nil
+	}
+	if err != nil {
+		logger.Debug("Could not retrieve review settings named: ", secretName)
+		return nil, err
+	}
+
+	reviewConfig := &common_models.ReviewConfig{
+		Provider: strings.TrimSpace(string(secret.Data["provider"])),
+		APIURL:   strings.TrimSpace(string(secret.Data["apiURL"])),
+		Token:    strings.TrimSpace(string(secret.Data["token"])),
+		Stages:   []string{},
+	}
+	if !isChangeRequestProvider(reviewConfig.Provider) {
+		return nil, kerrors.ErrInvalidChangeRequestProvider
+	}
+	for _, stage := range strings.Split(string(secret.Data["stages"]), ",") {
+		if stage = strings.TrimSpace(stage); stage != "" {
+			reviewConfig.Stages = append(reviewConfig.Stages, stage)
+		}
+	}
+	return reviewConfig, nil
+}
+
+// NewReviewBranchName returns a unique name for a branch containing changes proposed as change request
+func NewReviewBranchName() string {
+	return ReviewBranchPrefix + time.Now().UTC().Format("20060102-150405.000000000")
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestK8sReviewConfigReader_GetReviewConfig(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	reviewConfigReader := NewK8sReviewConfigReader(fake.NewSimpleClientset(
+		getK8sReviewSecret("my-project", map[string]string{"provider": "github", "stages": "dev, production,"}),
+		getK8sReviewSecret("my-gitlab-project", map[string]string{"provider": "gitlab", "apiURL": "https://gitlab.example.com/api/v4", "token": "my-token"}),
+		getK8sReviewSecret("my-invalid-project", map[string]string{"provider": "bitbucket"}),
+		getK8sReviewSecret("my-local-project", map[string]string{"provider": "local"}),
+	))
+
+	reviewConfig, err := reviewConfigReader.GetReviewConfig("my-project")
+	require.Nil(t, err)
+	require.Equal(t, &common_models.ReviewConfig{
+		Provider: common_models.ChangeRequestProviderGitHub,
+		Stages:   []string{"dev", "production"},
+	}, reviewConfig)
+
+	reviewConfig, err = reviewConfigReader.GetReviewConfig("my-gitlab-project")
+	require.Nil(t, err)
+	require.Equal(t, &common_models.ReviewConfig{
+		Provider: common_models.ChangeRequestProviderGitLab,
+		APIURL:   "https://gitlab.example.com/api/v4",
+		Token:    "my-token",
+		Stages:   []string{},
+	}, reviewConfig)
+
+	reviewConfig, err = reviewConfigReader.GetReviewConfig("my-other-project")
+	require.Nil(t, err)
+	require.Nil(t, reviewConfig)
+
+	reviewConfig, err = reviewConfigReader.GetReviewConfig("my-invalid-project")
+	require.ErrorIs(t, err, errors.ErrInvalidChangeRequestProvider)
+	require.Nil(t, reviewConfig)
+
+	// the local provider is meant for tests only
+	reviewConfig, err = reviewConfigReader.GetReviewConfig("my-local-project")
+	require.ErrorIs(t, err, errors.ErrInvalidChangeRequestProvider)
+	require.Nil(t, reviewConfig)
+}
+
+func TestNewReviewBranchName(t *testing.T) {
+	branch := NewReviewBranchName()
+	require.True(t, strings.HasPrefix(branch, ReviewBranchPrefix))
+}
+
+func getK8sReviewSecret(project string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "git-review-" + project,
+			Namespace: "keptn",
+		},
+		Data: map[string][]byte{},
+		Type: corev1.SecretTypeOpaque,
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return secret
+}
@@ -0,0 +1,166 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	logger "github.com/sirupsen/logrus"
+	ssh2 "golang.org/x/crypto/ssh"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	sshSignatureMagic     = "SSHSIG"
+	sshSignatureVersion   = 1
+	sshSignatureNamespace = "git"
+	sshSignatureHashAlgo  = "sha512"
+)
+
+// SigningKeyReader provides the keys used for signing the commits of a project
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/signing_key_reader_mock.go . SigningKeyReader
+type SigningKeyReader interface {
+	// GetSigningKey returns the signing key of the project, or nil if the commits of the project are not signed
+	GetSigningKey(project string) (*common_models.SigningKey, error)
+}
+
+type K8sSigningKeyReader struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewK8sSigningKeyReader(k8sClient kubernetes.Interface) *K8sSigningKeyReader {
+	return &K8sSigningKeyReader{k8sClient: k8sClient}
+}
+
+// GetSigningKey reads the signing key of the project from the secret git-signing-key-<project>. The secret contains the format
+// of the key (gpg or ssh), the private key and an optional passphrase
+func (kr K8sSigningKeyReader) GetSigningKey(project string) (*common_models.SigningKey, error) {
+	secretName := fmt.Sprintf("git-signing-key-%s", project)
+
+	secret, err := kr.k8sClient.CoreV1().Secrets(GetKeptnNamespace()).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		logger.Debug("No signing key found for project ", project)
+		return nil, nil
+	}
+	if err != nil {
+		logger.Debug("Could not retrieve signing key named: ", secretName)
+		return nil, err
+	}
+
+	signingKey := &common_models.SigningKey{
+		Format:     strings.TrimSpace(string(secret.Data["format"])),
+		PrivateKey: secret.Data["privateKey"],
+		Passphrase: secret.Data["passphrase"],
+	}
+	if signingKey.Format != common_models.SigningKeyFormatGPG && signingKey.Format != common_models.SigningKeyFormatSSH {
+		return nil, kerrors.ErrInvalidSigningKeyFormat
+	}
+	if len(signingKey.PrivateKey) == 0 {
+		return nil, kerrors.ErrMalformedSigningKey
+	}
+	return signingKey, nil
+}
+
+// readGPGSigningKey decodes an armored OpenPGP private key and decrypts it using the passphrase of the signing key
+func readGPGSigningKey(signingKey common_models.SigningKey) (*openpgp.Entity, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(signingKey.PrivateKey))
+	if err != nil || len(entities) == 0 || entities[0].PrivateKey == nil {
+		return nil, kerrors.ErrMalformedSigningKey
+	}
+	entity := entities[0]
+	if entity.PrivateKey.Encrypted {
+		if err := entity.PrivateKey.Decrypt(signingKey.Passphrase); err != nil {
+			return nil, fmt.Errorf("%w: %v", kerrors.ErrMalformedSigningKey, err)
+		}
+	}
+	for _, subkey := range entity.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			if err := subkey.PrivateKey.Decrypt(signingKey.Passphrase); err != nil {
+				return nil, fmt.Errorf("%w: %v", kerrors.ErrMalformedSigningKey, err)
+			}
+		}
+	}
+	return entity, nil
+}
+
+// readSSHSigningKey decodes a PEM encoded SSH private key, using the passphrase of the signing key if it is set
+func readSSHSigningKey(signingKey common_models.SigningKey) (ssh2.Signer, error) {
+	var signer ssh2.Signer
+	var err error
+	if len(signingKey.Passphrase) > 0 {
+		signer, err = ssh2.ParsePrivateKeyWithPassphrase(signingKey.PrivateKey, signingKey.Passphrase)
+	} else {
+		signer, err = ssh2.ParsePrivateKey(signingKey.PrivateKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", kerrors.ErrMalformedSigningKey, err)
+	}
+	return signer, nil
+}
+
+// signSSH creates an armored SSH signature of the message in the format used by git, see
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+func signSSH(signer ssh2.Signer, message []byte) (string, error) {
+	hash := sha512.Sum512(message)
+	signedData := ssh2.Marshal(struct {
+		Magic     [6]byte
+		Namespace string
+		Reserved  string
+		HashAlgo  string
+		Hash      string
+	}{
+		Namespace: sshSignatureNamespace,
+		HashAlgo:  sshSignatureHashAlgo,
+		Hash:      string(hash[:]),
+	})
+	copy(signedData, sshSignatureMagic)
+
+	var signature *ssh2.Signature
+	var err error
+	if algorithmSigner, ok := signer.(ssh2.AlgorithmSigner); ok && signer.PublicKey().Type() == ssh2.KeyAlgoRSA {
+		// SHA-1 based RSA signatures are not accepted for SSH signatures
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, signedData, ssh2.KeyAlgoRSASHA512)
+	} else {
+		signature, err = signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	blob := ssh2.Marshal(struct {
+		Magic     [6]byte
+		Version   uint32
+		PublicKey string
+		Namespace string
+		Reserved  string
+		HashAlgo  string
+		Signature string
+	}{
+		Version:   sshSignatureVersion,
+		PublicKey: string(signer.PublicKey().Marshal()),
+		Namespace: sshSignatureNamespace,
+		HashAlgo:  sshSignatureHashAlgo,
+		Signature: string(ssh2.Marshal(signature)),
+	})
+	copy(blob, sshSignatureMagic)
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	armored := strings.Builder{}
+	armored.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		armored.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+	armored.WriteString(encoded + "\n")
+	armored.WriteString("-----END SSH SIGNATURE-----\n")
+	return armored.String(), nil
+}
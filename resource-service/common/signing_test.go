@@ -0,0 +1,174 @@
+package common
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+	ssh2 "golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestK8sSigningKeyReader_GetSigningKey(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	signingKeyReader := NewK8sSigningKeyReader(fake.NewSimpleClientset(
+		getK8sSigningKeySecret("my-project", "ssh", "my-key"),
+		getK8sSigningKeySecret("my-invalid-project", "x509", "my-key"),
+		getK8sSigningKeySecret("my-empty-project", "gpg", ""),
+	))
+
+	signingKey, err := signingKeyReader.GetSigningKey("my-project")
+	require.Nil(t, err)
+	require.Equal(t, &common_models.SigningKey{
+		Format:     common_models.SigningKeyFormatSSH,
+		PrivateKey: []byte("my-key"),
+		Passphrase: []byte("my-passphrase"),
+	}, signingKey)
+
+	signingKey, err = signingKeyReader.GetSigningKey("my-other-project")
+	require.Nil(t, err)
+	require.Nil(t, signingKey)
+
+	signingKey, err = signingKeyReader.GetSigningKey("my-invalid-project")
+	require.ErrorIs(t, err, errors.ErrInvalidSigningKeyFormat)
+	require.Nil(t, signingKey)
+
+	signingKey, err = signingKeyReader.GetSigningKey("my-empty-project")
+	require.ErrorIs(t, err, errors.ErrMalformedSigningKey)
+	require.Nil(t, signingKey)
+}
+
+func TestReadGPGSigningKey(t *testing.T) {
+	signingKey := newGPGSigningKey(t)
+
+	entity, err := readGPGSigningKey(signingKey)
+	require.Nil(t, err)
+	require.NotNil(t, entity.PrivateKey)
+
+	_, err = readGPGSigningKey(common_models.SigningKey{Format: common_models.SigningKeyFormatGPG, PrivateKey: []byte("invalid")})
+	require.ErrorIs(t, err, errors.ErrMalformedSigningKey)
+}
+
+func TestSignSSH(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+	ed25519Signer, err := ssh2.NewSignerFromKey(ed25519Key)
+	require.Nil(t, err)
+
+	rsaSigningKey := newSSHSigningKey(t)
+	rsaSigner, err := readSSHSigningKey(rsaSigningKey)
+	require.Nil(t, err)
+
+	tests := []struct {
+		name   string
+		signer ssh2.Signer
+	}{
+		{
+			name:   "ed25519 key",
+			signer: ed25519Signer,
+		},
+		{
+			name:   "rsa key",
+			signer: rsaSigner,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nmy commit\n")
+
+			signature, err := signSSH(tt.signer, message)
+			require.Nil(t, err)
+			require.True(t, strings.HasPrefix(signature, "-----BEGIN SSH SIGNATURE-----\n"))
+			require.True(t, strings.HasSuffix(signature, "-----END SSH SIGNATURE-----\n"))
+
+			verifySSHSignature(t, tt.signer.PublicKey(), message, signature)
+		})
+	}
+}
+
+func TestReadSSHSigningKey_InvalidKey(t *testing.T) {
+	_, err := readSSHSigningKey(common_models.SigningKey{Format: common_models.SigningKeyFormatSSH, PrivateKey: []byte("invalid")})
+	require.ErrorIs(t, err, errors.ErrMalformedSigningKey)
+}
+
+func getK8sSigningKeySecret(project, format, privateKey string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "git-signing-key-" + project,
+			Namespace: "keptn",
+		},
+		Data: map[string][]byte{
+			"format":     []byte(format),
+			"privateKey": []byte(privateKey),
+			"passphrase": []byte("my-passphrase"),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+}
+
+func newGPGSigningKey(t require.TestingT) common_models.SigningKey {
+	entity, err := openpgp.NewEntity("keptn", "", "keptn@keptn.sh", nil)
+	require.Nil(t, err)
+	buf := &bytes.Buffer{}
+	writer, err := armor.Encode(buf, openpgp.PrivateKeyType, nil)
+	require.Nil(t, err)
+	require.Nil(t, entity.SerializePrivate(writer, nil))
+	require.Nil(t, writer.Close())
+	return common_models.SigningKey{Format: common_models.SigningKeyFormatGPG, PrivateKey: buf.Bytes()}
+}
+
+func newSSHSigningKey(t require.TestingT) common_models.SigningKey {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	return common_models.SigningKey{Format: common_models.SigningKeyFormatSSH, PrivateKey: privateKey}
+}
+
+// verifySSHSignature checks an armored SSH signature of the message created with the git namespace
+func verifySSHSignature(t require.TestingT, publicKey ssh2.PublicKey, message []byte, armored string) {
+	encoded := strings.TrimPrefix(strings.TrimSpace(armored), "-----BEGIN SSH SIGNATURE-----")
+	encoded = strings.TrimSuffix(encoded, "-----END SSH SIGNATURE-----")
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+	require.Nil(t, err)
+	require.Equal(t, sshSignatureMagic, string(blob[:6]))
+
+	sshSignature := struct {
+		Version   uint32
+		PublicKey string
+		Namespace string
+		Reserved  string
+		HashAlgo  string
+		Signature string
+	}{}
+	require.Nil(t, ssh2.Unmarshal(blob[6:], &sshSignature))
+	require.Equal(t, uint32(sshSignatureVersion), sshSignature.Version)
+	require.Equal(t, string(publicKey.Marshal()), sshSignature.PublicKey)
+	require.Equal(t, sshSignatureNamespace, sshSignature.Namespace)
+	require.Equal(t, sshSignatureHashAlgo, sshSignature.HashAlgo)
+
+	signature := &ssh2.Signature{}
+	require.Nil(t, ssh2.Unmarshal([]byte(sshSignature.Signature), signature))
+
+	hash := sha512.Sum512(message)
+	signedData := ssh2.Marshal(struct {
+		Namespace string
+		Reserved  string
+		HashAlgo  string
+		Hash      string
+	}{sshSignatureNamespace, "", sshSignatureHashAlgo, string(hash[:])})
+	require.Nil(t, publicKey.Verify(append([]byte(sshSignatureMagic), signedData...), signature))
+}
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+// TemplateData is the data available to resources which are rendered as Go templates
+type TemplateData struct {
+	Project string
+	Stage   string
+	Service string
+	// Values are the values defined in the values.yaml file of the stage
+	Values map[string]interface{}
+}
+
+// RenderTemplate parses the content as Go template and applies the data to it. Referencing a value that does not exist is an error
+func RenderTemplate(name string, content []byte, data TemplateData) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", kerrors.ErrResourceRenderFailed, err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("%w: %v", kerrors.ErrResourceRenderFailed, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	data := TemplateData{
+		Project: "my-project",
+		Stage:   "my-stage",
+		Service: "my-service",
+		Values: map[string]interface{}{
+			"replicas": 3,
+			"endpoints": map[string]interface{}{
+				"api": "https://api.my-stage.example.com",
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "values and context",
+			content: "url: {{ .Values.endpoints.api }}/{{ .Service }}\nreplicas: {{ .Values.replicas }}\nlabel: {{ .Project }}-{{ .Stage }}\n",
+			want:    "url: https://api.my-stage.example.com/my-service\nreplicas: 3\nlabel: my-project-my-stage\n",
+		},
+		{
+			name:    "no template",
+			content: "spec_version: '1.0'\n",
+			want:    "spec_version: '1.0'\n",
+		},
+		{
+			name:    "missing value",
+			content: "url: {{ .Values.endpoints.web }}\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			content: "url: {{ .Values.endpoints.api \n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTemplate("my-file.yaml", []byte(tt.content), data)
+			if tt.wantErr {
+				require.ErrorIs(t, err, errors.ErrResourceRenderFailed)
+				require.Contains(t, err.Error(), "my-file.yaml")
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+}
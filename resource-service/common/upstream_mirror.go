@@ -0,0 +1,57 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// UpstreamMirror keeps track of the time the local repository of each project has last been synchronized with its upstream
+// repository. As long as the local repository is fresh, read-only operations are served without contacting the upstream
+type UpstreamMirror struct {
+	maxStaleness time.Duration
+	now          func() time.Time
+
+	mu       sync.Mutex
+	lastSync map[string]time.Time
+}
+
+// NewUpstreamMirror creates an UpstreamMirror considering local repositories fresh for the given duration after their last synchronization
+func NewUpstreamMirror(maxStaleness time.Duration) *UpstreamMirror {
+	return &UpstreamMirror{
+		maxStaleness: maxStaleness,
+		now:          time.Now,
+		lastSync:     map[string]time.Time{},
+	}
+}
+
+// IsFresh returns true if the local repository of the project has been synchronized within the freshness bound.
+// A nil UpstreamMirror never considers a repository fresh, i.e., the upstream is always contacted
+func (m *UpstreamMirror) IsFresh(project string) bool {
+	if m == nil {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lastSync, ok := m.lastSync[project]
+	return ok && m.now().Sub(lastSync) < m.maxStaleness
+}
+
+// MarkSynced records that the local repository of the project has just been synchronized with the upstream
+func (m *UpstreamMirror) MarkSynced(project string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastSync[project] = m.now()
+}
+
+// Invalidate marks the local repository of the project as outdated, e.g., because the upstream has been changed
+func (m *UpstreamMirror) Invalidate(project string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.lastSync, project)
+}
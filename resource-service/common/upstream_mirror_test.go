@@ -0,0 +1,38 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamMirror_IsFresh(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	mirror := NewUpstreamMirror(5 * time.Minute)
+	mirror.now = func() time.Time { return now }
+
+	require.False(t, mirror.IsFresh("my-project"))
+
+	mirror.MarkSynced("my-project")
+	require.True(t, mirror.IsFresh("my-project"))
+	require.False(t, mirror.IsFresh("other-project"))
+
+	now = now.Add(4 * time.Minute)
+	require.True(t, mirror.IsFresh("my-project"))
+
+	now = now.Add(time.Minute)
+	require.False(t, mirror.IsFresh("my-project"))
+
+	mirror.MarkSynced("my-project")
+	mirror.Invalidate("my-project")
+	require.False(t, mirror.IsFresh("my-project"))
+}
+
+func TestUpstreamMirror_Nil(t *testing.T) {
+	var mirror *UpstreamMirror
+
+	mirror.MarkSynced("my-project")
+	require.False(t, mirror.IsFresh("my-project"))
+	mirror.Invalidate("my-project")
+}
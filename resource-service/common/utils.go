@@ -1,8 +1,14 @@
 package common
 
 import (
+	"crypto/tls"
 	"fmt"
+	nethttp "net/http"
+	"net/url"
 	"os"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common_models"
 )
 
 const StageDirectoryName = ".keptn-stages"
@@ -30,3 +36,24 @@ func ensureDirectoryExists(path string) error {
 	}
 	return nil
 }
+
+// newUpstreamHTTPClient creates an HTTP client for the server hosting the upstream repository, using the TLS and proxy settings of
+// the https credentials if they are set
+func newUpstreamHTTPClient(gitContext common_models.GitContext, timeout time.Duration) *nethttp.Client {
+	transport := nethttp.DefaultTransport.(*nethttp.Transport).Clone()
+	if gitContext.Credentials != nil && gitContext.Credentials.HttpsAuth != nil {
+		httpsAuth := gitContext.Credentials.HttpsAuth
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: httpsAuth.InsecureSkipTLS}
+		if httpsAuth.Proxy != nil {
+			transport.Proxy = nethttp.ProxyURL(&url.URL{
+				Scheme: httpsAuth.Proxy.Scheme,
+				User:   url.UserPassword(httpsAuth.Proxy.User, httpsAuth.Proxy.Password),
+				Host:   httpsAuth.Proxy.URL,
+			})
+		}
+	}
+	return &nethttp.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
@@ -1,8 +1,11 @@
 package common_models
 
 import (
+	"fmt"
+	"io"
 	"net/url"
 	"strings"
+	"time"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 
@@ -15,6 +18,114 @@ type GitCredentials apimodels.GitAuthCredentials
 type GitContext struct {
 	Project     string
 	Credentials *GitCredentials
+	// ReadOnly indicates that the repository is not modified. Read-only operations are served from the local repository
+	// without contacting the upstream, as long as the upstream mirror is enabled and the local repository is fresh
+	ReadOnly bool
+	// Author is the author of the commits created in the context. If not set, the keptn user is used as author
+	Author *CommitAuthor
+	// Review contains the settings for proposing the changes of the context as a change request. If not set, the changes are
+	// pushed to the upstream directly
+	Review *ReviewConfig
+	// MergedRevision is recorded as second parent of the commits created in the context, i.e., the commits merge the revision
+	// into the current branch. If not set, the commits only have the HEAD of the current branch as parent
+	MergedRevision string
+}
+
+// CommitAuthor contains the identity of the author of a commit
+type CommitAuthor struct {
+	Name  string
+	Email string
+}
+
+const (
+	// SigningKeyFormatGPG denotes an armored OpenPGP private key
+	SigningKeyFormatGPG = "gpg"
+	// SigningKeyFormatSSH denotes a PEM encoded SSH private key
+	SigningKeyFormatSSH = "ssh"
+)
+
+// SigningKey contains the private key used for signing the commits of a project
+type SigningKey struct {
+	Format     string
+	PrivateKey []byte
+	Passphrase []byte
+}
+
+const (
+	// ChangeRequestProviderGitHub opens pull requests using the REST API of GitHub
+	ChangeRequestProviderGitHub = "github"
+	// ChangeRequestProviderGitLab opens merge requests using the REST API of GitLab
+	ChangeRequestProviderGitLab = "gitlab"
+	// ChangeRequestProviderGitea opens pull requests using the REST API of Gitea
+	ChangeRequestProviderGitea = "gitea"
+)
+
+// ReviewConfig contains the settings of a project whose changes are proposed as change requests on the upstream repository
+// instead of being pushed to the branch of the stage
+type ReviewConfig struct {
+	// Provider is the name of the provider hosting the upstream repository, e.g. github
+	Provider string
+	// APIURL is the base URL of the REST API of the provider. If not set, the API URL is derived from the remote URL
+	APIURL string
+	// Token is used for authenticating against the REST API. If not set, the token of the git credentials is used
+	Token string
+	// Stages are the stages whose changes are reviewed. If empty, the changes of all stages are reviewed
+	Stages []string
+}
+
+// IsReviewed returns true if the changes of the given stage are proposed as change requests. Changes without a stage are
+// reviewed if the changes of all stages are reviewed
+func (rc ReviewConfig) IsReviewed(stage string) bool {
+	if len(rc.Stages) == 0 {
+		return true
+	}
+	for _, reviewedStage := range rc.Stages {
+		if reviewedStage == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeRequest is a request for merging the changes of a source branch into a target branch of the upstream repository,
+// e.g. a pull request on GitHub or a merge request on GitLab
+type ChangeRequest struct {
+	ID           string
+	URL          string
+	Title        string
+	Description  string
+	SourceBranch string
+	TargetBranch string
+}
+
+// FileContent contains the content of a file together with its size and checksum
+type FileContent struct {
+	Content  io.ReadCloser
+	Size     int64
+	Checksum string
+}
+
+// LFSPointerVersion is the version of the Git LFS pointer file format
+const LFSPointerVersion = "https://git-lfs.github.com/spec/v1"
+
+// LFSPointer references a file stored in the Git LFS storage of a repository. The OID is the SHA-256 hash of the file content
+type LFSPointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// String returns the content of the pointer file committed instead of the file
+func (p LFSPointer) String() string {
+	return fmt.Sprintf("version %s\noid sha256:%s\nsize %d\n", LFSPointerVersion, p.OID, p.Size)
+}
+
+// Commit contains the information about a commit of the git repository of a project
+type Commit struct {
+	ID          string
+	Author      string
+	AuthorEmail string
+	Message     string
+	Time        time.Time
 }
 
 func (g GitCredentials) Validate() error {
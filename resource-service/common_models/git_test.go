@@ -166,3 +166,46 @@ func TestGitCredentials_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestReviewConfig_IsReviewed(t *testing.T) {
+	tests := []struct {
+		name         string
+		reviewConfig ReviewConfig
+		stage        string
+		want         bool
+	}{
+		{
+			name:         "all stages",
+			reviewConfig: ReviewConfig{Provider: ChangeRequestProviderGitHub},
+			stage:        "dev",
+			want:         true,
+		},
+		{
+			name:         "project without stage",
+			reviewConfig: ReviewConfig{Provider: ChangeRequestProviderGitHub},
+			want:         true,
+		},
+		{
+			name:         "selected stage",
+			reviewConfig: ReviewConfig{Provider: ChangeRequestProviderGitHub, Stages: []string{"hardening", "production"}},
+			stage:        "production",
+			want:         true,
+		},
+		{
+			name:         "other stage",
+			reviewConfig: ReviewConfig{Provider: ChangeRequestProviderGitHub, Stages: []string{"production"}},
+			stage:        "dev",
+		},
+		{
+			name:         "project without stage and selected stages",
+			reviewConfig: ReviewConfig{Provider: ChangeRequestProviderGitHub, Stages: []string{"production"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reviewConfig.IsReviewed(tt.stage); got != tt.want {
+				t.Errorf("IsReviewed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,33 @@
 package config
 
+import "time"
+
 var Global EnvConfig
 
+const (
+	// ProjectLockingLocal locks projects within the process only, which is sufficient for a single replica
+	ProjectLockingLocal = "local"
+	// ProjectLockingFile additionally locks projects using lock files on the configuration volume, which must then be shared by all replicas
+	ProjectLockingFile = "file"
+)
+
+const (
+	// UpstreamSyncPull pulls the upstream repository on every request
+	UpstreamSyncPull = "pull"
+	// UpstreamSyncMirror serves read requests from the local repository, which is kept up to date by a background fetch loop
+	// and push webhooks of the git provider. Write requests are still pushed to the upstream repository synchronously
+	UpstreamSyncMirror = "mirror"
+)
+
 type EnvConfig struct {
-	LogLevel                string `envconfig:"LOG_LEVEL" default:"info"`
-	DirectoryStageStructure bool   `envconfig:"DIRECTORY_STAGE_STRUCTURE" default:"false"`
+	LogLevel                string        `envconfig:"LOG_LEVEL" default:"info"`
+	DirectoryStageStructure bool          `envconfig:"DIRECTORY_STAGE_STRUCTURE" default:"false"`
+	ProjectLocking          string        `envconfig:"PROJECT_LOCKING" default:"local"`
+	UpstreamSync            string        `envconfig:"UPSTREAM_SYNC" default:"pull"`
+	UpstreamSyncInterval    time.Duration `envconfig:"UPSTREAM_SYNC_INTERVAL" default:"1m"`
+	UpstreamMaxStaleness    time.Duration `envconfig:"UPSTREAM_MAX_STALENESS" default:"5m"`
+	UpstreamWebhookSecret   string        `envconfig:"UPSTREAM_WEBHOOK_SECRET" default:""`
+	// LFSThreshold is the size in bytes above which committed files are stored in Git LFS. If it is 0, no files are stored in
+	// Git LFS, but files already stored in Git LFS are still resolved when they are read
+	LFSThreshold int64 `envconfig:"LFS_THRESHOLD" default:"0"`
 }
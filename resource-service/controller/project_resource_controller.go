@@ -20,4 +20,11 @@ func (controller ProjectResourceController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.GET("/project/:projectName/resource/:resourceURI", controller.ProjectResourceHandler.GetProjectResource)
 	apiGroup.PUT("/project/:projectName/resource/:resourceURI", controller.ProjectResourceHandler.UpdateProjectResource)
 	apiGroup.DELETE("/project/:projectName/resource/:resourceURI", controller.ProjectResourceHandler.DeleteProjectResource)
+	apiGroup.GET("/project/:projectName/resource/:resourceURI/history", controller.ProjectResourceHandler.GetProjectResourceHistory)
+	apiGroup.GET("/project/:projectName/resource/:resourceURI/diff", controller.ProjectResourceHandler.GetProjectResourceDiff)
+	apiGroup.POST("/project/:projectName/resource/:resourceURI/revert", controller.ProjectResourceHandler.RevertProjectResource)
+	apiGroup.GET("/project/:projectName/resource/:resourceURI/content", controller.ProjectResourceHandler.GetProjectResourceContent)
+	apiGroup.GET("/project/:projectName/export", controller.ProjectResourceHandler.ExportProjectResources)
+	apiGroup.POST("/project/:projectName/import", controller.ProjectResourceHandler.ImportProjectResources)
+	apiGroup.POST("/project/:projectName/upload", controller.ProjectResourceHandler.UploadProjectResources)
 }
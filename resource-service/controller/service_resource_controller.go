@@ -20,4 +20,10 @@ func (controller ServiceResourceController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI", controller.ServiceResourceHandler.GetServiceResource)
 	apiGroup.PUT("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI", controller.ServiceResourceHandler.UpdateServiceResource)
 	apiGroup.DELETE("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI", controller.ServiceResourceHandler.DeleteServiceResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/history", controller.ServiceResourceHandler.GetServiceResourceHistory)
+	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/diff", controller.ServiceResourceHandler.GetServiceResourceDiff)
+	apiGroup.POST("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/revert", controller.ServiceResourceHandler.RevertServiceResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/content", controller.ServiceResourceHandler.GetServiceResourceContent)
+	apiGroup.POST("/project/:projectName/stage/:stageName/service/:serviceName/promote", controller.ServiceResourceHandler.PromoteServiceResources)
+	apiGroup.POST("/project/:projectName/stage/:stageName/service/:serviceName/upload", controller.ServiceResourceHandler.UploadServiceResources)
 }
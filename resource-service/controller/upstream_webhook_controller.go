@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/handler"
+)
+
+type UpstreamWebhookController struct {
+	UpstreamWebhookHandler handler.IUpstreamWebhookHandler
+}
+
+func NewUpstreamWebhookController(upstreamWebhookHandler handler.IUpstreamWebhookHandler) Controller {
+	return &UpstreamWebhookController{UpstreamWebhookHandler: upstreamWebhookHandler}
+}
+
+func (controller UpstreamWebhookController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/upstream/webhook", controller.UpstreamWebhookHandler.HandlePushEvent)
+}
@@ -23,6 +23,7 @@ func (e *ResourceServiceError) Error() string {
 
 var ErrProjectNotFound = New("project not found")
 var ErrProjectAlreadyExists = New("project already exists")
+var ErrProjectLockFailed = New("could not lock project")
 
 // Stage specific errors
 
@@ -40,6 +41,17 @@ var ErrResourceNotFound = New("resource not found")
 var ErrResourceAlreadyExists = New("resource already exists")
 var ErrResourceNotBase64Encoded = New("resource content is not base64 encoded")
 var ErrResourceInvalidResourceURI = New("invalid resource uri")
+var ErrRevisionMustNotBeEmpty = New("revision must not be empty")
+var ErrPromotionTargetStageInvalid = New("target stage must be different from the source stage")
+var ErrInvalidArchive = New("invalid archive")
+var ErrInvalidUpload = New("invalid multipart upload")
+var ErrResourceRenderFailed = New("resource could not be rendered")
+var ErrInvalidCommitAuthor = New("commit author must consist of a name and a valid email address")
+
+// Upstream synchronization specific errors
+
+var ErrPushEventRepositoryMissing = New("push event does not contain a repository URL")
+var ErrInvalidWebhookSignature = New("invalid webhook signature")
 
 // Git specific errors
 
@@ -96,6 +108,22 @@ var ErrInvalidRemoteURL = New("remoteURL scheme must be http, https or ssh")
 var ErrProxyInvalidURL = New("proxy URL must contain IP address and port (<ip-address>:<port>)")
 var ErrInvalidCredentials = New("credentials need to have ssh or http auth method")
 
+// Signing key specific errors
+
+var ErrMalformedSigningKey = New("could not decode commit signing key")
+var ErrInvalidSigningKeyFormat = New("signing key format must be gpg or ssh")
+
+// Git LFS specific errors
+
+var ErrLFSRequiresHTTPS = New("git lfs is only supported for upstream repositories using https credentials")
+
+// Change request specific errors
+
+var ErrInvalidChangeRequestProvider = New("change request provider must be github, gitlab, gitea or local")
+var ErrChangeRequestFailed = New("could not create change request")
+var ErrChangeRequestNotFound = New("change request not found")
+var ErrChangeRequestNotMergeable = New("change request can not be merged by fast-forwarding the target branch")
+
 // Error messages
 
 const ErrMsgCouldNotRetrieveCredentials = "could not read credentials for project %s: %w"
@@ -108,3 +136,6 @@ const ErrMsgCouldNotGetRevision = "could not get current revision for project %s
 const ErrMsgCouldNotGetDefBranch = "could not get default branch for project %s: %w"
 const ErrMsgCouldNotCheckout = "could not checkout branch %s: %w"
 const ErrMsgCouldNotCreate = "could not create branch %s for project %s: %w"
+const ErrMsgCouldNotDelete = "could not delete branch %s of project %s: %w"
+const ErrMsgCouldNotTransferLFSObject = "could not transfer lfs object %s: %w"
+const ErrMsgCouldNotCreateChangeRequest = "could not create change request for branch %s of project %s: %w"
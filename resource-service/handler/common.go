@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/internal/commitauthor"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
 	logger "github.com/sirupsen/logrus"
@@ -28,6 +35,11 @@ func OnAPIError(c *gin.Context, err error) {
 		SetFailedDependencyErrorResponse(c, "Could not decode credentials for upstream repository")
 	} else if errors.Is(err, errors2.ErrCredentialsInvalidRemoteURL) || errors.Is(err, errors2.ErrCredentialsTokenMustNotBeEmpty) {
 		SetBadRequestErrorResponse(c, "Upstream repository not found")
+	} else if errors.Is(err, errors2.ErrInvalidArchive) || errors.Is(err, errors2.ErrInvalidUpload) || errors.Is(err, errors2.ErrResourceInvalidResourceURI) ||
+		errors.Is(err, errors2.ErrResourceRenderFailed) {
+		SetBadRequestErrorResponse(c, err.Error())
+	} else if errors.Is(err, errors2.ErrLFSRequiresHTTPS) || errors.Is(err, errors2.ErrInvalidChangeRequestProvider) || errors.Is(err, errors2.ErrChangeRequestFailed) {
+		SetFailedDependencyErrorResponse(c, err.Error())
 	} else if errors.Is(err, errors2.ErrRepositoryNotFound) {
 		SetNotFoundErrorResponse(c, "Upstream repository not found")
 	} else if check, resourceType := resourceNotFound(err); check {
@@ -58,6 +70,8 @@ func resourceNotFound(err error) (bool, string) {
 		return true, "Service"
 	} else if errors.Is(err, errors2.ErrResourceNotFound) {
 		return true, "Resource"
+	} else if errors.Is(err, errors2.ErrResolveRevision) {
+		return true, "Revision"
 	}
 	return false, ""
 }
@@ -83,6 +97,13 @@ func SetInternalServerErrorResponse(c *gin.Context, msg string) {
 	})
 }
 
+func SetUnauthorizedErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusUnauthorized, models.Error{
+		Code:    http.StatusUnauthorized,
+		Message: msg,
+	})
+}
+
 func SetBadRequestErrorResponse(c *gin.Context, msg string) {
 	c.JSON(http.StatusBadRequest, models.Error{
 		Code:    http.StatusBadRequest,
@@ -96,3 +117,24 @@ func SetConflictErrorResponse(c *gin.Context, msg string) {
 		Message: msg,
 	})
 }
+
+// setResourceContentResponse streams the raw content of a resource to the client. The checksum of the content is reported in the
+// Digest header as defined in RFC 3230
+func setResourceContentResponse(c *gin.Context, resourceURI string, content *models.GetResourceContentResponse) {
+	defer content.Content.Close()
+
+	headers := map[string]string{}
+	if checksum, err := hex.DecodeString(strings.TrimPrefix(content.Checksum, "sha256:")); err == nil && len(checksum) > 0 {
+		headers["Digest"] = "sha-256=" + base64.StdEncoding.EncodeToString(checksum)
+	}
+	if unescapedResourceURI, err := url.QueryUnescape(resourceURI); err == nil {
+		headers["Content-Disposition"] = fmt.Sprintf("attachment; filename=%q", path.Base(unescapedResourceURI))
+	}
+	c.DataFromReader(http.StatusOK, content.Size, "application/octet-stream", content.Content, headers)
+}
+
+// setCommitAuthor sets the author of the commit to the authenticated caller given by the author headers of the request
+func setCommitAuthor(c *gin.Context, commitInfo *models.CommitInfo) {
+	commitInfo.AuthorName = c.GetHeader(commitauthor.NameHeader)
+	commitInfo.AuthorEmail = c.GetHeader(commitauthor.EmailHeader)
+}
@@ -20,18 +20,42 @@ import (
 // 			DeleteResourceFunc: func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
 // 				panic("mock out the DeleteResource method")
 // 			},
+// 			ExportResourcesFunc: func(params models.ExportResourcesParams) ([]byte, error) {
+// 				panic("mock out the ExportResources method")
+// 			},
 // 			GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
 // 				panic("mock out the GetResource method")
 // 			},
+// 			GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+// 				panic("mock out the GetResourceContent method")
+// 			},
+// 			GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+// 				panic("mock out the GetResourceDiff method")
+// 			},
+// 			GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+// 				panic("mock out the GetResourceHistory method")
+// 			},
 // 			GetResourcesFunc: func(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
 // 				panic("mock out the GetResources method")
 // 			},
+// 			ImportResourcesFunc: func(params models.ImportResourcesParams) (*models.ImportResourcesResponse, error) {
+// 				panic("mock out the ImportResources method")
+// 			},
+// 			PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.WriteResourceResponse, error) {
+// 				panic("mock out the PromoteResources method")
+// 			},
+// 			RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+// 				panic("mock out the RevertResource method")
+// 			},
 // 			UpdateResourceFunc: func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
 // 				panic("mock out the UpdateResource method")
 // 			},
 // 			UpdateResourcesFunc: func(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
 // 				panic("mock out the UpdateResources method")
 // 			},
+// 			UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+// 				panic("mock out the UploadResources method")
+// 			},
 // 		}
 //
 // 		// use mockedIResourceManager in code that requires handler.IResourceManager
@@ -45,18 +69,42 @@ type IResourceManagerMock struct {
 	// DeleteResourceFunc mocks the DeleteResource method.
 	DeleteResourceFunc func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error)
 
+	// ExportResourcesFunc mocks the ExportResources method.
+	ExportResourcesFunc func(params models.ExportResourcesParams) ([]byte, error)
+
 	// GetResourceFunc mocks the GetResource method.
 	GetResourceFunc func(params models.GetResourceParams) (*models.GetResourceResponse, error)
 
+	// GetResourceContentFunc mocks the GetResourceContent method.
+	GetResourceContentFunc func(params models.GetResourceParams) (*models.GetResourceContentResponse, error)
+
+	// GetResourceDiffFunc mocks the GetResourceDiff method.
+	GetResourceDiffFunc func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error)
+
+	// GetResourceHistoryFunc mocks the GetResourceHistory method.
+	GetResourceHistoryFunc func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error)
+
 	// GetResourcesFunc mocks the GetResources method.
 	GetResourcesFunc func(params models.GetResourcesParams) (*models.GetResourcesResponse, error)
 
+	// ImportResourcesFunc mocks the ImportResources method.
+	ImportResourcesFunc func(params models.ImportResourcesParams) (*models.ImportResourcesResponse, error)
+
+	// PromoteResourcesFunc mocks the PromoteResources method.
+	PromoteResourcesFunc func(params models.PromoteResourcesParams) (*models.WriteResourceResponse, error)
+
+	// RevertResourceFunc mocks the RevertResource method.
+	RevertResourceFunc func(params models.RevertResourceParams) (*models.WriteResourceResponse, error)
+
 	// UpdateResourceFunc mocks the UpdateResource method.
 	UpdateResourceFunc func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error)
 
 	// UpdateResourcesFunc mocks the UpdateResources method.
 	UpdateResourcesFunc func(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error)
 
+	// UploadResourcesFunc mocks the UploadResources method.
+	UploadResourcesFunc func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateResources holds details about calls to the CreateResources method.
@@ -69,16 +117,51 @@ type IResourceManagerMock struct {
 			// Params is the params argument value.
 			Params models.DeleteResourceParams
 		}
+		// ExportResources holds details about calls to the ExportResources method.
+		ExportResources []struct {
+			// Params is the params argument value.
+			Params models.ExportResourcesParams
+		}
 		// GetResource holds details about calls to the GetResource method.
 		GetResource []struct {
 			// Params is the params argument value.
 			Params models.GetResourceParams
 		}
+		// GetResourceContent holds details about calls to the GetResourceContent method.
+		GetResourceContent []struct {
+			// Params is the params argument value.
+			Params models.GetResourceParams
+		}
+		// GetResourceDiff holds details about calls to the GetResourceDiff method.
+		GetResourceDiff []struct {
+			// Params is the params argument value.
+			Params models.GetResourceDiffParams
+		}
+		// GetResourceHistory holds details about calls to the GetResourceHistory method.
+		GetResourceHistory []struct {
+			// Params is the params argument value.
+			Params models.GetResourceHistoryParams
+		}
 		// GetResources holds details about calls to the GetResources method.
 		GetResources []struct {
 			// Params is the params argument value.
 			Params models.GetResourcesParams
 		}
+		// ImportResources holds details about calls to the ImportResources method.
+		ImportResources []struct {
+			// Params is the params argument value.
+			Params models.ImportResourcesParams
+		}
+		// PromoteResources holds details about calls to the PromoteResources method.
+		PromoteResources []struct {
+			// Params is the params argument value.
+			Params models.PromoteResourcesParams
+		}
+		// RevertResource holds details about calls to the RevertResource method.
+		RevertResource []struct {
+			// Params is the params argument value.
+			Params models.RevertResourceParams
+		}
 		// UpdateResource holds details about calls to the UpdateResource method.
 		UpdateResource []struct {
 			// Params is the params argument value.
@@ -89,13 +172,26 @@ type IResourceManagerMock struct {
 			// Params is the params argument value.
 			Params models.UpdateResourcesParams
 		}
+		// UploadResources holds details about calls to the UploadResources method.
+		UploadResources []struct {
+			// Params is the params argument value.
+			Params models.UploadResourcesParams
+		}
 	}
-	lockCreateResources sync.RWMutex
-	lockDeleteResource  sync.RWMutex
-	lockGetResource     sync.RWMutex
-	lockGetResources    sync.RWMutex
-	lockUpdateResource  sync.RWMutex
-	lockUpdateResources sync.RWMutex
+	lockCreateResources    sync.RWMutex
+	lockDeleteResource     sync.RWMutex
+	lockExportResources    sync.RWMutex
+	lockGetResource        sync.RWMutex
+	lockGetResourceContent sync.RWMutex
+	lockGetResourceDiff    sync.RWMutex
+	lockGetResourceHistory sync.RWMutex
+	lockGetResources       sync.RWMutex
+	lockImportResources    sync.RWMutex
+	lockPromoteResources   sync.RWMutex
+	lockRevertResource     sync.RWMutex
+	lockUpdateResource     sync.RWMutex
+	lockUpdateResources    sync.RWMutex
+	lockUploadResources    sync.RWMutex
 }
 
 // CreateResources calls CreateResourcesFunc.
@@ -160,6 +256,37 @@ func (mock *IResourceManagerMock) DeleteResourceCalls() []struct {
 	return calls
 }
 
+// ExportResources calls ExportResourcesFunc.
+func (mock *IResourceManagerMock) ExportResources(params models.ExportResourcesParams) ([]byte, error) {
+	if mock.ExportResourcesFunc == nil {
+		panic("IResourceManagerMock.ExportResourcesFunc: method is nil but IResourceManager.ExportResources was just called")
+	}
+	callInfo := struct {
+		Params models.ExportResourcesParams
+	}{
+		Params: params,
+	}
+	mock.lockExportResources.Lock()
+	mock.calls.ExportResources = append(mock.calls.ExportResources, callInfo)
+	mock.lockExportResources.Unlock()
+	return mock.ExportResourcesFunc(params)
+}
+
+// ExportResourcesCalls gets all the calls that were made to ExportResources.
+// Check the length with:
+//     len(mockedIResourceManager.ExportResourcesCalls())
+func (mock *IResourceManagerMock) ExportResourcesCalls() []struct {
+	Params models.ExportResourcesParams
+} {
+	var calls []struct {
+		Params models.ExportResourcesParams
+	}
+	mock.lockExportResources.RLock()
+	calls = mock.calls.ExportResources
+	mock.lockExportResources.RUnlock()
+	return calls
+}
+
 // GetResource calls GetResourceFunc.
 func (mock *IResourceManagerMock) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
 	if mock.GetResourceFunc == nil {
@@ -191,6 +318,99 @@ func (mock *IResourceManagerMock) GetResourceCalls() []struct {
 	return calls
 }
 
+// GetResourceContent calls GetResourceContentFunc.
+func (mock *IResourceManagerMock) GetResourceContent(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+	if mock.GetResourceContentFunc == nil {
+		panic("IResourceManagerMock.GetResourceContentFunc: method is nil but IResourceManager.GetResourceContent was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceContent.Lock()
+	mock.calls.GetResourceContent = append(mock.calls.GetResourceContent, callInfo)
+	mock.lockGetResourceContent.Unlock()
+	return mock.GetResourceContentFunc(params)
+}
+
+// GetResourceContentCalls gets all the calls that were made to GetResourceContent.
+// Check the length with:
+//     len(mockedIResourceManager.GetResourceContentCalls())
+func (mock *IResourceManagerMock) GetResourceContentCalls() []struct {
+	Params models.GetResourceParams
+} {
+	var calls []struct {
+		Params models.GetResourceParams
+	}
+	mock.lockGetResourceContent.RLock()
+	calls = mock.calls.GetResourceContent
+	mock.lockGetResourceContent.RUnlock()
+	return calls
+}
+
+// GetResourceDiff calls GetResourceDiffFunc.
+func (mock *IResourceManagerMock) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	if mock.GetResourceDiffFunc == nil {
+		panic("IResourceManagerMock.GetResourceDiffFunc: method is nil but IResourceManager.GetResourceDiff was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceDiffParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceDiff.Lock()
+	mock.calls.GetResourceDiff = append(mock.calls.GetResourceDiff, callInfo)
+	mock.lockGetResourceDiff.Unlock()
+	return mock.GetResourceDiffFunc(params)
+}
+
+// GetResourceDiffCalls gets all the calls that were made to GetResourceDiff.
+// Check the length with:
+//     len(mockedIResourceManager.GetResourceDiffCalls())
+func (mock *IResourceManagerMock) GetResourceDiffCalls() []struct {
+	Params models.GetResourceDiffParams
+} {
+	var calls []struct {
+		Params models.GetResourceDiffParams
+	}
+	mock.lockGetResourceDiff.RLock()
+	calls = mock.calls.GetResourceDiff
+	mock.lockGetResourceDiff.RUnlock()
+	return calls
+}
+
+// GetResourceHistory calls GetResourceHistoryFunc.
+func (mock *IResourceManagerMock) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	if mock.GetResourceHistoryFunc == nil {
+		panic("IResourceManagerMock.GetResourceHistoryFunc: method is nil but IResourceManager.GetResourceHistory was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceHistoryParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceHistory.Lock()
+	mock.calls.GetResourceHistory = append(mock.calls.GetResourceHistory, callInfo)
+	mock.lockGetResourceHistory.Unlock()
+	return mock.GetResourceHistoryFunc(params)
+}
+
+// GetResourceHistoryCalls gets all the calls that were made to GetResourceHistory.
+// Check the length with:
+//     len(mockedIResourceManager.GetResourceHistoryCalls())
+func (mock *IResourceManagerMock) GetResourceHistoryCalls() []struct {
+	Params models.GetResourceHistoryParams
+} {
+	var calls []struct {
+		Params models.GetResourceHistoryParams
+	}
+	mock.lockGetResourceHistory.RLock()
+	calls = mock.calls.GetResourceHistory
+	mock.lockGetResourceHistory.RUnlock()
+	return calls
+}
+
 // GetResources calls GetResourcesFunc.
 func (mock *IResourceManagerMock) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
 	if mock.GetResourcesFunc == nil {
@@ -222,6 +442,99 @@ func (mock *IResourceManagerMock) GetResourcesCalls() []struct {
 	return calls
 }
 
+// ImportResources calls ImportResourcesFunc.
+func (mock *IResourceManagerMock) ImportResources(params models.ImportResourcesParams) (*models.ImportResourcesResponse, error) {
+	if mock.ImportResourcesFunc == nil {
+		panic("IResourceManagerMock.ImportResourcesFunc: method is nil but IResourceManager.ImportResources was just called")
+	}
+	callInfo := struct {
+		Params models.ImportResourcesParams
+	}{
+		Params: params,
+	}
+	mock.lockImportResources.Lock()
+	mock.calls.ImportResources = append(mock.calls.ImportResources, callInfo)
+	mock.lockImportResources.Unlock()
+	return mock.ImportResourcesFunc(params)
+}
+
+// ImportResourcesCalls gets all the calls that were made to ImportResources.
+// Check the length with:
+//     len(mockedIResourceManager.ImportResourcesCalls())
+func (mock *IResourceManagerMock) ImportResourcesCalls() []struct {
+	Params models.ImportResourcesParams
+} {
+	var calls []struct {
+		Params models.ImportResourcesParams
+	}
+	mock.lockImportResources.RLock()
+	calls = mock.calls.ImportResources
+	mock.lockImportResources.RUnlock()
+	return calls
+}
+
+// PromoteResources calls PromoteResourcesFunc.
+func (mock *IResourceManagerMock) PromoteResources(params models.PromoteResourcesParams) (*models.WriteResourceResponse, error) {
+	if mock.PromoteResourcesFunc == nil {
+		panic("IResourceManagerMock.PromoteResourcesFunc: method is nil but IResourceManager.PromoteResources was just called")
+	}
+	callInfo := struct {
+		Params models.PromoteResourcesParams
+	}{
+		Params: params,
+	}
+	mock.lockPromoteResources.Lock()
+	mock.calls.PromoteResources = append(mock.calls.PromoteResources, callInfo)
+	mock.lockPromoteResources.Unlock()
+	return mock.PromoteResourcesFunc(params)
+}
+
+// PromoteResourcesCalls gets all the calls that were made to PromoteResources.
+// Check the length with:
+//     len(mockedIResourceManager.PromoteResourcesCalls())
+func (mock *IResourceManagerMock) PromoteResourcesCalls() []struct {
+	Params models.PromoteResourcesParams
+} {
+	var calls []struct {
+		Params models.PromoteResourcesParams
+	}
+	mock.lockPromoteResources.RLock()
+	calls = mock.calls.PromoteResources
+	mock.lockPromoteResources.RUnlock()
+	return calls
+}
+
+// RevertResource calls RevertResourceFunc.
+func (mock *IResourceManagerMock) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	if mock.RevertResourceFunc == nil {
+		panic("IResourceManagerMock.RevertResourceFunc: method is nil but IResourceManager.RevertResource was just called")
+	}
+	callInfo := struct {
+		Params models.RevertResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockRevertResource.Lock()
+	mock.calls.RevertResource = append(mock.calls.RevertResource, callInfo)
+	mock.lockRevertResource.Unlock()
+	return mock.RevertResourceFunc(params)
+}
+
+// RevertResourceCalls gets all the calls that were made to RevertResource.
+// Check the length with:
+//     len(mockedIResourceManager.RevertResourceCalls())
+func (mock *IResourceManagerMock) RevertResourceCalls() []struct {
+	Params models.RevertResourceParams
+} {
+	var calls []struct {
+		Params models.RevertResourceParams
+	}
+	mock.lockRevertResource.RLock()
+	calls = mock.calls.RevertResource
+	mock.lockRevertResource.RUnlock()
+	return calls
+}
+
 // UpdateResource calls UpdateResourceFunc.
 func (mock *IResourceManagerMock) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
 	if mock.UpdateResourceFunc == nil {
@@ -283,3 +596,34 @@ func (mock *IResourceManagerMock) UpdateResourcesCalls() []struct {
 	mock.lockUpdateResources.RUnlock()
 	return calls
 }
+
+// UploadResources calls UploadResourcesFunc.
+func (mock *IResourceManagerMock) UploadResources(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+	if mock.UploadResourcesFunc == nil {
+		panic("IResourceManagerMock.UploadResourcesFunc: method is nil but IResourceManager.UploadResources was just called")
+	}
+	callInfo := struct {
+		Params models.UploadResourcesParams
+	}{
+		Params: params,
+	}
+	mock.lockUploadResources.Lock()
+	mock.calls.UploadResources = append(mock.calls.UploadResources, callInfo)
+	mock.lockUploadResources.Unlock()
+	return mock.UploadResourcesFunc(params)
+}
+
+// UploadResourcesCalls gets all the calls that were made to UploadResources.
+// Check the length with:
+//     len(mockedIResourceManager.UploadResourcesCalls())
+func (mock *IResourceManagerMock) UploadResourcesCalls() []struct {
+	Params models.UploadResourcesParams
+} {
+	var calls []struct {
+		Params models.UploadResourcesParams
+	}
+	mock.lockUploadResources.RLock()
+	calls = mock.calls.UploadResources
+	mock.lockUploadResources.RUnlock()
+	return calls
+}
@@ -86,11 +86,17 @@ func GetPaginatedResources(dir string, pageSize int64, nextPageKey string, write
 	totalCount := len(files)
 	if paginationInfo.NextPageKey < int64(totalCount) {
 		for _, resourceURI := range files[paginationInfo.NextPageKey:paginationInfo.EndIndex] {
+			checksum, size, err := writer.GetFileChecksum(dir + "/" + resourceURI)
+			if err != nil {
+				return nil, err
+			}
 			var resource = models.GetResourceResponse{
 				Resource: models.Resource{
 					ResourceURI: resourceURI,
 				},
 				Metadata: metadata,
+				Size:     size,
+				Checksum: checksum,
 			}
 			result.Resources = append(result.Resources, resource)
 		}
@@ -37,7 +37,9 @@ func NewProjectManager(git common.IGit, credentialReader common.CredentialReader
 }
 
 func (p ProjectManager) CreateProject(project models.CreateProjectParams) error {
-	common.LockProject(project.ProjectName)
+	if err := common.LockProject(project.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(project.ProjectName)
 	projectDirectory := common.GetProjectConfigPath(project.ProjectName)
 
@@ -118,7 +120,9 @@ func (p ProjectManager) isProjectInitialized(project string) bool {
 }
 
 func (p ProjectManager) UpdateProject(project models.UpdateProjectParams) error {
-	common.LockProject(project.ProjectName)
+	if err := common.LockProject(project.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(project.ProjectName)
 
 	credentials, err := p.credentialReader.GetCredentials(project.ProjectName)
@@ -188,7 +192,9 @@ func (p ProjectManager) migrateProject(project models.UpdateProjectParams, gitCo
 }
 
 func (p ProjectManager) DeleteProject(projectName string) error {
-	common.LockProject(projectName)
+	if err := common.LockProject(projectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(projectName)
 
 	if err := p.fileSystem.DeleteFile(common.GetProjectConfigPath(projectName)); err != nil {
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
 )
@@ -15,6 +18,13 @@ type IProjectResourceHandler interface {
 	GetProjectResource(context *gin.Context)
 	UpdateProjectResource(context *gin.Context)
 	DeleteProjectResource(context *gin.Context)
+	GetProjectResourceHistory(context *gin.Context)
+	GetProjectResourceDiff(context *gin.Context)
+	RevertProjectResource(context *gin.Context)
+	ExportProjectResources(context *gin.Context)
+	ImportProjectResources(context *gin.Context)
+	UploadProjectResources(context *gin.Context)
+	GetProjectResourceContent(context *gin.Context)
 }
 
 type ProjectResourceHandler struct {
@@ -55,6 +65,7 @@ func (ph *ProjectResourceHandler) CreateProjectResources(c *gin.Context) {
 	}
 
 	params.CreateResourcesPayload = *createResources
+	setCommitAuthor(c, &params.CommitInfo)
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
@@ -142,6 +153,7 @@ func (ph *ProjectResourceHandler) UpdateProjectResources(c *gin.Context) {
 	}
 
 	params.UpdateResourcesPayload = *updateResources
+	setCommitAuthor(c, &params.CommitInfo)
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
@@ -168,6 +180,7 @@ func (ph *ProjectResourceHandler) UpdateProjectResources(c *gin.Context) {
 // @Param        projectName                                 path    string  true  "The name of the project"
 // @Param        resourceURI                           path  string  true    "The path of the resource file"
 // @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Param        render       query     bool    false  "Render the resource as Go template using the values.yaml file of the stage"
 // @Success      200          {object}  models.GetResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -230,6 +243,7 @@ func (ph *ProjectResourceHandler) UpdateProjectResource(c *gin.Context) {
 	}
 
 	params.UpdateResourcePayload = *updateResource
+	setCommitAuthor(c, &params.CommitInfo)
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
@@ -255,6 +269,7 @@ func (ph *ProjectResourceHandler) UpdateProjectResource(c *gin.Context) {
 // @Produce      json
 // @Param        projectName  path    string  true  "The name of the project"
 // @Param        resourceURI  path  string  true    "The path of the resource file"
+// @Param        commitMessage  query  string  false  "The message of the commit"
 // @Success      200          {string}  models.WriteResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -267,6 +282,15 @@ func (ph *ProjectResourceHandler) DeleteProjectResource(c *gin.Context) {
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
 
+	commitInfo := &models.CommitInfo{}
+	if err := c.ShouldBindQuery(commitInfo); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.CommitInfo = *commitInfo
+	setCommitAuthor(c, &params.CommitInfo)
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -280,3 +304,327 @@ func (ph *ProjectResourceHandler) DeleteProjectResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetProjectResourceHistory godoc
+// @Summary      Get the history of a project resource
+// @Description  Get the commits that changed the resource of the project, starting with the most recent one
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        pageSize     query     int     false  "The number of items to return"
+// @Param        nextPageKey  query     string  false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/history [get]
+func (ph *ProjectResourceHandler) GetProjectResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceHistory := &models.GetResourceHistoryQuery{PageSize: 20}
+	if err := c.ShouldBindQuery(getResourceHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getResourceHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.ProjectResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetProjectResourceDiff godoc
+// @Summary      Get the diff of a project resource
+// @Description  Get the changes of the resource of the project between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        from         query     string  true   "The commit ID of the base revision"
+// @Param        to           query     string  false  "The commit ID of the target revision, defaults to the current revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/diff [get]
+func (ph *ProjectResourceHandler) GetProjectResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getResourceDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getResourceDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ProjectResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertProjectResource godoc
+// @Summary      Reverts a project resource
+// @Description  Restores the content the resource of the project had in the given revision and commits it as a new revision
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        commitID     query     string  true  "The commit ID of the revision to be restored"
+// @Param        commitMessage  query  string  false  "The message of the commit"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/revert [post]
+func (ph *ProjectResourceHandler) RevertProjectResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	revertResource := &models.RevertResourceQuery{}
+	if err := c.ShouldBindQuery(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourceQuery = *revertResource
+	setCommitAuthor(c, &params.CommitInfo)
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportProjectResources godoc
+// @Summary      Exports all resources of a project
+// @Description  Get a tar.gz archive containing the resources of the project and of all of its stages and services
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Produce      application/gzip
+// @Param        projectName  path  string  true  "The name of the project"
+// @Success      200          {file}    file
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/export [get]
+func (ph *ProjectResourceHandler) ExportProjectResources(c *gin.Context) {
+	params := &models.ExportResourcesParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	archive, err := ph.ProjectResourceManager.ExportResources(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.tar.gz", params.ProjectName))
+	c.Data(http.StatusOK, "application/gzip", archive)
+}
+
+// ImportProjectResources godoc
+// @Summary      Imports resources into a project
+// @Description  Writes the resources of a tar.gz archive created by the export of a project. The resources of the project and of each stage are committed separately. The stages contained in the archive must exist, otherwise nothing is imported
+// @Description  The archive must not exceed 64 MiB, 256 MiB of decompressed content and 10000 entries
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       application/gzip
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        archive      body  string  true  "The tar.gz archive"
+// @Param        commitMessage  query  string  false  "The message of the commits"
+// @Success      200          {object}  models.ImportResourcesResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/import [post]
+func (ph *ProjectResourceHandler) ImportProjectResources(c *gin.Context) {
+	archive, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, common.MaxArchiveSize))
+	if err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf("%s: %v", errors.ErrInvalidArchive, err))
+		return
+	}
+	params := &models.ImportResourcesParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		Archive: archive,
+	}
+
+	commitInfo := &models.CommitInfo{}
+	if err := c.ShouldBindQuery(commitInfo); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.CommitInfo = *commitInfo
+	setCommitAuthor(c, &params.CommitInfo)
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.ImportResources(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UploadProjectResources godoc
+// @Summary      Uploads project resources
+// @Description  Creates or updates the resources of the project from a multipart/form-data request. The name of each form field is the URI of the resource,
+// @Description  its file is the raw content of the resource. Files are streamed to the service and do not need to be base64 encoded
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI    formData  file    true   "The content of the resource, named after the URI of the resource"
+// @Param        commitMessage  query     string  false  "The message of the commit"
+// @Success      201          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/upload [post]
+func (ph *ProjectResourceHandler) UploadProjectResources(c *gin.Context) {
+	params := &models.UploadResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+	}
+
+	commitInfo := &models.CommitInfo{}
+	if err := c.ShouldBindQuery(commitInfo); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.CommitInfo = *commitInfo
+	setCommitAuthor(c, &params.CommitInfo)
+
+	parts, err := c.Request.MultipartReader()
+	if err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrInvalidUpload.Error())
+		return
+	}
+
+	params.Parts = parts
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.UploadResources(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetProjectResourceContent godoc
+// @Summary      Get the content of a project resource
+// @Description  Get the raw content of a resource of the project. The content is streamed and not base64 encoded.
+// @Description  The Digest header contains the SHA-256 checksum of the content
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Produce      application/octet-stream
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI  path   string  true   "The path of the resource file"
+// @Param        gitCommitID  query  string  false  "The commit ID to be checked out"
+// @Param        render       query  bool    false  "Render the resource as Go template using the values.yaml file of the stage"
+// @Success      200          {file}    file
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/content [get]
+func (ph *ProjectResourceHandler) GetProjectResourceContent(c *gin.Context) {
+	params := &models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResource := &models.GetResourceQuery{}
+	if err := c.ShouldBindQuery(getResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceQuery = *getResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	content, err := ph.ProjectResourceManager.GetResourceContent(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	setResourceContentResponse(c, params.ResourceURI, content)
+}
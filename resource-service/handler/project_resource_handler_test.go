@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/internal/commitauthor"
+	"github.com/keptn/keptn/resource-service/common"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
 	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
 	"github.com/keptn/keptn/resource-service/models"
 	"github.com/stretchr/testify/require"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -680,6 +686,27 @@ func TestProjectResourceHandler_UpdateProjectResource(t *testing.T) {
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "update resource with commit info",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{UpdateResourceFunc: func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+					return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+				}},
+			},
+			// the author is only taken from the headers set by the api-gateway
+			request: withAuthorHeaders(httptest.NewRequest(http.MethodPut, "/project/my-project/resource/resource.yaml", bytes.NewBuffer([]byte(`{"resourceContent": "c3RyaW5n", "commitMessage": "my-message", "authorName": "Mallory", "authorEmail": "mallory@example.com"}`))), "Jane Doe", "jane.doe@example.com"),
+			wantParams: &models.UpdateResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+				UpdateResourcePayload: models.UpdateResourcePayload{
+					ResourceContent: "c3RyaW5n",
+					CommitInfo:      models.CommitInfo{CommitMessage: "my-message", AuthorName: "Jane Doe", AuthorEmail: "jane.doe@example.com"},
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
 		{
 			name: "resource content not base64 encoded",
 			fields: fields{
@@ -719,6 +746,23 @@ func TestProjectResourceHandler_UpdateProjectResource(t *testing.T) {
 			},
 			wantStatus: http.StatusInternalServerError,
 		},
+		{
+			name: "change request could not be created",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{UpdateResourceFunc: func(project models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+					return nil, fmt.Errorf("%w: unexpected status code 401", errors2.ErrChangeRequestFailed)
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPut, "/project/my-project/resource/resource.yaml", bytes.NewBuffer([]byte(updateResourceTestPayload))),
+			wantParams: &models.UpdateResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI:           "resource.yaml",
+				UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: "c3RyaW5n"},
+			},
+			wantStatus: http.StatusFailedDependency,
+		},
 		{
 			name: "invalid payload",
 			fields: fields{
@@ -779,6 +823,39 @@ func TestProjectResourceHandler_DeleteProjectResource(t *testing.T) {
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "delete resource with commit info",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{DeleteResourceFunc: func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+					return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+				}},
+			},
+			// the author is only taken from the headers set by the api-gateway
+			request: withAuthorHeaders(httptest.NewRequest(http.MethodDelete, "/project/my-project/resource/resource.yaml?commitMessage=Remove+resource&authorName=Mallory&authorEmail=mallory%40example.com", nil), "Jane Doe", "jane.doe@example.com"),
+			wantParams: &models.DeleteResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+				CommitInfo: models.CommitInfo{
+					CommitMessage: "Remove resource",
+					AuthorName:    "Jane Doe",
+					AuthorEmail:   "jane.doe@example.com",
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "invalid commit author",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{DeleteResourceFunc: func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+					return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+				}},
+			},
+			request:    withAuthorHeaders(httptest.NewRequest(http.MethodDelete, "/project/my-project/resource/resource.yaml", nil), "Jane Doe", ""),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name: "project name empty",
 			fields: fields{
@@ -827,3 +904,768 @@ func TestProjectResourceHandler_DeleteProjectResource(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectResourceHandler_GetProjectResourceHistory(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	testHistory := models.GetResourceHistoryResponse{
+		NextPageKey: "0",
+		PageSize:    1,
+		TotalCount:  1,
+		Commits:     []models.ResourceCommit{{CommitID: "commit-id", Author: "keptn", Message: "Updated resource"}},
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceHistoryParams
+		wantResult *models.GetResourceHistoryResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource history",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return &testHistory, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/history?pageSize=1", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 1,
+				},
+			},
+			wantResult: &testHistory,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get history of resource in parent directory - should return error",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/resource/..my-resource.yaml/history", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/history", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 20,
+				}},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/resource/:resourceURI/history", ph.GetProjectResourceHistory)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetResourceHistoryCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceHistoryCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetResourceHistoryCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceHistoryResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_GetProjectResourceDiff(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	testDiff := models.GetResourceDiffResponse{From: "commit-1", To: "commit-2", Diff: "my-diff"}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceDiffParams
+		wantResult *models.GetResourceDiffResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource diff",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return &testDiff, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/diff?from=commit-1&to=commit-2", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "commit-1",
+					To:   "commit-2",
+				},
+			},
+			wantResult: &testDiff,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "from revision not set - should return error",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/diff", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "revision not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return nil, errors2.ErrResolveRevision
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/diff?from=unknown", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "unknown",
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/resource/:resourceURI/diff", ph.GetProjectResourceDiff)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetResourceDiffCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceDiffCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetResourceDiffCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceDiffResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_RevertProjectResource(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	testRevertResponse := models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{Version: "my-revision"}}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.RevertResourceParams
+		wantResult *models.WriteResourceResponse
+		wantStatus int
+	}{
+		{
+			name: "revert resource",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return &testRevertResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/resource/my-resource.yaml/revert?commitID=commit-1", nil),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				RevertResourceQuery: models.RevertResourceQuery{
+					CommitID: "commit-1",
+				},
+			},
+			wantResult: &testRevertResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "revert resource with commit message and author",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return &testRevertResponse, nil
+					},
+				},
+			},
+			request: withAuthorHeaders(httptest.NewRequest(http.MethodPost, "/project/my-project/resource/my-resource.yaml/revert?commitID=commit-1&commitMessage=Undo+change", nil), "Jane Doe", "jane.doe@example.com"),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				RevertResourceQuery: models.RevertResourceQuery{
+					CommitID:   "commit-1",
+					CommitInfo: models.CommitInfo{CommitMessage: "Undo change", AuthorName: "Jane Doe", AuthorEmail: "jane.doe@example.com"},
+				},
+			},
+			wantResult: &testRevertResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "commit ID not set - should return error",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/resource/my-resource.yaml/revert", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return nil, errors.New("oops")
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/resource/my-resource.yaml/revert?commitID=commit-1", nil),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				RevertResourceQuery: models.RevertResourceQuery{
+					CommitID: "commit-1",
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/resource/:resourceURI/revert", ph.RevertProjectResource)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.RevertResourceCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.RevertResourceCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.RevertResourceCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.WriteResourceResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_ExportProjectResources(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.ExportResourcesParams
+		wantBody   []byte
+		wantStatus int
+	}{
+		{
+			name: "export resources",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					ExportResourcesFunc: func(params models.ExportResourcesParams) ([]byte, error) {
+						return []byte("my-archive"), nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/export", nil),
+			wantParams: &models.ExportResourcesParams{
+				Project: models.Project{ProjectName: "my-project"},
+			},
+			wantBody:   []byte("my-archive"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "project not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					ExportResourcesFunc: func(params models.ExportResourcesParams) ([]byte, error) {
+						return nil, errors2.ErrProjectNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/export", nil),
+			wantParams: &models.ExportResourcesParams{
+				Project: models.Project{ProjectName: "my-project"},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					ExportResourcesFunc: func(params models.ExportResourcesParams) ([]byte, error) {
+						return nil, errors.New("oops")
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/export", nil),
+			wantParams: &models.ExportResourcesParams{
+				Project: models.Project{ProjectName: "my-project"},
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/export", ph.ExportProjectResources)
+
+			resp := performRequest(router, tt.request)
+
+			require.Len(t, tt.fields.ProjectResourceManager.ExportResourcesCalls(), 1)
+			require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.ExportResourcesCalls()[0].Params)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantBody != nil {
+				require.Equal(t, tt.wantBody, resp.Body.Bytes())
+				require.Equal(t, "application/gzip", resp.Header().Get("Content-Type"))
+				require.Equal(t, "attachment; filename=my-project.tar.gz", resp.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_ImportProjectResources(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	testImportResponse := models.ImportResourcesResponse{
+		Commits: []models.WriteResourceResponse{{CommitID: "my-revision", Metadata: models.Version{Version: "my-revision"}}},
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.ImportResourcesParams
+		wantResult *models.ImportResourcesResponse
+		wantStatus int
+	}{
+		{
+			name: "import resources",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					ImportResourcesFunc: func(params models.ImportResourcesParams) (*models.ImportResourcesResponse, error) {
+						return &testImportResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/import", bytes.NewBuffer([]byte("my-archive"))),
+			wantParams: &models.ImportResourcesParams{
+				Project: models.Project{ProjectName: "my-project"},
+				Archive: []byte("my-archive"),
+			},
+			wantResult: &testImportResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "import resources with commit message and author",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					ImportResourcesFunc: func(params models.ImportResourcesParams) (*models.ImportResourcesResponse, error) {
+						return &testImportResponse, nil
+					},
+				},
+			},
+			request: withAuthorHeaders(httptest.NewRequest(http.MethodPost, "/project/my-project/import?commitMessage=Restore+backup", bytes.NewBuffer([]byte("my-archive"))), "Jane Doe", "jane.doe@example.com"),
+			wantParams: &models.ImportResourcesParams{
+				Project:    models.Project{ProjectName: "my-project"},
+				Archive:    []byte("my-archive"),
+				CommitInfo: models.CommitInfo{CommitMessage: "Restore backup", AuthorName: "Jane Doe", AuthorEmail: "jane.doe@example.com"},
+			},
+			wantResult: &testImportResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "empty archive - should return error",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/import", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "archive too large - should return error",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/import", bytes.NewBuffer(make([]byte, common.MaxArchiveSize+1))),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid archive",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					ImportResourcesFunc: func(params models.ImportResourcesParams) (*models.ImportResourcesResponse, error) {
+						return nil, errors2.ErrInvalidArchive
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/import", bytes.NewBuffer([]byte("my-archive"))),
+			wantParams: &models.ImportResourcesParams{
+				Project: models.Project{ProjectName: "my-project"},
+				Archive: []byte("my-archive"),
+			},
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "stage not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					ImportResourcesFunc: func(params models.ImportResourcesParams) (*models.ImportResourcesResponse, error) {
+						return nil, errors2.ErrStageNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/import", bytes.NewBuffer([]byte("my-archive"))),
+			wantParams: &models.ImportResourcesParams{
+				Project: models.Project{ProjectName: "my-project"},
+				Archive: []byte("my-archive"),
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/import", ph.ImportProjectResources)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.ImportResourcesCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.ImportResourcesCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.ImportResourcesCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.ImportResourcesResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_UploadProjectResources(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.UploadResourcesParams
+		wantResult *models.WriteResourceResponse
+		wantStatus int
+	}{
+		{
+			name: "upload resources",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+					},
+				},
+			},
+			request: newTestUploadRequest(t, "/project/my-project/upload?commitMessage=my-message", "file1"),
+			wantParams: &models.UploadResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				CommitInfo: models.CommitInfo{CommitMessage: "my-message"},
+			},
+			wantResult: &models.WriteResourceResponse{CommitID: "my-commit-id"},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid resource URI",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors2.ErrResourceInvalidResourceURI
+					},
+				},
+			},
+			request: newTestUploadRequest(t, "/project/my-project/upload", "../file1"),
+			wantParams: &models.UploadResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not a multipart request",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/upload", bytes.NewBufferString(createResourcesTestPayload)),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid commit author",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    withAuthorHeaders(newTestUploadRequest(t, "/project/my-project/upload", "file1"), "keptn", ""),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/upload", ph.UploadProjectResources)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.UploadResourcesCalls(), 1)
+				params := tt.fields.ProjectResourceManager.UploadResourcesCalls()[0].Params
+				require.NotNil(t, params.Parts)
+				params.Parts = nil
+				require.Equal(t, *tt.wantParams, params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.UploadResourcesCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.WriteResourceResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_GetProjectResourceContent(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceParams
+		wantBody   []byte
+		wantStatus int
+	}{
+		{
+			name: "get resource content",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return newTestGetResourceContentResponse(), nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/helm%2Fmy-chart.tgz/content?gitCommitID=my-commit-id", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI:      "helm%2Fmy-chart.tgz",
+				GetResourceQuery: models.GetResourceQuery{GitCommitID: "my-commit-id"},
+			},
+			wantBody:   []byte("file-content"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/file1/content", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "file1",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return nil, errors.New("oops")
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/file1/content", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "file1",
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.UseRawPath = true
+			router.UnescapePathValues = false
+			router.GET("/project/:projectName/resource/:resourceURI/content", ph.GetProjectResourceContent)
+
+			resp := performRequest(router, tt.request)
+
+			require.Len(t, tt.fields.ProjectResourceManager.GetResourceContentCalls(), 1)
+			require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceContentCalls()[0].Params)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantBody != nil {
+				require.Equal(t, tt.wantBody, resp.Body.Bytes())
+				require.Equal(t, "application/octet-stream", resp.Header().Get("Content-Type"))
+				require.Equal(t, "12", resp.Header().Get("Content-Length"))
+				require.Equal(t, testResourceContentDigest, resp.Header().Get("Digest"))
+				require.Equal(t, `attachment; filename="my-chart.tgz"`, resp.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
+
+const testResourceContentDigest = "sha-256=IjnOTfnujbASg0ZC7IAbVboskrKL3RH01z2cVdOfOwo="
+
+func newTestGetResourceContentResponse() *models.GetResourceContentResponse {
+	return &models.GetResourceContentResponse{
+		Content:  io.NopCloser(strings.NewReader("file-content")),
+		Size:     12,
+		Checksum: "sha256:2239ce4df9ee8db012834642ec801b55ba2c92b28bdd11f4d73d9c55d39f3b0a",
+		Metadata: models.Version{Version: "my-commit-id"},
+	}
+}
+
+// newTestUploadRequest creates a multipart upload request containing a file with the given resource URI as form name
+func newTestUploadRequest(t *testing.T, target string, resourceURI string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(resourceURI, "file1")
+	require.Nil(t, err)
+	_, err = part.Write([]byte("file-content"))
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+
+	request := httptest.NewRequest(http.MethodPost, target, body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func withAuthorHeaders(request *http.Request, name string, email string) *http.Request {
+	request.Header.Set(commitauthor.NameHeader, name)
+	request.Header.Set(commitauthor.EmailHeader, email)
+	return request
+}
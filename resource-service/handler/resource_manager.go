@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,8 +20,16 @@ import (
 	"github.com/keptn/keptn/resource-service/common_models"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
+	"gopkg.in/yaml.v3"
 )
 
+const exportMetadataFileName = "metadata.yaml"
+const serviceMetadataFileName = "metadata.yaml"
+const exportProjectDirectory = "project"
+const shipyardFileName = "shipyard.yaml"
+const exportStagesDirectory = "stages"
+const stageValuesFileName = "values.yaml"
+
 //IResourceManager provides an interface for resource CRUD operations
 //go:generate moq -pkg handler_mock -skip-ensure -out ./fake/resource_manager_mock.go . IResourceManager
 type IResourceManager interface {
@@ -24,13 +39,23 @@ type IResourceManager interface {
 	GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error)
 	UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error)
 	DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error)
+	GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error)
+	GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error)
+	RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error)
+	PromoteResources(params models.PromoteResourcesParams) (*models.WriteResourceResponse, error)
+	ExportResources(params models.ExportResourcesParams) ([]byte, error)
+	ImportResources(params models.ImportResourcesParams) (*models.ImportResourcesResponse, error)
+	UploadResources(params models.UploadResourcesParams) (*models.WriteResourceResponse, error)
+	GetResourceContent(params models.GetResourceParams) (*models.GetResourceContentResponse, error)
 }
 
 type ResourceManager struct {
-	git                  common.IGit
-	credentialReader     common.CredentialReader
-	fileSystem           common.IFileSystem
-	configurationContext IConfigurationContext
+	git                   common.IGit
+	credentialReader      common.CredentialReader
+	fileSystem            common.IFileSystem
+	configurationContext  IConfigurationContext
+	reviewConfigReader    common.ReviewConfigReader
+	changeRequestProvider common.ChangeRequestProvider
 }
 
 func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext) *ResourceManager {
@@ -43,8 +68,16 @@ func NewResourceManager(git common.IGit, credentialReader common.CredentialReade
 	return projectResourceManager
 }
 
+// SetReview enables proposing the changes of projects with review settings as change requests, which are opened using the given provider
+func (p *ResourceManager) SetReview(reviewConfigReader common.ReviewConfigReader, changeRequestProvider common.ChangeRequestProvider) {
+	p.reviewConfigReader = reviewConfigReader
+	p.changeRequestProvider = changeRequestProvider
+}
+
 func (p ResourceManager) CreateResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
@@ -52,14 +85,17 @@ func (p ResourceManager) CreateResources(params models.CreateResourcesParams) (*
 		return nil, err
 	}
 
-	return p.writeAndCommitResources(gitContext, params.Resources, configPath)
+	message := applyCommitInfo(gitContext, params.CommitInfo, "Updated resource")
+	return p.writeAndCommitResources(gitContext, params.Resources, configPath, message)
 }
 
 func (p ResourceManager) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
-	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	gitContext, configPath, err := p.establishReadOnlyContext(params.Project, params.Stage, params.Service)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +117,9 @@ func (p ResourceManager) GetResources(params models.GetResourcesParams) (*models
 }
 
 func (p ResourceManager) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
@@ -89,14 +127,17 @@ func (p ResourceManager) UpdateResources(params models.UpdateResourcesParams) (*
 		return nil, err
 	}
 
-	return p.writeAndCommitResources(gitContext, params.Resources, configPath)
+	message := applyCommitInfo(gitContext, params.CommitInfo, "Updated resource")
+	return p.writeAndCommitResources(gitContext, params.Resources, configPath, message)
 }
 
 func (p ResourceManager) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
-	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	gitContext, configPath, err := p.establishReadOnlyContext(params.Project, params.Stage, params.Service)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +151,9 @@ func (p ResourceManager) GetResource(params models.GetResourceParams) (*models.G
 }
 
 func (p ResourceManager) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
@@ -125,11 +168,14 @@ func (p ResourceManager) UpdateResource(params models.UpdateResourceParams) (*mo
 
 	resourcePath := configPath + "/" + unescapedResourceName
 
-	return p.writeAndCommitResource(gitContext, resourcePath, string(params.ResourceContent))
+	message := applyCommitInfo(gitContext, params.CommitInfo, "Updated resource")
+	return p.writeAndCommitResource(gitContext, resourcePath, string(params.ResourceContent), message)
 }
 
 func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
@@ -143,6 +189,7 @@ func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*mo
 	}
 
 	resourcePath := configPath + "/" + unescapedResource
+	message := applyCommitInfo(gitContext, params.CommitInfo, "Deleted resources")
 
 	var resultErr error
 	var resultCommit *models.WriteResourceResponse
@@ -152,7 +199,7 @@ func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*mo
 			resultErr = err
 			return nil
 		}
-		response, err := p.deleteResource(gitContext, resourcePath)
+		response, err := p.deleteResource(gitContext, resourcePath, message)
 		if err != nil {
 			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
 				return err
@@ -167,7 +214,678 @@ func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*mo
 	return resultCommit, resultErr
 }
 
+func (p ResourceManager) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishReadOnlyContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	commits, err := p.git.GetFileHistory(*gitContext, getRelativeResourcePath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.GetResourceHistoryResponse{
+		Commits: []models.ResourceCommit{},
+	}
+	paginationInfo := Paginate(len(commits), params.PageSize, params.NextPageKey)
+	if paginationInfo.NextPageKey < int64(len(commits)) {
+		for _, commit := range commits[paginationInfo.NextPageKey:paginationInfo.EndIndex] {
+			result.Commits = append(result.Commits, models.ResourceCommit{
+				CommitID:    commit.ID,
+				Author:      commit.Author,
+				AuthorEmail: commit.AuthorEmail,
+				Message:     commit.Message,
+				Timestamp:   commit.Time,
+			})
+		}
+	}
+	result.PageSize = float64(len(result.Commits))
+	result.TotalCount = float64(len(commits))
+	result.NextPageKey = paginationInfo.NewNextPageKey
+	return result, nil
+}
+
+func (p ResourceManager) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishReadOnlyContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	toRevision := params.To
+	if toRevision == "" {
+		toRevision, err = p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	diff, err := p.git.GetFileDiff(*gitContext, params.From, toRevision, getRelativeResourcePath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetResourceDiffResponse{
+		From: params.From,
+		To:   toRevision,
+		Diff: diff,
+	}, nil
+}
+
+func (p ResourceManager) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	// the revision might only exist in the upstream
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	fileContent, err := p.git.GetFileRevision(*gitContext, params.CommitID, getRelativeResourcePath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	resourcePath := configPath + "/" + unescapedResourceName
+	message := applyCommitInfo(gitContext, params.CommitInfo, fmt.Sprintf("Reverted resource %s to revision %s", unescapedResourceName, params.CommitID))
+
+	return p.writeAndCommitResource(gitContext, resourcePath, base64.StdEncoding.EncodeToString(fileContent), message)
+}
+
+// PromoteResources copies the resources of a service from the stage of the given context to the target stage and commits them.
+// In directory mode, the resources are copied between the stage directories of the default branch.
+// In branch mode, the resources are read from the branch of the source stage and committed to the branch of the target stage.
+// If all resources of the service are promoted, the commit merges the branch of the source stage, taking the resources of the
+// service from the source stage and everything else from the target stage. If only some resources are promoted, they are
+// cherry-picked, i.e., the commit references the commit of the source stage it has been picked from.
+// If all resources of the service are promoted, resources that only exist in the target stage are removed, so that the service
+// has the same resources in both stages afterwards. The metadata of the service in the target stage is kept
+func (p ResourceManager) PromoteResources(params models.PromoteResourcesParams) (*models.WriteResourceResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, sourcePath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	resources, err := p.readPromotedResources(sourcePath, params.ResourceURIs)
+	if err != nil {
+		return nil, err
+	}
+
+	var sourceRevision string
+	if p.usesStageBranches() {
+		// the branch of the source stage is still checked out
+		sourceRevision, err = p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	targetContext, targetPath, err := p.establishContext(params.Project, &models.Stage{StageName: params.TargetStage}, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	message := applyCommitInfo(targetContext, params.CommitInfo, fmt.Sprintf("Promoted resources of service %s from stage %s to stage %s", params.Service.ServiceName, params.Stage.StageName, params.TargetStage))
+	if sourceRevision != "" {
+		if len(params.ResourceURIs) == 0 {
+			targetContext.MergedRevision = sourceRevision
+		} else {
+			message = fmt.Sprintf("%s\n\n(cherry picked from commit %s)", message, sourceRevision)
+		}
+	}
+	return p.syncAndCommitResources(targetContext, resources, targetPath, message, len(params.ResourceURIs) == 0)
+}
+
+// usesStageBranches returns whether the stages of projects are represented as branches, rather than as directories of the default branch
+func (p ResourceManager) usesStageBranches() bool {
+	switch p.configurationContext.(type) {
+	case *BranchConfigurationContext, BranchConfigurationContext:
+		return true
+	default:
+		return false
+	}
+}
+
+// readPromotedResources reads the given resources from the directory. If no resources are given, all files of the directory are read,
+// except for the metadata of the service
+func (p ResourceManager) readPromotedResources(directory string, resourceURIs []string) ([]models.Resource, error) {
+	if len(resourceURIs) == 0 {
+		uris, err := p.listResourceURIs(directory)
+		if err != nil {
+			return nil, err
+		}
+		for _, uri := range uris {
+			if uri != serviceMetadataFileName {
+				resourceURIs = append(resourceURIs, uri)
+			}
+		}
+	}
+
+	resources := []models.Resource{}
+	for _, resourceURI := range resourceURIs {
+		resourcePath := directory + "/" + resourceURI
+		if !p.fileSystem.FileExists(resourcePath) {
+			return nil, fmt.Errorf("could not promote resource %s: %w", resourceURI, kerrors.ErrResourceNotFound)
+		}
+		content, err := p.fileSystem.ReadFile(resourcePath)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, models.Resource{
+			ResourceURI:     resourceURI,
+			ResourceContent: models.ResourceContent(base64.StdEncoding.EncodeToString(content)),
+		})
+	}
+	return resources, nil
+}
+
+// ExportResources creates a tar.gz archive containing the resources of the project and of all of its stages, independent of whether
+// stages are represented as branches or as directories. The archive contains a metadata.yaml file listing the exported stages,
+// the resources of the root folder of the default branch in the project directory, and the resources of each stage, including
+// the folders of its services, in the directory stages/<stage>. If stages are represented as branches, the files the branch of a
+// stage has inherited from the default branch are only contained in the project directory, so that the layout of the archive is
+// the same as for stages represented as directories
+func (p ResourceManager) ExportResources(params models.ExportResourcesParams) ([]byte, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, projectPath, err := p.establishReadOnlyContext(params.Project, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	projectMetadata, err := p.readProjectMetadata(gitContext, projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := p.getStages(gitContext, projectPath, projectMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	projectFiles, err := p.readArchiveFiles(gitContext, projectPath, exportProjectDirectory)
+	if err != nil {
+		return nil, err
+	}
+	files := projectFiles
+
+	for _, stage := range stages {
+		_, stagePath, err := p.establishReadOnlyContext(params.Project, &models.Stage{StageName: stage}, nil)
+		if err != nil {
+			return nil, err
+		}
+		stageDirectory := exportStagesDirectory + "/" + stage
+		stageFiles, err := p.readArchiveFiles(gitContext, stagePath, stageDirectory)
+		if err != nil {
+			return nil, err
+		}
+		if !projectMetadata.IsUsingDirectoryStructure {
+			stageFiles = removeInheritedProjectFiles(stageFiles, stageDirectory, projectFiles)
+		}
+		files = append(files, stageFiles...)
+	}
+
+	metadata, err := yaml.Marshal(common.ExportMetadata{
+		ProjectName:     params.ProjectName,
+		ExportTimestamp: time.Now().UTC().Format(time.RFC3339),
+		Stages:          stages,
+	})
+	if err != nil {
+		return nil, err
+	}
+	files = append([]common.ArchiveFile{{Path: exportMetadataFileName, Content: metadata}}, files...)
+
+	return common.WriteTarGz(files)
+}
+
+// ImportResources writes the resources of an archive created by ExportResources to the project. The resources of the project and of
+// each stage are committed separately. The stages contained in the archive must already exist, otherwise nothing is imported.
+// The metadata files of the project and its stages are not overwritten
+func (p ResourceManager) ImportResources(params models.ImportResourcesParams) (*models.ImportResourcesResponse, error) {
+	files, err := common.ReadTarGz(params.Archive)
+	if err != nil {
+		return nil, err
+	}
+	projectResources, stageResources, err := groupImportedResources(files)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	stages := make([]string, 0, len(stageResources))
+	for stage := range stageResources {
+		stages = append(stages, stage)
+	}
+	sort.Strings(stages)
+
+	// the stages are checked before anything is committed, so that the project is not left partially imported if a stage is missing
+	for _, stage := range stages {
+		if _, _, err := p.establishContext(params.Project, &models.Stage{StageName: stage}, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &models.ImportResourcesResponse{Commits: []models.WriteResourceResponse{}}
+
+	if len(projectResources) > 0 {
+		gitContext, projectPath, err := p.establishContext(params.Project, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		message := applyCommitInfo(gitContext, params.CommitInfo, "Imported project resources")
+		commit, err := p.writeAndCommitResources(gitContext, projectResources, projectPath, message)
+		if err != nil {
+			return nil, err
+		}
+		result.Commits = append(result.Commits, *commit)
+	}
+
+	for _, stage := range stages {
+		gitContext, stagePath, err := p.establishContext(params.Project, &models.Stage{StageName: stage}, nil)
+		if err != nil {
+			return nil, err
+		}
+		message := applyCommitInfo(gitContext, params.CommitInfo, fmt.Sprintf("Imported resources of stage %s", stage))
+		commit, err := p.writeAndCommitResources(gitContext, stageResources[stage], stagePath, message)
+		if err != nil {
+			return nil, err
+		}
+		result.Commits = append(result.Commits, *commit)
+	}
+	return result, nil
+}
+
+// UploadResources writes the files of a multipart upload to the resources of the given context. The uploaded files are
+// buffered in a temporary directory before the project is locked, so that slow uploads do not block other requests
+func (p ResourceManager) UploadResources(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+	tmpDir, err := p.fileSystem.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer p.fileSystem.DeleteFile(tmpDir)
+
+	uploadedFiles, err := p.receiveUploadedFiles(params.Parts, tmpDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	message := applyCommitInfo(gitContext, params.CommitInfo, "Uploaded resources")
+	return p.copyAndCommitResources(gitContext, uploadedFiles, configPath, message)
+}
+
+// GetResourceContent returns the raw content of a resource, which is read from the repository while it is streamed to the client
+func (p ResourceManager) GetResourceContent(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishReadOnlyContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	revision := params.GitCommitID
+	if revision == "" || revision == "\"\"" {
+		if err := p.git.Pull(*gitContext); err != nil {
+			return nil, err
+		}
+		revision, err = p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resourcePath := getRelativeResourcePath(params.ProjectName, configPath, unescapedResourceName)
+	fileContent, err := p.git.OpenFileRevision(*gitContext, revision, resourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Render {
+		fileContent, err = p.renderResourceContent(gitContext, params, configPath, revision, unescapedResourceName, fileContent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.GetResourceContentResponse{
+		Content:  fileContent.Content,
+		Size:     fileContent.Size,
+		Checksum: fileContent.Checksum,
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     revision,
+		},
+	}, nil
+}
+
+// uploadedFile is a file of a multipart upload which has been buffered in a temporary directory
+type uploadedFile struct {
+	resourceURI string
+	path        string
+}
+
+// receiveUploadedFiles stores the files of the multipart upload in the given directory. The name of the form field of each file
+// is used as resource URI
+func (p ResourceManager) receiveUploadedFiles(parts *multipart.Reader, directory string) ([]uploadedFile, error) {
+	uploadedFiles := []uploadedFile{}
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", kerrors.ErrInvalidUpload, err)
+		}
+
+		resourceURI := strings.TrimPrefix(part.FormName(), "/")
+		if resourceURI == "" || part.FileName() == "" {
+			part.Close()
+			return nil, fmt.Errorf("%w: every part must contain a file named after the resource URI", kerrors.ErrInvalidUpload)
+		}
+		if err := (models.Resource{ResourceURI: resourceURI}).Validate(); err != nil {
+			part.Close()
+			return nil, err
+		}
+
+		file := uploadedFile{
+			resourceURI: resourceURI,
+			path:        fmt.Sprintf("%s/%d", directory, len(uploadedFiles)),
+		}
+		err = p.fileSystem.WriteFileFromReader(file.path, part)
+		part.Close()
+		if err != nil {
+			return nil, err
+		}
+		uploadedFiles = append(uploadedFiles, file)
+	}
+	if len(uploadedFiles) == 0 {
+		return nil, fmt.Errorf("%w: no files have been uploaded", kerrors.ErrInvalidUpload)
+	}
+	return uploadedFiles, nil
+}
+
+// readProjectMetadata reads the metadata of the project from the given project directory. If the project has no metadata, empty
+// metadata is returned
+func (p ResourceManager) readProjectMetadata(gitContext *common_models.GitContext, projectPath string) (*common.ProjectMetadata, error) {
+	metadata := &common.ProjectMetadata{}
+	metadataPath := projectPath + "/" + exportMetadataFileName
+	if p.fileSystem.FileExists(metadataPath) {
+		content, err := p.fileSystem.ReadFile(metadataPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(content, metadata); err != nil {
+			return nil, fmt.Errorf("could not parse metadata of project %s: %w", gitContext.Project, err)
+		}
+	}
+	return metadata, nil
+}
+
+// getStages returns the names of the stages of the project. Depending on the metadata of the project, stages are either
+// the directories within the stage directory, or the branches other than the default branch
+func (p ResourceManager) getStages(gitContext *common_models.GitContext, projectPath string, metadata *common.ProjectMetadata) ([]string, error) {
+	stages := []string{}
+	if metadata.IsUsingDirectoryStructure {
+		stageDirectory := projectPath + "/" + common.StageDirectoryName
+		if !p.fileSystem.FileExists(stageDirectory) {
+			return stages, nil
+		}
+		err := p.fileSystem.WalkPath(stageDirectory, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if path == stageDirectory || !info.IsDir() {
+				return nil
+			}
+			stages = append(stages, info.Name())
+			return filepath.SkipDir
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		defaultBranch, err := p.git.GetDefaultBranch(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+		branches, err := p.git.GetBranches(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			// branches of change requests are fetched from the upstream, but do not belong to a stage
+			if branch != strings.TrimPrefix(defaultBranch, "refs/heads/") && !strings.HasPrefix(branch, common.ReviewBranchPrefix) {
+				stages = append(stages, branch)
+			}
+		}
+	}
+	sort.Strings(stages)
+	return stages, nil
+}
+
+// readArchiveFiles reads all resources of the directory and places them in the given directory of the archive. Files stored in
+// Git LFS are resolved, so that the archive contains their content instead of the pointers
+func (p ResourceManager) readArchiveFiles(gitContext *common_models.GitContext, directory string, archiveDirectory string) ([]common.ArchiveFile, error) {
+	resourceURIs, err := p.listResourceURIs(directory)
+	if err != nil {
+		return nil, err
+	}
+	files := []common.ArchiveFile{}
+	for _, resourceURI := range resourceURIs {
+		content, err := p.fileSystem.ReadFile(directory + "/" + resourceURI)
+		if err != nil {
+			return nil, err
+		}
+		if _, isLFSPointer := common.ParseLFSPointer(content); isLFSPointer {
+			content, err = p.git.GetFileRevision(*gitContext, "HEAD", getRelativeResourcePath(gitContext.Project, directory, resourceURI))
+			if err != nil {
+				return nil, err
+			}
+		}
+		files = append(files, common.ArchiveFile{Path: archiveDirectory + "/" + resourceURI, Content: content})
+	}
+	return files, nil
+}
+
+// removeInheritedProjectFiles removes the files of a stage branch that have been inherited from the default branch, i.e., the
+// metadata and the shipyard of the project, and the files having the same content as the project resource with the same URI
+func removeInheritedProjectFiles(stageFiles []common.ArchiveFile, stageDirectory string, projectFiles []common.ArchiveFile) []common.ArchiveFile {
+	projectContents := map[string][]byte{}
+	for _, file := range projectFiles {
+		projectContents[strings.TrimPrefix(file.Path, exportProjectDirectory+"/")] = file.Content
+	}
+	files := []common.ArchiveFile{}
+	for _, file := range stageFiles {
+		resourceURI := strings.TrimPrefix(file.Path, stageDirectory+"/")
+		if resourceURI == exportMetadataFileName || resourceURI == shipyardFileName {
+			continue
+		}
+		if content, ok := projectContents[resourceURI]; ok && bytes.Equal(content, file.Content) {
+			continue
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// groupImportedResources assigns the files of an archive to the project or to the stages. The metadata files are skipped
+func groupImportedResources(files []common.ArchiveFile) ([]models.Resource, map[string][]models.Resource, error) {
+	projectResources := []models.Resource{}
+	stageResources := map[string][]models.Resource{}
+	for _, file := range files {
+		if file.Path == exportMetadataFileName {
+			continue
+		}
+		resource := models.Resource{ResourceContent: models.ResourceContent(base64.StdEncoding.EncodeToString(file.Content))}
+		if strings.HasPrefix(file.Path, exportProjectDirectory+"/") {
+			resource.ResourceURI = strings.TrimPrefix(file.Path, exportProjectDirectory+"/")
+			if !isImportableResourceURI(resource.ResourceURI) {
+				return nil, nil, fmt.Errorf("%w: unexpected file %s", kerrors.ErrInvalidArchive, file.Path)
+			}
+			if resource.ResourceURI != exportMetadataFileName {
+				projectResources = append(projectResources, resource)
+			}
+			continue
+		}
+		parts := strings.SplitN(file.Path, "/", 3)
+		if len(parts) != 3 || parts[0] != exportStagesDirectory || !isImportableResourceURI(parts[2]) {
+			return nil, nil, fmt.Errorf("%w: unexpected file %s", kerrors.ErrInvalidArchive, file.Path)
+		}
+		stage := models.Stage{StageName: parts[1]}
+		if err := stage.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("%w: invalid stage name %s", kerrors.ErrInvalidArchive, parts[1])
+		}
+		resource.ResourceURI = parts[2]
+		if resource.ResourceURI != exportMetadataFileName {
+			stageResources[stage.StageName] = append(stageResources[stage.StageName], resource)
+		}
+	}
+	return projectResources, stageResources, nil
+}
+
+// isImportableResourceURI checks that an imported resource neither modifies the git repository nor the stage directory
+func isImportableResourceURI(resourceURI string) bool {
+	for _, segment := range strings.Split(resourceURI, "/") {
+		if segment == ".git" || segment == common.StageDirectoryName {
+			return false
+		}
+	}
+	return models.Resource{ResourceURI: resourceURI}.Validate() == nil
+}
+
+// listResourceURIs returns the URIs of all files within the directory. Files of helm charts that have been extracted from
+// a chart archive are omitted, since they are extracted again when the archive is stored
+func (p ResourceManager) listResourceURIs(directory string) ([]string, error) {
+	files := []string{}
+	err := p.fileSystem.WalkPath(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath := strings.TrimPrefix(strings.TrimPrefix(path, directory), "/")
+		if strings.HasPrefix(relativePath, ".git/") || strings.HasPrefix(relativePath, common.StageDirectoryName+"/") {
+			return nil
+		}
+		if !info.IsDir() {
+			files = append(files, relativePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	archives := map[string]bool{}
+	for _, file := range files {
+		if common.IsHelmChartPath(file) {
+			archives[strings.TrimSuffix(file, ".tgz")+"/"] = true
+		}
+	}
+	resourceURIs := []string{}
+	for _, file := range files {
+		if !isExtractedHelmChartFile(file, archives) {
+			resourceURIs = append(resourceURIs, file)
+		}
+	}
+	return resourceURIs, nil
+}
+
+func isExtractedHelmChartFile(file string, archives map[string]bool) bool {
+	for chartDir := range archives {
+		if strings.HasPrefix(file, chartDir) {
+			return true
+		}
+	}
+	return false
+}
+
 func (p ResourceManager) establishContext(project models.Project, stage *models.Stage, service *models.Service) (*common_models.GitContext, string, error) {
+	return p.establishGitContext(project, stage, service, false)
+}
+
+// establishReadOnlyContext establishes the context for requests that do not modify the repository. If the upstream mirror is enabled,
+// these requests are served from the local repository as long as it is fresh
+func (p ResourceManager) establishReadOnlyContext(project models.Project, stage *models.Stage, service *models.Service) (*common_models.GitContext, string, error) {
+	return p.establishGitContext(project, stage, service, true)
+}
+
+func (p ResourceManager) establishGitContext(project models.Project, stage *models.Stage, service *models.Service, readOnly bool) (*common_models.GitContext, string, error) {
 	credentials, err := p.credentialReader.GetCredentials(project.ProjectName)
 	if err != nil {
 		return nil, "", fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, project.ProjectName, err)
@@ -176,12 +894,20 @@ func (p ResourceManager) establishContext(project models.Project, stage *models.
 	gitContext := common_models.GitContext{
 		Project:     project.ProjectName,
 		Credentials: credentials,
+		ReadOnly:    readOnly,
 	}
 
 	if !p.git.ProjectExists(gitContext) {
 		return nil, "", kerrors.ErrProjectNotFound
 	}
 
+	if !readOnly {
+		gitContext.Review, err = p.getReviewConfig(project, stage)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
 	configPath, err := p.configurationContext.Establish(common_models.ConfigurationContextParams{
 		Project:                 project,
 		Stage:                   stage,
@@ -195,16 +921,48 @@ func (p ResourceManager) establishContext(project models.Project, stage *models.
 	return &gitContext, configPath, nil
 }
 
+// getReviewConfig returns the review settings of the project if the changes of the stage are proposed as change requests,
+// or nil if the changes are pushed directly
+func (p ResourceManager) getReviewConfig(project models.Project, stage *models.Stage) (*common_models.ReviewConfig, error) {
+	if p.reviewConfigReader == nil {
+		return nil, nil
+	}
+	reviewConfig, err := p.reviewConfigReader.GetReviewConfig(project.ProjectName)
+	if err != nil || reviewConfig == nil {
+		return nil, err
+	}
+	stageName := ""
+	if stage != nil {
+		stageName = stage.StageName
+	}
+	if !reviewConfig.IsReviewed(stageName) {
+		return nil, nil
+	}
+	return reviewConfig, nil
+}
+
+// applyCommitInfo sets the author requested for the commit in the git context and returns the requested commit message,
+// or the default message if no commit message has been requested
+func applyCommitInfo(gitContext *common_models.GitContext, commitInfo models.CommitInfo, defaultMessage string) string {
+	if commitInfo.AuthorName != "" {
+		gitContext.Author = &common_models.CommitAuthor{
+			Name:  commitInfo.AuthorName,
+			Email: commitInfo.AuthorEmail,
+		}
+	}
+	if strings.TrimSpace(commitInfo.CommitMessage) == "" {
+		return defaultMessage
+	}
+	return commitInfo.CommitMessage
+}
+
 func (p ResourceManager) readResource(gitContext *common_models.GitContext, params models.GetResourceParams, configPath string, resourceName string) (*models.GetResourceResponse, error) {
 	var fileContent []byte
 	var revision string
 	var err error
 
 	if params.GitCommitID != "" && params.GitCommitID != "\"\"" {
-		// if commit ID is set, path needs to be relative to the project directory
-		configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(params.ProjectName))
-		// resource path must not start with "/", otherwise git is not able to resolve the revision
-		resourcePath := strings.TrimPrefix(configPath+"/"+resourceName, "/")
+		resourcePath := getRelativeResourcePath(params.ProjectName, configPath, resourceName)
 		fileContent, err = p.git.GetFileRevision(*gitContext, params.GitCommitID, resourcePath)
 		revision = params.GitCommitID
 	} else {
@@ -217,12 +975,24 @@ func (p ResourceManager) readResource(gitContext *common_models.GitContext, para
 			return nil, err
 		}
 		revision, err = p.git.GetCurrentRevision(*gitContext)
+		if _, isLFSPointer := common.ParseLFSPointer(fileContent); err == nil && isLFSPointer {
+			// the working tree only contains the pointer of files stored in Git LFS
+			fileContent, err = p.git.GetFileRevision(*gitContext, revision, getRelativeResourcePath(params.ProjectName, configPath, resourceName))
+		}
 	}
 	if err != nil {
 		return nil, err
 	}
 
+	if params.Render {
+		fileContent, err = p.renderResource(gitContext, params, configPath, revision, resourceName, fileContent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	resourceContent := base64.StdEncoding.EncodeToString(fileContent)
+	checksum := sha256.Sum256(fileContent)
 
 	return &models.GetResourceResponse{
 		Resource: models.Resource{
@@ -233,10 +1003,80 @@ func (p ResourceManager) readResource(gitContext *common_models.GitContext, para
 			UpstreamURL: gitContext.Credentials.RemoteURL,
 			Version:     revision,
 		},
+		Size:     int64(len(fileContent)),
+		Checksum: common.FormatChecksum(checksum[:]),
 	}, nil
 }
 
-func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitContext, resourcePath, resourceContent string) (*models.WriteResourceResponse, error) {
+// renderResource renders the resource as Go template, using the names of the project, stage and service of the resource and
+// the values of the stage in the given revision
+func (p ResourceManager) renderResource(gitContext *common_models.GitContext, params models.GetResourceParams, configPath string, revision string, resourceName string, content []byte) ([]byte, error) {
+	data := common.TemplateData{
+		Project: params.ProjectName,
+		Values:  map[string]interface{}{},
+	}
+	if params.Stage != nil {
+		data.Stage = params.Stage.StageName
+		stageConfigPath := configPath
+		if params.Service != nil {
+			data.Service = params.Service.ServiceName
+			stageConfigPath = strings.TrimSuffix(configPath, "/"+params.Service.ServiceName)
+		}
+		values, err := p.readStageValues(gitContext, params.ProjectName, stageConfigPath, revision)
+		if err != nil {
+			return nil, err
+		}
+		data.Values = values
+	}
+	return common.RenderTemplate(resourceName, content, data)
+}
+
+func (p ResourceManager) renderResourceContent(gitContext *common_models.GitContext, params models.GetResourceParams, configPath string, revision string, resourceName string, fileContent *common_models.FileContent) (*common_models.FileContent, error) {
+	content, err := io.ReadAll(fileContent.Content)
+	fileContent.Content.Close()
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := p.renderResource(gitContext, params, configPath, revision, resourceName, content)
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.Sum256(rendered)
+	return &common_models.FileContent{
+		Content:  io.NopCloser(bytes.NewReader(rendered)),
+		Size:     int64(len(rendered)),
+		Checksum: common.FormatChecksum(checksum[:]),
+	}, nil
+}
+
+// readStageValues reads the values.yaml file of the stage in the given revision. If the stage has no values.yaml file, no values are returned
+func (p ResourceManager) readStageValues(gitContext *common_models.GitContext, projectName string, stageConfigPath string, revision string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	content, err := p.git.GetFileRevision(*gitContext, revision, getRelativeResourcePath(projectName, stageConfigPath, stageValuesFileName))
+	if errors.Is(err, kerrors.ErrResourceNotFound) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("%w: invalid %s of stage: %v", kerrors.ErrResourceRenderFailed, stageValuesFileName, err)
+	}
+	if values == nil {
+		// the values file is empty
+		values = map[string]interface{}{}
+	}
+	return values, nil
+}
+
+// getRelativeResourcePath returns the path of the resource relative to the project directory, as required to resolve the resource in a git revision
+func getRelativeResourcePath(projectName string, configPath string, resourceName string) string {
+	configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(projectName))
+	// resource path must not start with "/", otherwise git is not able to resolve the revision
+	return strings.TrimPrefix(configPath+"/"+resourceName, "/")
+}
+
+func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitContext, resourcePath, resourceContent string, message string) (*models.WriteResourceResponse, error) {
 
 	var resultErr error
 	var resultCommit *models.WriteResourceResponse
@@ -251,7 +1091,7 @@ func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitCon
 			return nil
 		}
 
-		commit, err := p.stageAndCommit(gitContext, "Updated resource")
+		commit, err := p.stageAndCommit(gitContext, message)
 		if err != nil {
 			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
 				return err
@@ -265,7 +1105,13 @@ func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitCon
 	return resultCommit, resultErr
 }
 
-func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitContext, resources []models.Resource, directory string) (*models.WriteResourceResponse, error) {
+func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitContext, resources []models.Resource, directory string, message string) (*models.WriteResourceResponse, error) {
+	return p.syncAndCommitResources(gitContext, resources, directory, message, false)
+}
+
+// syncAndCommitResources stores the resources in the directory and commits them. If removeOthers is set, the resources of the
+// directory that are not part of the given resources are removed within the same commit
+func (p ResourceManager) syncAndCommitResources(gitContext *common_models.GitContext, resources []models.Resource, directory string, message string, removeOthers bool) (*models.WriteResourceResponse, error) {
 
 	var resultErr error
 	var resultCommit *models.WriteResourceResponse
@@ -282,8 +1128,14 @@ func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitCo
 				return nil
 			}
 		}
+		if removeOthers {
+			if err := p.removeOtherResources(resources, directory); err != nil {
+				resultErr = err
+				return nil
+			}
+		}
 
-		commit, err := p.stageAndCommit(gitContext, "Updated resource")
+		commit, err := p.stageAndCommit(gitContext, message)
 		if err != nil {
 			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
 				return err
@@ -297,6 +1149,34 @@ func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitCo
 	return resultCommit, resultErr
 }
 
+// removeOtherResources deletes the files of the directory that are not part of the given resources. The metadata of the
+// directory is kept, and the extracted files of removed helm chart archives are deleted along with the archive
+func (p ResourceManager) removeOtherResources(resources []models.Resource, directory string) error {
+	keep := map[string]bool{serviceMetadataFileName: true}
+	for _, res := range resources {
+		keep[res.ResourceURI] = true
+	}
+	resourceURIs, err := p.listResourceURIs(directory)
+	if err != nil {
+		return err
+	}
+	for _, resourceURI := range resourceURIs {
+		if keep[resourceURI] {
+			continue
+		}
+		resourcePath := directory + "/" + resourceURI
+		if err := p.fileSystem.DeleteFile(resourcePath); err != nil {
+			return err
+		}
+		if common.IsHelmChartPath(resourcePath) {
+			if err := p.fileSystem.DeleteFile(strings.TrimSuffix(resourcePath, ".tgz")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (p ResourceManager) storeResource(resourcePath, resourceContent string) error {
 	if err := p.fileSystem.WriteBase64EncodedFile(resourcePath, resourceContent); err != nil {
 		return err
@@ -309,7 +1189,59 @@ func (p ResourceManager) storeResource(resourcePath, resourceContent string) err
 	return nil
 }
 
+func (p ResourceManager) copyAndCommitResources(gitContext *common_models.GitContext, files []uploadedFile, directory string, message string) (*models.WriteResourceResponse, error) {
+
+	var resultErr error
+	var resultCommit *models.WriteResourceResponse
+	_ = retry.Retry(func() error {
+		err := p.git.Pull(*gitContext)
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+		for _, file := range files {
+			if err := p.copyResource(file.path, directory+"/"+file.resourceURI); err != nil {
+				resultErr = err
+				return nil
+			}
+		}
+
+		commit, err := p.stageAndCommit(gitContext, message)
+		if err != nil {
+			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
+				return err
+			}
+			resultErr = err
+			return nil
+		}
+		resultCommit = commit
+		return nil
+	}, retry.NumberOfRetries(5), retry.DelayBetweenRetries(1*time.Second))
+	return resultCommit, resultErr
+}
+
+func (p ResourceManager) copyResource(sourcePath, resourcePath string) error {
+	content, err := p.fileSystem.OpenFile(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if err := p.fileSystem.WriteFileFromReader(resourcePath, content); err != nil {
+		return err
+	}
+	if common.IsHelmChartPath(resourcePath) {
+		if err := p.fileSystem.WriteHelmChart(resourcePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p ResourceManager) stageAndCommit(gitContext *common_models.GitContext, message string) (*models.WriteResourceResponse, error) {
+	if gitContext.Review != nil {
+		return p.proposeChanges(gitContext, message)
+	}
 	commitID, err := p.git.StageAndCommitAll(*gitContext, message)
 	if err != nil {
 		return nil, err
@@ -325,7 +1257,43 @@ func (p ResourceManager) stageAndCommit(gitContext *common_models.GitContext, me
 	return result, nil
 }
 
-func (p ResourceManager) deleteResource(gitContext *common_models.GitContext, resourcePath string) (*models.WriteResourceResponse, error) {
+// proposeChanges commits the changes to a new branch and opens a change request for merging it into the current branch. The
+// changes are picked up as a new commit of the current branch once the change request has been merged upstream
+func (p ResourceManager) proposeChanges(gitContext *common_models.GitContext, message string) (*models.WriteResourceResponse, error) {
+	if p.changeRequestProvider == nil {
+		return nil, kerrors.ErrInvalidChangeRequestProvider
+	}
+	branch := common.NewReviewBranchName()
+	commitID, targetBranch, err := p.git.StageAndCommitToBranch(*gitContext, message, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	changeRequest, err := p.changeRequestProvider.CreateChangeRequest(*gitContext, common_models.ChangeRequest{
+		Title:        strings.SplitN(message, "\n", 2)[0],
+		Description:  message,
+		SourceBranch: branch,
+		TargetBranch: targetBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotCreateChangeRequest, branch, gitContext.Project, err)
+	}
+	return &models.WriteResourceResponse{
+		CommitID: commitID,
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     commitID,
+		},
+		ChangeRequest: &models.ChangeRequest{
+			ID:           changeRequest.ID,
+			URL:          changeRequest.URL,
+			SourceBranch: changeRequest.SourceBranch,
+			TargetBranch: changeRequest.TargetBranch,
+		},
+	}, nil
+}
+
+func (p ResourceManager) deleteResource(gitContext *common_models.GitContext, resourcePath string, message string) (*models.WriteResourceResponse, error) {
 	if !p.fileSystem.FileExists(resourcePath) {
 		return nil, kerrors.ErrResourceNotFound
 	}
@@ -333,5 +1301,5 @@ func (p ResourceManager) deleteResource(gitContext *common_models.GitContext, re
 		return nil, err
 	}
 
-	return p.stageAndCommit(gitContext, "Deleted resources")
+	return p.stageAndCommit(gitContext, message)
 }
@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
 	"io/fs"
+	"mime/multipart"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -21,6 +27,7 @@ import (
 
 const testConfigDir = "/data/config/my-project"
 const testServiceConfigDir = "/data/config/my-project/my-service"
+const testFileContentChecksum = "sha256:2239ce4df9ee8db012834642ec801b55ba2c92b28bdd11f4d73d9c55d39f3b0a"
 
 type testResourceManagerFields struct {
 	git              *common_mock.IGitMock
@@ -364,6 +371,37 @@ func TestResourceManager_UpdateResources_ProjectResource(t *testing.T) {
 	require.Equal(t, testConfigDir+"/file2", fields.fileSystem.WriteBase64EncodedFileCalls()[1].Path)
 }
 
+func TestResourceManager_UpdateResources_ProjectResource_CommitInfo(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	_, err := rm.UpdateResources(models.UpdateResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		UpdateResourcesPayload: models.UpdateResourcesPayload{
+			Resources: []models.Resource{
+				{
+					ResourceContent: "c3RyaW5n",
+					ResourceURI:     "file1",
+				},
+			},
+			CommitInfo: models.CommitInfo{
+				CommitMessage: "Increase replicas",
+				AuthorName:    "Jane Doe",
+				AuthorEmail:   "jane.doe@example.com",
+			},
+		},
+	})
+
+	require.Nil(t, err)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Increase replicas", fields.git.StageAndCommitAllCalls()[0].Message)
+	require.Equal(t, &common_models.CommitAuthor{Name: "Jane Doe", Email: "jane.doe@example.com"}, fields.git.StageAndCommitAllCalls()[0].GitContext.Author)
+}
+
 func TestResourceManager_UpdateResources_ProjectResource_ProjectNotFound(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
@@ -576,7 +614,11 @@ func TestResourceManager_UpdateResource_ProjectResource(t *testing.T) {
 
 	require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{Branch: "", UpstreamURL: "remote-url", Version: "my-revision"}}, revision)
 
+	require.False(t, fields.stageContext.EstablishCalls()[0].Params.GitContext.ReadOnly)
 	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.False(t, fields.git.StageAndCommitAllCalls()[0].GitContext.ReadOnly)
+	require.Equal(t, "Updated resource", fields.git.StageAndCommitAllCalls()[0].Message)
+	require.Nil(t, fields.git.StageAndCommitAllCalls()[0].GitContext.Author)
 
 	require.Len(t, fields.fileSystem.WriteBase64EncodedFileCalls(), 1)
 	require.Equal(t, testConfigDir+"/file1", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
@@ -778,6 +820,30 @@ func TestResourceManager_DeleteResource_ProjectResource(t *testing.T) {
 	require.Equal(t, testConfigDir+"/file1", fields.fileSystem.DeleteFileCalls()[0].Path)
 }
 
+func TestResourceManager_DeleteResource_ProjectResource_CommitInfo(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	_, err := rm.DeleteResource(models.DeleteResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		CommitInfo: models.CommitInfo{
+			CommitMessage: "Remove obsolete file",
+			AuthorName:    "Jane Doe",
+			AuthorEmail:   "jane.doe@example.com",
+		},
+	})
+
+	require.Nil(t, err)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Remove obsolete file", fields.git.StageAndCommitAllCalls()[0].Message)
+	require.Equal(t, &common_models.CommitAuthor{Name: "Jane Doe", Email: "jane.doe@example.com"}, fields.git.StageAndCommitAllCalls()[0].GitContext.Author)
+}
+
 func TestResourceManager_DeleteResource_ProjectResource_ProjectNotFound(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
@@ -941,11 +1007,15 @@ func TestResourceManager_GetResource_ProjectResource(t *testing.T) {
 			UpstreamURL: "remote-url",
 			Version:     "my-revision",
 		},
+		Size:     12,
+		Checksum: testFileContentChecksum,
 	}, result)
 
 	require.Len(t, fields.stageContext.EstablishCalls(), 1)
+	require.True(t, fields.stageContext.EstablishCalls()[0].Params.GitContext.ReadOnly)
 
 	require.Len(t, fields.git.PullCalls(), 1)
+	require.True(t, fields.git.PullCalls()[0].GitContext.ReadOnly)
 
 	require.Empty(t, fields.git.GetFileRevisionCalls())
 	require.Len(t, fields.fileSystem.ReadFileCalls(), 1)
@@ -977,6 +1047,8 @@ func TestResourceManager_GetResource_ProjectResource_ProvideGitCommitID(t *testi
 			UpstreamURL: "remote-url",
 			Version:     "my-commit-id",
 		},
+		Size:     12,
+		Checksum: testFileContentChecksum,
 	}, result)
 
 	require.Len(t, fields.stageContext.EstablishCalls(), 1)
@@ -1018,6 +1090,8 @@ func TestResourceManager_GetResource_ServiceResource_ProvideGitCommitID(t *testi
 			UpstreamURL: "remote-url",
 			Version:     "my-commit-id",
 		},
+		Size:     12,
+		Checksum: testFileContentChecksum,
 	}, result)
 
 	require.Len(t, fields.stageContext.EstablishCalls(), 1)
@@ -1216,6 +1290,8 @@ func TestResourceManager_GetResources(t *testing.T) {
 					UpstreamURL: "remote-url",
 					Version:     "my-revision",
 				},
+				Size:     12,
+				Checksum: testFileContentChecksum,
 			},
 			{
 				Resource: models.Resource{
@@ -1227,6 +1303,8 @@ func TestResourceManager_GetResources(t *testing.T) {
 					UpstreamURL: "remote-url",
 					Version:     "my-revision",
 				},
+				Size:     12,
+				Checksum: testFileContentChecksum,
 			},
 			{
 				Resource: models.Resource{
@@ -1238,6 +1316,8 @@ func TestResourceManager_GetResources(t *testing.T) {
 					UpstreamURL: "remote-url",
 					Version:     "my-revision",
 				},
+				Size:     12,
+				Checksum: testFileContentChecksum,
 			},
 		},
 		TotalCount: 3,
@@ -1250,74 +1330,1455 @@ func TestResourceManager_GetResources(t *testing.T) {
 	require.Len(t, fields.fileSystem.WalkPathCalls(), 1)
 }
 
-type fakeFileInfo struct {
-	name  string
-	isDir bool
+func TestResourceManager_GetResourceHistory_ServiceResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "helm%2Fvalues.yaml",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+			PageSize: 2,
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.GetResourceHistoryResponse{
+		NextPageKey: "2",
+		PageSize:    2,
+		TotalCount:  3,
+		Commits: []models.ResourceCommit{
+			{CommitID: "commit-3", Author: "keptn", Message: "Updated resource", Timestamp: time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC)},
+			{CommitID: "commit-2", Author: "keptn", Message: "Updated resource", Timestamp: time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)},
+		},
+	}, result)
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.git.GetFileHistoryCalls(), 1)
+	require.Equal(t, "my-service/helm/values.yaml", fields.git.GetFileHistoryCalls()[0].File)
 }
 
-func newFakeFileInfo(name string, isDir bool) *fakeFileInfo {
-	return &fakeFileInfo{name: name, isDir: isDir}
+func TestResourceManager_GetResourceHistory_SecondPage(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+			PageSize:    2,
+			NextPageKey: "2",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "0", result.NextPageKey)
+	require.Equal(t, float64(3), result.TotalCount)
+	require.Len(t, result.Commits, 1)
+	require.Equal(t, "commit-1", result.Commits[0].CommitID)
+	require.Equal(t, "file1", fields.git.GetFileHistoryCalls()[0].File)
 }
 
-func (f fakeFileInfo) Name() string {
-	return f.name
+func TestResourceManager_GetResourceHistory_ResourceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileHistoryFunc = func(gitContext common_models.GitContext, file string) ([]common_models.Commit, error) {
+		return nil, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
 }
 
-func (fakeFileInfo) Size() int64 {
-	return 100
+func TestResourceManager_GetResourceHistory_PullFails(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.PullFunc = func(gitContext common_models.GitContext) error {
+		return errors.New("oops")
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.NotNil(t, err)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.GetFileHistoryCalls())
 }
 
-func (fakeFileInfo) Mode() fs.FileMode {
-	return os.ModePerm
+func TestResourceManager_GetResourceDiff(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			From: "commit-1",
+			To:   "commit-2",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.GetResourceDiffResponse{From: "commit-1", To: "commit-2", Diff: "my-diff"}, result)
+
+	require.Len(t, fields.git.GetFileDiffCalls(), 1)
+	require.Equal(t, "commit-1", fields.git.GetFileDiffCalls()[0].FromRevision)
+	require.Equal(t, "commit-2", fields.git.GetFileDiffCalls()[0].ToRevision)
+	require.Equal(t, "file1", fields.git.GetFileDiffCalls()[0].File)
+	require.Empty(t, fields.git.GetCurrentRevisionCalls())
 }
 
-func (fakeFileInfo) ModTime() time.Time {
-	return time.Time{}
+func TestResourceManager_GetResourceDiff_ToCurrentRevision(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			From: "commit-1",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.GetResourceDiffResponse{From: "commit-1", To: "my-revision", Diff: "my-diff"}, result)
+	require.Equal(t, "my-revision", fields.git.GetFileDiffCalls()[0].ToRevision)
 }
 
-func (f fakeFileInfo) IsDir() bool {
-	return f.isDir
+func TestResourceManager_GetResourceDiff_RevisionNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileDiffFunc = func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+		return "", errors2.ErrResolveRevision
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			From: "unknown",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResolveRevision)
+	require.Nil(t, result)
 }
 
-func (fakeFileInfo) Sys() interface{} {
-	return nil
+func TestResourceManager_RevertResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "file1",
+		RevertResourceQuery: models.RevertResourceQuery{
+			CommitID: "commit-1",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-revision"}}, result)
+
+	// the upstream is pulled before the revision is resolved
+	require.NotEmpty(t, fields.git.PullCalls())
+
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "commit-1", fields.git.GetFileRevisionCalls()[0].Revision)
+	require.Equal(t, "my-service/file1", fields.git.GetFileRevisionCalls()[0].File)
+
+	require.Len(t, fields.fileSystem.WriteBase64EncodedFileCalls(), 1)
+	require.Equal(t, testServiceConfigDir+"/file1", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
+	require.Equal(t, "ZmlsZS1jb250ZW50", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Content)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Reverted resource file1 to revision commit-1", fields.git.StageAndCommitAllCalls()[0].Message)
 }
 
-func getTestResourceManagerFields() testResourceManagerFields {
-	return testResourceManagerFields{
-		git: &common_mock.IGitMock{
-			ResetHardFunc:          func(gitContext common_models.GitContext) error { return nil },
-			CheckoutBranchFunc:     func(gitContext common_models.GitContext, branch string) error { return nil },
-			CloneRepoFunc:          func(gitContext common_models.GitContext) (bool, error) { return true, nil },
-			CreateBranchFunc:       func(gitContext common_models.GitContext, branch string, sourceBranch string) error { return nil },
-			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) { return "my-revision", nil },
-			GetDefaultBranchFunc:   func(gitContext common_models.GitContext) (string, error) { return "main", nil },
-			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
-				return []byte("file-content"), nil
-			},
-			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
-			ProjectRepoExistsFunc: func(projectName string) bool { return true },
-			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
-			PushFunc:              func(gitContext common_models.GitContext) error { return nil },
-			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) { return "my-revision", nil },
+func TestResourceManager_RevertResource_CommitInfo(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	_, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
 		},
-		credentialReader: &common_mock.CredentialReaderMock{
-			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
-				return &common_models.GitCredentials{
-					User: "user",
-					HttpsAuth: &apimodels.HttpsGitAuth{
-						Token: "token",
-					},
-					RemoteURL: "remote-url",
-				}, nil
+		ResourceURI: "file1",
+		RevertResourceQuery: models.RevertResourceQuery{
+			CommitID: "commit-1",
+			CommitInfo: models.CommitInfo{
+				CommitMessage: "Undo change",
+				AuthorName:    "Jane Doe",
+				AuthorEmail:   "jane.doe@example.com",
 			},
 		},
-		fileSystem: &common_mock.IFileSystemMock{
-			DeleteFileFunc: func(path string) error {
-				return nil
-			},
-			FileExistsFunc: func(path string) bool {
-				return true
+	})
+
+	require.Nil(t, err)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Undo change", fields.git.StageAndCommitAllCalls()[0].Message)
+	require.Equal(t, &common_models.CommitAuthor{Name: "Jane Doe", Email: "jane.doe@example.com"}, fields.git.StageAndCommitAllCalls()[0].GitContext.Author)
+}
+
+func TestResourceManager_RevertResource_RevisionNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return nil, errors2.ErrResolveRevision
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		RevertResourceQuery: models.RevertResourceQuery{
+			CommitID: "unknown",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResolveRevision)
+	require.Nil(t, result)
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_RevertResource_PullFails(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.PullFunc = func(gitContext common_models.GitContext) error {
+		return errors2.ErrRepositoryNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		RevertResourceQuery: models.RevertResourceQuery{
+			CommitID: "commit-1",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrRepositoryNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.GetFileRevisionCalls())
+}
+
+func TestResourceManager_PromoteResources(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return "/data/config/my-project/.keptn-stages/" + params.Stage.StageName + "/my-service", nil
+	}
+	fields.fileSystem.WalkPathFunc = func(path string, walkFunc filepath.WalkFunc) error {
+		_ = walkFunc(path, newFakeFileInfo("my-service", true), nil)
+		_ = walkFunc(path+"/helm", newFakeFileInfo("helm", true), nil)
+		_ = walkFunc(path+"/helm/my-service", newFakeFileInfo("my-service", true), nil)
+		_ = walkFunc(path+"/helm/my-service/Chart.yaml", newFakeFileInfo("Chart.yaml", false), nil)
+		_ = walkFunc(path+"/helm/my-service.tgz", newFakeFileInfo("my-service.tgz", false), nil)
+		_ = walkFunc(path+"/slo.yaml", newFakeFileInfo("slo.yaml", false), nil)
+		return nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "hardening",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-revision"}}, result)
+
+	require.Len(t, fields.stageContext.EstablishCalls(), 2)
+	require.Equal(t, "dev", fields.stageContext.EstablishCalls()[0].Params.Stage.StageName)
+	require.Equal(t, "hardening", fields.stageContext.EstablishCalls()[1].Params.Stage.StageName)
+
+	require.Len(t, fields.fileSystem.ReadFileCalls(), 2)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/dev/my-service/helm/my-service.tgz", fields.fileSystem.ReadFileCalls()[0].Filename)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/dev/my-service/slo.yaml", fields.fileSystem.ReadFileCalls()[1].Filename)
+
+	require.Len(t, fields.fileSystem.WriteBase64EncodedFileCalls(), 2)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/hardening/my-service/helm/my-service.tgz", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/hardening/my-service/slo.yaml", fields.fileSystem.WriteBase64EncodedFileCalls()[1].Path)
+	require.Len(t, fields.fileSystem.WriteHelmChartCalls(), 1)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Promoted resources of service my-service from stage dev to stage hardening", fields.git.StageAndCommitAllCalls()[0].Message)
+}
+
+func TestResourceManager_PromoteResources_RemovesResourcesMissingInSourceStage(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return "/data/config/my-project/.keptn-stages/" + params.Stage.StageName + "/my-service", nil
+	}
+	fields.fileSystem.WalkPathFunc = func(path string, walkFunc filepath.WalkFunc) error {
+		_ = walkFunc(path, newFakeFileInfo("my-service", true), nil)
+		_ = walkFunc(path+"/metadata.yaml", newFakeFileInfo("metadata.yaml", false), nil)
+		_ = walkFunc(path+"/slo.yaml", newFakeFileInfo("slo.yaml", false), nil)
+		if strings.Contains(path, "/hardening/") {
+			_ = walkFunc(path+"/helm", newFakeFileInfo("helm", true), nil)
+			_ = walkFunc(path+"/helm/my-service", newFakeFileInfo("my-service", true), nil)
+			_ = walkFunc(path+"/helm/my-service/Chart.yaml", newFakeFileInfo("Chart.yaml", false), nil)
+			_ = walkFunc(path+"/helm/my-service.tgz", newFakeFileInfo("my-service.tgz", false), nil)
+			_ = walkFunc(path+"/webhook/webhook.yaml", newFakeFileInfo("webhook.yaml", false), nil)
+		}
+		return nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	_, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "hardening",
+		},
+	})
+
+	require.Nil(t, err)
+	// the metadata of the service in the target stage is kept
+	require.Len(t, fields.fileSystem.WriteBase64EncodedFileCalls(), 1)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/hardening/my-service/slo.yaml", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
+
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 3)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/hardening/my-service/helm/my-service.tgz", fields.fileSystem.DeleteFileCalls()[0].Path)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/hardening/my-service/helm/my-service", fields.fileSystem.DeleteFileCalls()[1].Path)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/hardening/my-service/webhook/webhook.yaml", fields.fileSystem.DeleteFileCalls()[2].Path)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+}
+
+func TestResourceManager_PromoteResources_SelectedResources(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	_, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage:  "hardening",
+			ResourceURIs: []string{"slo.yaml"},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Empty(t, fields.fileSystem.WalkPathCalls())
+	require.Empty(t, fields.fileSystem.DeleteFileCalls())
+	require.Len(t, fields.fileSystem.WriteBase64EncodedFileCalls(), 1)
+	require.Equal(t, testServiceConfigDir+"/slo.yaml", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
+	require.Equal(t, "ZmlsZS1jb250ZW50", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Content)
+}
+
+func TestResourceManager_PromoteResources_BranchModeMergesSourceStage(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetCurrentRevisionFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "dev-revision", nil
+	}
+	fields.fileSystem.WalkPathFunc = func(path string, walkFunc filepath.WalkFunc) error {
+		_ = walkFunc(path, newFakeFileInfo("my-service", true), nil)
+		_ = walkFunc(path+"/slo.yaml", newFakeFileInfo("slo.yaml", false), nil)
+		return nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, NewBranchConfigurationContext(fields.git, fields.fileSystem))
+
+	_, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "hardening",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Len(t, fields.git.CheckoutBranchCalls(), 2)
+	require.Equal(t, "dev", fields.git.CheckoutBranchCalls()[0].Branch)
+	require.Equal(t, "hardening", fields.git.CheckoutBranchCalls()[1].Branch)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "dev-revision", fields.git.StageAndCommitAllCalls()[0].GitContext.MergedRevision)
+	require.Equal(t, "Promoted resources of service my-service from stage dev to stage hardening", fields.git.StageAndCommitAllCalls()[0].Message)
+}
+
+func TestResourceManager_PromoteResources_BranchModeCherryPicksSelectedResources(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetCurrentRevisionFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "dev-revision", nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, NewBranchConfigurationContext(fields.git, fields.fileSystem))
+
+	_, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage:  "hardening",
+			ResourceURIs: []string{"slo.yaml"},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Empty(t, fields.git.StageAndCommitAllCalls()[0].GitContext.MergedRevision)
+	require.Equal(t, "Promoted resources of service my-service from stage dev to stage hardening\n\n(cherry picked from commit dev-revision)", fields.git.StageAndCommitAllCalls()[0].Message)
+}
+
+func TestResourceManager_PromoteResources_CommitInfo(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	_, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage:  "hardening",
+			ResourceURIs: []string{"slo.yaml"},
+			CommitInfo: models.CommitInfo{
+				CommitMessage: "Promote release 1.2",
+				AuthorName:    "Jane Doe",
+				AuthorEmail:   "jane.doe@example.com",
+			},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Promote release 1.2", fields.git.StageAndCommitAllCalls()[0].Message)
+	require.Equal(t, &common_models.CommitAuthor{Name: "Jane Doe", Email: "jane.doe@example.com"}, fields.git.StageAndCommitAllCalls()[0].GitContext.Author)
+}
+
+func TestResourceManager_PromoteResources_ResourceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.fileSystem.FileExistsFunc = func(path string) bool {
+		return false
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage:  "hardening",
+			ResourceURIs: []string{"slo.yaml"},
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+	require.Len(t, fields.stageContext.EstablishCalls(), 1)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_PromoteResources_TargetServiceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		if params.Stage.StageName == "hardening" {
+			return "", errors2.ErrServiceNotFound
+		}
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "hardening",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrServiceNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_ExportResources(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
+		if filename == testConfigDir+"/metadata.yaml" {
+			return []byte("projectName: my-project\nisUsingDirectoryStructure: false"), nil
+		}
+		// the branch of each stage has its own version of file2
+		if strings.HasSuffix(filename, "/file2") {
+			return []byte("content of " + filename), nil
+		}
+		return []byte("file-content"), nil
+	}
+	fields.fileSystem.WalkPathFunc = func(path string, walkFunc filepath.WalkFunc) error {
+		_ = walkFunc(path, newFakeFileInfo(filepath.Base(path), true), nil)
+		_ = walkFunc(path+"/metadata.yaml", newFakeFileInfo("metadata.yaml", false), nil)
+		_ = walkFunc(path+"/shipyard.yaml", newFakeFileInfo("shipyard.yaml", false), nil)
+		_ = walkFunc(path+"/file1", newFakeFileInfo("file1", false), nil)
+		_ = walkFunc(path+"/file2", newFakeFileInfo("file2", false), nil)
+		if path != testConfigDir {
+			_ = walkFunc(path+"/my-service/slo.yaml", newFakeFileInfo("slo.yaml", false), nil)
+		}
+		return nil
+	}
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		if params.Stage != nil {
+			return testConfigDir + "-" + params.Stage.StageName, nil
+		}
+		return testConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	archive, err := rm.ExportResources(models.ExportResourcesParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.Nil(t, err)
+
+	files, err := common.ReadTarGz(archive)
+	require.Nil(t, err)
+
+	paths := []string{}
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	// the files inherited from the default branch are only contained in the project directory
+	require.Equal(t, []string{
+		"metadata.yaml",
+		"project/metadata.yaml",
+		"project/shipyard.yaml",
+		"project/file1",
+		"project/file2",
+		"stages/dev/file2",
+		"stages/dev/my-service/slo.yaml",
+		"stages/hardening/file2",
+		"stages/hardening/my-service/slo.yaml",
+	}, paths)
+	require.Contains(t, string(files[0].Content), "projectName: my-project")
+	require.Contains(t, string(files[0].Content), "- dev\n    - hardening")
+	require.Equal(t, "file-content", string(files[2].Content))
+	require.Equal(t, "content of "+testConfigDir+"-dev/file2", string(files[5].Content))
+
+	require.Len(t, fields.stageContext.EstablishCalls(), 3)
+	require.Nil(t, fields.stageContext.EstablishCalls()[0].Params.Stage)
+	require.Equal(t, "dev", fields.stageContext.EstablishCalls()[1].Params.Stage.StageName)
+	require.Equal(t, "hardening", fields.stageContext.EstablishCalls()[2].Params.Stage.StageName)
+}
+
+func TestResourceManager_ExportResources_DirectoryStructure(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
+		if filename == testConfigDir+"/metadata.yaml" {
+			return []byte("projectName: my-project\nisUsingDirectoryStructure: true"), nil
+		}
+		return []byte("file-content"), nil
+	}
+	fields.fileSystem.WalkPathFunc = func(path string, walkFunc filepath.WalkFunc) error {
+		_ = walkFunc(path, newFakeFileInfo(filepath.Base(path), true), nil)
+		if path == testConfigDir+"/.keptn-stages" {
+			_ = walkFunc(path+"/production", newFakeFileInfo("production", true), nil)
+			_ = walkFunc(path+"/dev", newFakeFileInfo("dev", true), nil)
+			return nil
+		}
+		_ = walkFunc(path+"/shipyard.yaml", newFakeFileInfo("shipyard.yaml", false), nil)
+		return nil
+	}
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		if params.Stage != nil {
+			return testConfigDir + "/.keptn-stages/" + params.Stage.StageName, nil
+		}
+		return testConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	archive, err := rm.ExportResources(models.ExportResourcesParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.Nil(t, err)
+
+	files, err := common.ReadTarGz(archive)
+	require.Nil(t, err)
+
+	paths := []string{}
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	require.Equal(t, []string{"metadata.yaml", "project/shipyard.yaml", "stages/dev/shipyard.yaml", "stages/production/shipyard.yaml"}, paths)
+	require.Empty(t, fields.git.GetBranchesCalls())
+}
+
+func TestResourceManager_ExportResources_ProjectNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	archive, err := rm.ExportResources(models.ExportResourcesParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Nil(t, archive)
+}
+
+func TestResourceManager_ImportResources(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		if params.Stage != nil {
+			return testConfigDir + "/.keptn-stages/" + params.Stage.StageName, nil
+		}
+		return testConfigDir, nil
+	}
+
+	archive, err := common.WriteTarGz([]common.ArchiveFile{
+		{Path: "metadata.yaml", Content: []byte("projectName: other-project")},
+		{Path: "project/metadata.yaml", Content: []byte("projectName: other-project")},
+		{Path: "project/shipyard.yaml", Content: []byte("file-content")},
+		{Path: "stages/production/my-service/helm/my-service.tgz", Content: []byte("file-content")},
+		{Path: "stages/dev/metadata.yaml", Content: []byte("stageName: dev")},
+		{Path: "stages/dev/my-service/slo.yaml", Content: []byte("file-content")},
+	})
+	require.Nil(t, err)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.ImportResources(models.ImportResourcesParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Archive: archive,
+	})
+
+	require.Nil(t, err)
+	require.Len(t, result.Commits, 3)
+
+	require.Len(t, fields.fileSystem.WriteBase64EncodedFileCalls(), 3)
+	require.Equal(t, testConfigDir+"/shipyard.yaml", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
+	require.Equal(t, "ZmlsZS1jb250ZW50", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Content)
+	require.Equal(t, testConfigDir+"/.keptn-stages/dev/my-service/slo.yaml", fields.fileSystem.WriteBase64EncodedFileCalls()[1].Path)
+	require.Equal(t, testConfigDir+"/.keptn-stages/production/my-service/helm/my-service.tgz", fields.fileSystem.WriteBase64EncodedFileCalls()[2].Path)
+	require.Len(t, fields.fileSystem.WriteHelmChartCalls(), 1)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 3)
+	require.Equal(t, "Imported project resources", fields.git.StageAndCommitAllCalls()[0].Message)
+	require.Equal(t, "Imported resources of stage dev", fields.git.StageAndCommitAllCalls()[1].Message)
+	require.Equal(t, "Imported resources of stage production", fields.git.StageAndCommitAllCalls()[2].Message)
+}
+
+func TestResourceManager_ImportResources_CommitInfo(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	archive, err := common.WriteTarGz([]common.ArchiveFile{
+		{Path: "metadata.yaml", Content: []byte("projectName: other-project")},
+		{Path: "project/shipyard.yaml", Content: []byte("file-content")},
+		{Path: "stages/dev/my-service/slo.yaml", Content: []byte("file-content")},
+	})
+	require.Nil(t, err)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	_, err = rm.ImportResources(models.ImportResourcesParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Archive: archive,
+		CommitInfo: models.CommitInfo{
+			CommitMessage: "Restore backup",
+			AuthorName:    "Jane Doe",
+			AuthorEmail:   "jane.doe@example.com",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 2)
+	for _, call := range fields.git.StageAndCommitAllCalls() {
+		require.Equal(t, "Restore backup", call.Message)
+		require.Equal(t, &common_models.CommitAuthor{Name: "Jane Doe", Email: "jane.doe@example.com"}, call.GitContext.Author)
+	}
+}
+
+func TestResourceManager_ImportResources_InvalidArchive(t *testing.T) {
+	archiveWithUnexpectedFile, err := common.WriteTarGz([]common.ArchiveFile{{Path: "services/my-service/slo.yaml", Content: []byte("file-content")}})
+	require.Nil(t, err)
+	archiveWithInvalidStage, err := common.WriteTarGz([]common.ArchiveFile{{Path: "stages/my stage/slo.yaml", Content: []byte("file-content")}})
+	require.Nil(t, err)
+	archiveWithGitFile, err := common.WriteTarGz([]common.ArchiveFile{{Path: "project/.git/config", Content: []byte("file-content")}})
+	require.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		archive []byte
+	}{
+		{
+			name:    "not a tar.gz archive",
+			archive: []byte("file-content"),
+		},
+		{
+			name:    "unexpected file",
+			archive: archiveWithUnexpectedFile,
+		},
+		{
+			name:    "invalid stage name",
+			archive: archiveWithInvalidStage,
+		},
+		{
+			name:    "file of git repository",
+			archive: archiveWithGitFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := getTestResourceManagerFields()
+
+			rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+			result, err := rm.ImportResources(models.ImportResourcesParams{
+				Project: models.Project{ProjectName: "my-project"},
+				Archive: tt.archive,
+			})
+
+			require.ErrorIs(t, err, errors2.ErrInvalidArchive)
+			require.Nil(t, result)
+			require.Empty(t, fields.git.StageAndCommitAllCalls())
+		})
+	}
+}
+
+func TestResourceManager_ImportResources_StageNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		if params.Stage != nil {
+			return "", errors2.ErrStageNotFound
+		}
+		return testConfigDir, nil
+	}
+
+	archive, err := common.WriteTarGz([]common.ArchiveFile{
+		{Path: "project/shipyard.yaml", Content: []byte("file-content")},
+		{Path: "stages/dev/my-service/slo.yaml", Content: []byte("file-content")},
+	})
+	require.Nil(t, err)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.ImportResources(models.ImportResourcesParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Archive: archive,
+	})
+
+	require.ErrorIs(t, err, errors2.ErrStageNotFound)
+	require.Nil(t, result)
+	// nothing is committed if a stage does not exist
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+}
+
+func TestResourceManager_UploadResources(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	uploadedFiles := map[string]string{}
+	fields.fileSystem.CreateTempDirFunc = func() (string, error) {
+		return "/data/config/.tmp-upload", nil
+	}
+	fields.fileSystem.WriteFileFromReaderFunc = func(path string, content io.Reader) error {
+		data, err := io.ReadAll(content)
+		uploadedFiles[path] = string(data)
+		return err
+	}
+	fields.fileSystem.OpenFileFunc = func(filename string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(uploadedFiles[filename])), nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.UploadResources(models.UploadResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		Parts: newTestMultipartReader(t, [][2]string{
+			{"file1", "file-content"},
+			{"helm/my-service.tgz", "chart-content"},
+		}),
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{
+		CommitID: "my-revision",
+		Metadata: models.Version{
+			UpstreamURL: "remote-url",
+			Version:     "my-revision",
+		},
+	}, result)
+
+	require.Equal(t, "file-content", uploadedFiles[testConfigDir+"/file1"])
+	require.Equal(t, "chart-content", uploadedFiles[testConfigDir+"/helm/my-service.tgz"])
+	require.Len(t, fields.fileSystem.WriteHelmChartCalls(), 1)
+	require.Equal(t, testConfigDir+"/helm/my-service.tgz", fields.fileSystem.WriteHelmChartCalls()[0].Path)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Uploaded resources", fields.git.StageAndCommitAllCalls()[0].Message)
+
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+	require.Equal(t, "/data/config/.tmp-upload", fields.fileSystem.DeleteFileCalls()[0].Path)
+}
+
+func TestResourceManager_UploadResources_InvalidUpload(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   [][2]string
+		wantErr error
+	}{
+		{
+			name:    "no files",
+			files:   [][2]string{},
+			wantErr: errors2.ErrInvalidUpload,
+		},
+		{
+			name:    "invalid resource URI",
+			files:   [][2]string{{"../file1", "file-content"}},
+			wantErr: errors2.ErrResourceInvalidResourceURI,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := getTestResourceManagerFields()
+			fields.fileSystem.CreateTempDirFunc = func() (string, error) {
+				return "/data/config/.tmp-upload", nil
+			}
+			fields.fileSystem.WriteFileFromReaderFunc = func(path string, content io.Reader) error {
+				return nil
+			}
+
+			rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+			result, err := rm.UploadResources(models.UploadResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				Parts: newTestMultipartReader(t, tt.files),
+			})
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Nil(t, result)
+			require.Empty(t, fields.git.StageAndCommitAllCalls())
+			require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+		})
+	}
+}
+
+func TestResourceManager_GetResourceContent(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceContent(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "helm%2Fmy-service.tgz",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, int64(12), result.Size)
+	require.Equal(t, testFileContentChecksum, result.Checksum)
+	require.Equal(t, models.Version{UpstreamURL: "remote-url", Version: "my-revision"}, result.Metadata)
+	content, err := io.ReadAll(result.Content)
+	require.Nil(t, err)
+	require.Equal(t, "file-content", string(content))
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.git.OpenFileRevisionCalls(), 1)
+	require.Equal(t, "my-revision", fields.git.OpenFileRevisionCalls()[0].Revision)
+	require.Equal(t, "my-service/helm/my-service.tgz", fields.git.OpenFileRevisionCalls()[0].File)
+}
+
+func TestResourceManager_GetResourceContent_ProvideGitCommitID(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceContent(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceQuery: models.GetResourceQuery{
+			GitCommitID: "my-commit-id",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "my-commit-id", result.Metadata.Version)
+
+	require.Empty(t, fields.git.PullCalls())
+	require.Len(t, fields.git.OpenFileRevisionCalls(), 1)
+	require.Equal(t, "my-commit-id", fields.git.OpenFileRevisionCalls()[0].Revision)
+	require.Equal(t, "file1", fields.git.OpenFileRevisionCalls()[0].File)
+}
+
+func TestResourceManager_GetResourceContent_ResourceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.OpenFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) (*common_models.FileContent, error) {
+		return nil, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceContent(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+}
+
+func TestResourceManager_GetResource_LFSPointer(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
+		pointer := common_models.LFSPointer{OID: strings.TrimPrefix(testFileContentChecksum, "sha256:"), Size: 12}
+		return []byte(pointer.String()), nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, models.ResourceContent("ZmlsZS1jb250ZW50"), result.ResourceContent)
+	require.Equal(t, testFileContentChecksum, result.Checksum)
+
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "my-revision", fields.git.GetFileRevisionCalls()[0].Revision)
+	require.Equal(t, "file1", fields.git.GetFileRevisionCalls()[0].File)
+}
+
+func TestResourceManager_GetResource_Render(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      string
+		valuesErr   error
+		wantContent string
+		wantErr     error
+	}{
+		{
+			name:        "render with stage values",
+			values:      "endpoint: https://my-stage.example.com\n",
+			wantContent: "url: https://my-stage.example.com/my-service",
+		},
+		{
+			name:      "missing value without values file",
+			valuesErr: fmt.Errorf("could not retrieve revision: %w", errors2.ErrResourceNotFound),
+			wantErr:   errors2.ErrResourceRenderFailed,
+		},
+		{
+			name:    "invalid values",
+			values:  "endpoint: [",
+			wantErr: errors2.ErrResourceRenderFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := getTestResourceManagerFields()
+
+			fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+				return testServiceConfigDir, nil
+			}
+			fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
+				return []byte("url: {{ .Values.endpoint }}/{{ .Service }}"), nil
+			}
+			fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+				return []byte(tt.values), tt.valuesErr
+			}
+
+			rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+			result, err := rm.GetResource(models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI:      "config.yaml",
+				GetResourceQuery: models.GetResourceQuery{Render: true},
+			})
+
+			require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+			require.Equal(t, "my-revision", fields.git.GetFileRevisionCalls()[0].Revision)
+			require.Equal(t, "values.yaml", fields.git.GetFileRevisionCalls()[0].File)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, result)
+				return
+			}
+			require.Nil(t, err)
+			content, err := base64.StdEncoding.DecodeString(string(result.ResourceContent))
+			require.Nil(t, err)
+			require.Equal(t, tt.wantContent, string(content))
+			require.Equal(t, int64(len(tt.wantContent)), result.Size)
+		})
+	}
+}
+
+func TestResourceManager_GetResource_RenderProjectResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
+		return []byte("project: {{ .Project }}"), nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI:      "config.yaml",
+		GetResourceQuery: models.GetResourceQuery{Render: true},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, models.ResourceContent(base64.StdEncoding.EncodeToString([]byte("project: my-project"))), result.ResourceContent)
+	require.Empty(t, fields.git.GetFileRevisionCalls())
+}
+
+func TestResourceManager_GetResourceContent_Render(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testConfigDir + "/.keptn-stages/my-stage", nil
+	}
+	fields.git.OpenFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) (*common_models.FileContent, error) {
+		return &common_models.FileContent{
+			Content:  io.NopCloser(strings.NewReader("replicas: {{ .Values.replicas }}")),
+			Size:     32,
+			Checksum: "sha256:my-checksum",
+		}, nil
+	}
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return []byte("replicas: 3"), nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceContent(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+		},
+		ResourceURI: "config.yaml",
+		GetResourceQuery: models.GetResourceQuery{
+			GitCommitID: "my-commit-id",
+			Render:      true,
+		},
+	})
+
+	require.Nil(t, err)
+	content, err := io.ReadAll(result.Content)
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 3", string(content))
+	require.Equal(t, int64(11), result.Size)
+	require.Equal(t, "sha256:776530bb9f97f489746afe927013f7b659ac108e425ca071bb13efe63c130f98", result.Checksum)
+
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "my-commit-id", fields.git.GetFileRevisionCalls()[0].Revision)
+	require.Equal(t, ".keptn-stages/my-stage/values.yaml", fields.git.GetFileRevisionCalls()[0].File)
+}
+
+func TestResourceManager_UpdateResource_Review(t *testing.T) {
+	tests := []struct {
+		name              string
+		reviewConfig      *common_models.ReviewConfig
+		wantChangeRequest bool
+	}{
+		{
+			name:              "review all stages",
+			reviewConfig:      &common_models.ReviewConfig{Provider: common_models.ChangeRequestProviderGitHub, Stages: []string{}},
+			wantChangeRequest: true,
+		},
+		{
+			name:              "review stage",
+			reviewConfig:      &common_models.ReviewConfig{Provider: common_models.ChangeRequestProviderGitHub, Stages: []string{"dev", "production"}},
+			wantChangeRequest: true,
+		},
+		{
+			name:         "review other stage",
+			reviewConfig: &common_models.ReviewConfig{Provider: common_models.ChangeRequestProviderGitHub, Stages: []string{"production"}},
+		},
+		{
+			name: "no review",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := getTestResourceManagerFields()
+			reviewConfigReader := &common_mock.ReviewConfigReaderMock{
+				GetReviewConfigFunc: func(project string) (*common_models.ReviewConfig, error) {
+					return tt.reviewConfig, nil
+				},
+			}
+			changeRequestProvider := &common_mock.ChangeRequestProviderMock{
+				CreateChangeRequestFunc: func(gitContext common_models.GitContext, changeRequest common_models.ChangeRequest) (*common_models.ChangeRequest, error) {
+					changeRequest.ID = "1"
+					changeRequest.URL = "https://github.com/my-org/my-repo/pull/1"
+					return &changeRequest, nil
+				},
+			}
+
+			rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+			rm.SetReview(reviewConfigReader, changeRequestProvider)
+
+			result, err := rm.UpdateResource(models.UpdateResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "dev"},
+				},
+				ResourceURI: "file1",
+				UpdateResourcePayload: models.UpdateResourcePayload{
+					ResourceContent: "c3RyaW5n",
+					CommitInfo:      models.CommitInfo{CommitMessage: "Update file1\n\nIncrease the number of replicas"},
+				},
+			})
+			require.Nil(t, err)
+
+			if !tt.wantChangeRequest {
+				require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-revision"}}, result)
+				require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+				require.Empty(t, fields.git.StageAndCommitToBranchCalls())
+				require.Empty(t, changeRequestProvider.CreateChangeRequestCalls())
+				return
+			}
+
+			require.Empty(t, fields.git.StageAndCommitAllCalls())
+			require.Len(t, fields.git.StageAndCommitToBranchCalls(), 1)
+			branch := fields.git.StageAndCommitToBranchCalls()[0].Branch
+			require.True(t, strings.HasPrefix(branch, common.ReviewBranchPrefix))
+			require.Equal(t, tt.reviewConfig, fields.git.StageAndCommitToBranchCalls()[0].GitContext.Review)
+
+			require.Len(t, changeRequestProvider.CreateChangeRequestCalls(), 1)
+			require.Equal(t, common_models.ChangeRequest{
+				Title:        "Update file1",
+				Description:  "Update file1\n\nIncrease the number of replicas",
+				SourceBranch: branch,
+				TargetBranch: "main",
+			}, changeRequestProvider.CreateChangeRequestCalls()[0].ChangeRequest)
+
+			require.Equal(t, &models.WriteResourceResponse{
+				CommitID: "my-review-revision",
+				Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-review-revision"},
+				ChangeRequest: &models.ChangeRequest{
+					ID:           "1",
+					URL:          "https://github.com/my-org/my-repo/pull/1",
+					SourceBranch: branch,
+					TargetBranch: "main",
+				},
+			}, result)
+		})
+	}
+}
+
+func TestResourceManager_UpdateResource_Review_ChangeRequestFails(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	reviewConfigReader := &common_mock.ReviewConfigReaderMock{
+		GetReviewConfigFunc: func(project string) (*common_models.ReviewConfig, error) {
+			return &common_models.ReviewConfig{Provider: common_models.ChangeRequestProviderGitLab}, nil
+		},
+	}
+	changeRequestProvider := &common_mock.ChangeRequestProviderMock{
+		CreateChangeRequestFunc: func(gitContext common_models.GitContext, changeRequest common_models.ChangeRequest) (*common_models.ChangeRequest, error) {
+			return nil, fmt.Errorf("%w: unexpected status code 401", errors2.ErrChangeRequestFailed)
+		},
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm.SetReview(reviewConfigReader, changeRequestProvider)
+
+	result, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+	require.ErrorIs(t, err, errors2.ErrChangeRequestFailed)
+	require.Nil(t, result)
+	require.Len(t, fields.git.StageAndCommitToBranchCalls(), 1)
+}
+
+func TestResourceManager_UpdateResource_Review_InvalidProvider(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	reviewConfigReader := &common_mock.ReviewConfigReaderMock{
+		GetReviewConfigFunc: func(project string) (*common_models.ReviewConfig, error) {
+			return nil, errors2.ErrInvalidChangeRequestProvider
+		},
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm.SetReview(reviewConfigReader, &common_mock.ChangeRequestProviderMock{})
+
+	result, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+	require.ErrorIs(t, err, errors2.ErrInvalidChangeRequestProvider)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Empty(t, fields.git.StageAndCommitToBranchCalls())
+}
+
+func TestResourceManager_PromoteResources_Review(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	reviewConfigReader := &common_mock.ReviewConfigReaderMock{
+		GetReviewConfigFunc: func(project string) (*common_models.ReviewConfig, error) {
+			return &common_models.ReviewConfig{Provider: common_models.ChangeRequestProviderGitea, Stages: []string{"hardening"}}, nil
+		},
+	}
+	changeRequestProvider := &common_mock.ChangeRequestProviderMock{
+		CreateChangeRequestFunc: func(gitContext common_models.GitContext, changeRequest common_models.ChangeRequest) (*common_models.ChangeRequest, error) {
+			changeRequest.ID = "1"
+			return &changeRequest, nil
+		},
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm.SetReview(reviewConfigReader, changeRequestProvider)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "hardening",
+		},
+	})
+	require.Nil(t, err)
+	require.NotNil(t, result.ChangeRequest)
+
+	// the changes of the target stage are reviewed, regardless of the source stage
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Len(t, fields.git.StageAndCommitToBranchCalls(), 1)
+	require.Equal(t, "Promoted resources of service my-service from stage dev to stage hardening", fields.git.StageAndCommitToBranchCalls()[0].Message)
+}
+
+func newTestMultipartReader(t *testing.T, files [][2]string) *multipart.Reader {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file[0], path.Base(file[0]))
+		require.Nil(t, err)
+		_, err = part.Write([]byte(file[1]))
+		require.Nil(t, err)
+	}
+	require.Nil(t, writer.Close())
+	return multipart.NewReader(body, writer.Boundary())
+}
+
+type fakeFileInfo struct {
+	name  string
+	isDir bool
+}
+
+func newFakeFileInfo(name string, isDir bool) *fakeFileInfo {
+	return &fakeFileInfo{name: name, isDir: isDir}
+}
+
+func (f fakeFileInfo) Name() string {
+	return f.name
+}
+
+func (fakeFileInfo) Size() int64 {
+	return 100
+}
+
+func (fakeFileInfo) Mode() fs.FileMode {
+	return os.ModePerm
+}
+
+func (fakeFileInfo) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (f fakeFileInfo) IsDir() bool {
+	return f.isDir
+}
+
+func (fakeFileInfo) Sys() interface{} {
+	return nil
+}
+
+func getTestResourceManagerFields() testResourceManagerFields {
+	return testResourceManagerFields{
+		git: &common_mock.IGitMock{
+			ResetHardFunc:          func(gitContext common_models.GitContext) error { return nil },
+			CheckoutBranchFunc:     func(gitContext common_models.GitContext, branch string) error { return nil },
+			CloneRepoFunc:          func(gitContext common_models.GitContext) (bool, error) { return true, nil },
+			CreateBranchFunc:       func(gitContext common_models.GitContext, branch string, sourceBranch string) error { return nil },
+			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) { return "my-revision", nil },
+			GetDefaultBranchFunc:   func(gitContext common_models.GitContext) (string, error) { return "main", nil },
+			GetBranchesFunc: func(gitContext common_models.GitContext) ([]string, error) {
+				return []string{"dev", "main", "hardening", "keptn-review/20221019-120000.000000000"}, nil
+			},
+			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+				return []byte("file-content"), nil
+			},
+			GetFileHistoryFunc: func(gitContext common_models.GitContext, file string) ([]common_models.Commit, error) {
+				return []common_models.Commit{
+					{ID: "commit-3", Author: "keptn", Message: "Updated resource", Time: time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC)},
+					{ID: "commit-2", Author: "keptn", Message: "Updated resource", Time: time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)},
+					{ID: "commit-1", Author: "keptn", Message: "Added resource", Time: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)},
+				}, nil
+			},
+			GetFileDiffFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+				return "my-diff", nil
+			},
+			OpenFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) (*common_models.FileContent, error) {
+				return &common_models.FileContent{
+					Content:  io.NopCloser(strings.NewReader("file-content")),
+					Size:     12,
+					Checksum: testFileContentChecksum,
+				}, nil
+			},
+			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
+			ProjectRepoExistsFunc: func(projectName string) bool { return true },
+			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
+			PushFunc:              func(gitContext common_models.GitContext) error { return nil },
+			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) { return "my-revision", nil },
+			StageAndCommitToBranchFunc: func(gitContext common_models.GitContext, message string, branch string) (string, string, error) {
+				return "my-review-revision", "main", nil
+			},
+		},
+		credentialReader: &common_mock.CredentialReaderMock{
+			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
+				return &common_models.GitCredentials{
+					User: "user",
+					HttpsAuth: &apimodels.HttpsGitAuth{
+						Token: "token",
+					},
+					RemoteURL: "remote-url",
+				}, nil
+			},
+		},
+		fileSystem: &common_mock.IFileSystemMock{
+			DeleteFileFunc: func(path string) error {
+				return nil
+			},
+			FileExistsFunc: func(path string) bool {
+				return true
+			},
+			GetFileChecksumFunc: func(filename string) (string, int64, error) {
+				return testFileContentChecksum, 12, nil
 			},
 			MakeDirFunc: func(path string) error {
 				return nil
@@ -38,7 +38,9 @@ func NewServiceManager(git common.IGit, credentialReader common.CredentialReader
 }
 
 func (s ServiceManager) CreateService(params models.CreateServiceParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, servicePath, err := s.establishServiceContext(params.Project, params.Stage, params.Service)
@@ -69,7 +71,9 @@ func (s ServiceManager) CreateService(params models.CreateServiceParams) error {
 }
 
 func (s ServiceManager) DeleteService(params models.DeleteServiceParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, servicePath, err := s.establishServiceContext(params.Project, params.Stage, params.Service)
@@ -16,6 +16,12 @@ type IServiceResourceHandler interface {
 	GetServiceResource(context *gin.Context)
 	UpdateServiceResource(context *gin.Context)
 	DeleteServiceResource(context *gin.Context)
+	GetServiceResourceHistory(context *gin.Context)
+	GetServiceResourceDiff(context *gin.Context)
+	RevertServiceResource(context *gin.Context)
+	PromoteServiceResources(context *gin.Context)
+	UploadServiceResources(context *gin.Context)
+	GetServiceResourceContent(context *gin.Context)
 }
 
 type ServiceResourceHandler struct {
@@ -60,6 +66,7 @@ func (ph *ServiceResourceHandler) CreateServiceResources(c *gin.Context) {
 	}
 
 	params.CreateResourcesPayload = *createResources
+	setCommitAuthor(c, &params.CommitInfo)
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
@@ -155,6 +162,7 @@ func (ph *ServiceResourceHandler) UpdateServiceResources(c *gin.Context) {
 	}
 
 	params.UpdateResourcesPayload = *updateResources
+	setCommitAuthor(c, &params.CommitInfo)
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
@@ -183,6 +191,7 @@ func (ph *ServiceResourceHandler) UpdateServiceResources(c *gin.Context) {
 // @Param        serviceName                                 path    string  true  "The name of the service"
 // @Param        resourceURI                           path  string  true    "The path of the resource file"
 // @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Param        render       query     bool    false  "Render the resource as Go template using the values.yaml file of the stage"
 // @Success      200          {object}  models.GetResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -250,6 +259,7 @@ func (ph *ServiceResourceHandler) UpdateServiceResource(c *gin.Context) {
 	}
 
 	params.UpdateResourcePayload = *updateResource
+	setCommitAuthor(c, &params.CommitInfo)
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
@@ -277,6 +287,7 @@ func (ph *ServiceResourceHandler) UpdateServiceResource(c *gin.Context) {
 // @Param        stageName                        path    string  true  "The name of the stage"
 // @Param        serviceName                      path    string  true  "The name of the service"
 // @Param        resourceURI                path  string  true    "The path of the resource file"
+// @Param        commitMessage  query  string  false  "The message of the commit"
 // @Success      200          {string}  models.WriteResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -291,6 +302,15 @@ func (ph *ServiceResourceHandler) DeleteServiceResource(c *gin.Context) {
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
 
+	commitInfo := &models.CommitInfo{}
+	if err := c.ShouldBindQuery(commitInfo); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.CommitInfo = *commitInfo
+	setCommitAuthor(c, &params.CommitInfo)
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -304,3 +324,317 @@ func (ph *ServiceResourceHandler) DeleteServiceResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetServiceResourceHistory godoc
+// @Summary      Get the history of a service resource
+// @Description  Get the commits that changed the resource of the service of a project stage, starting with the most recent one
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        pageSize     query     int     false  "The number of items to return"
+// @Param        nextPageKey  query     string  false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/history [get]
+func (ph *ServiceResourceHandler) GetServiceResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceHistory := &models.GetResourceHistoryQuery{PageSize: 20}
+	if err := c.ShouldBindQuery(getResourceHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getResourceHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.ServiceResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetServiceResourceDiff godoc
+// @Summary      Get the diff of a service resource
+// @Description  Get the changes of the resource of the service of a project stage between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        from         query     string  true   "The commit ID of the base revision"
+// @Param        to           query     string  false  "The commit ID of the target revision, defaults to the current revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/diff [get]
+func (ph *ServiceResourceHandler) GetServiceResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getResourceDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getResourceDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ServiceResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertServiceResource godoc
+// @Summary      Reverts a service resource
+// @Description  Restores the content the resource of the service of a project stage had in the given revision and commits it as a new revision
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        commitID     query     string  true  "The commit ID of the revision to be restored"
+// @Param        commitMessage  query  string  false  "The message of the commit"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/revert [post]
+func (ph *ServiceResourceHandler) RevertServiceResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	revertResource := &models.RevertResourceQuery{}
+	if err := c.ShouldBindQuery(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourceQuery = *revertResource
+	setCommitAuthor(c, &params.CommitInfo)
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// PromoteServiceResources godoc
+// @Summary      Promotes service resources to another stage
+// @Description  Copies the resources of the service, or the given subset of them, from the given stage to the target stage and commits them.
+// @Description  If stages are represented as branches, promoting all resources merges the branch of the stage into the branch of the target stage,
+// @Description  taking the resources of the service from the stage and everything else from the target stage. Promoting a subset of the resources cherry-picks them.
+// @Description  If no resources are given, all resources of the service are promoted and the resources that only exist in the target stage are removed.
+// @Description  The metadata of the service in the target stage is kept.
+// @Description  The returned commit ID can be used as gitcommitid of the next sequence in the target stage
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage the resources are promoted from"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        promotion    body      models.PromoteResourcesPayload  true  "Target stage, resources and commit message"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/promote [post]
+func (ph *ServiceResourceHandler) PromoteServiceResources(c *gin.Context) {
+	params := &models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+	}
+
+	promoteResources := &models.PromoteResourcesPayload{}
+	if err := c.ShouldBindJSON(promoteResources); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.PromoteResourcesPayload = *promoteResources
+	setCommitAuthor(c, &params.CommitInfo)
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.PromoteResources(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UploadServiceResources godoc
+// @Summary      Uploads service resources
+// @Description  Creates or updates the resources of the service in the given stage of a project from a multipart/form-data request. The name of each form field is the URI of the resource,
+// @Description  its file is the raw content of the resource. Files are streamed to the service and do not need to be base64 encoded
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI    formData  file    true   "The content of the resource, named after the URI of the resource"
+// @Param        commitMessage  query     string  false  "The message of the commit"
+// @Success      201          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/upload [post]
+func (ph *ServiceResourceHandler) UploadServiceResources(c *gin.Context) {
+	params := &models.UploadResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+	}
+
+	commitInfo := &models.CommitInfo{}
+	if err := c.ShouldBindQuery(commitInfo); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.CommitInfo = *commitInfo
+	setCommitAuthor(c, &params.CommitInfo)
+
+	parts, err := c.Request.MultipartReader()
+	if err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrInvalidUpload.Error())
+		return
+	}
+
+	params.Parts = parts
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.UploadResources(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetServiceResourceContent godoc
+// @Summary      Get the content of a service resource
+// @Description  Get the raw content of a resource of the service in the given stage of a project. The content is streamed and not base64 encoded.
+// @Description  The Digest header contains the SHA-256 checksum of the content
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Produce      application/octet-stream
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI  path   string  true   "The path of the resource file"
+// @Param        gitCommitID  query  string  false  "The commit ID to be checked out"
+// @Param        render       query  bool    false  "Render the resource as Go template using the values.yaml file of the stage"
+// @Success      200          {file}    file
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/content [get]
+func (ph *ServiceResourceHandler) GetServiceResourceContent(c *gin.Context) {
+	params := &models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResource := &models.GetResourceQuery{}
+	if err := c.ShouldBindQuery(getResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceQuery = *getResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	content, err := ph.ServiceResourceManager.GetResourceContent(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	setResourceContentResponse(c, params.ResourceURI, content)
+}
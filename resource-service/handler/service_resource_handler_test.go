@@ -591,6 +591,54 @@ func TestServiceResourceHandler_GetServiceResource(t *testing.T) {
 			wantResult: nil,
 			wantStatus: http.StatusBadRequest,
 		},
+		{
+			name: "get rendered resource",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+						return &testGetResourceResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml?render=true", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceQuery: models.GetResourceQuery{
+					Render: true,
+				},
+			},
+			wantResult: &testGetResourceResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "resource could not be rendered",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+						return nil, errors2.ErrResourceRenderFailed
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml?render=true", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceQuery: models.GetResourceQuery{
+					Render: true,
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name: "resource not found",
 			fields: fields{
@@ -897,3 +945,663 @@ func TestServiceResourceHandler_DeleteServiceResource(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceResourceHandler_GetServiceResourceHistory(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	testHistory := models.GetResourceHistoryResponse{
+		NextPageKey: "0",
+		PageSize:    1,
+		TotalCount:  1,
+		Commits:     []models.ResourceCommit{{CommitID: "commit-id", Author: "keptn", Message: "Updated resource"}},
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceHistoryParams
+		wantResult *models.GetResourceHistoryResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource history",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return &testHistory, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/history?pageSize=1", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 1,
+				},
+			},
+			wantResult: &testHistory,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get history of resource in parent directory - should return error",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/..my-resource.yaml/history", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/history", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 20,
+				}},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/history", ph.GetServiceResourceHistory)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.GetResourceHistoryCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.GetResourceHistoryCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.GetResourceHistoryCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceHistoryResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestServiceResourceHandler_GetServiceResourceDiff(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	testDiff := models.GetResourceDiffResponse{From: "commit-1", To: "commit-2", Diff: "my-diff"}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceDiffParams
+		wantResult *models.GetResourceDiffResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource diff",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return &testDiff, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff?from=commit-1&to=commit-2", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "commit-1",
+					To:   "commit-2",
+				},
+			},
+			wantResult: &testDiff,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "from revision not set - should return error",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "revision not found",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return nil, errors2.ErrResolveRevision
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff?from=unknown", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "unknown",
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/diff", ph.GetServiceResourceDiff)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.GetResourceDiffCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.GetResourceDiffCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.GetResourceDiffCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceDiffResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestServiceResourceHandler_RevertServiceResource(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	testRevertResponse := models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{Version: "my-revision"}}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.RevertResourceParams
+		wantResult *models.WriteResourceResponse
+		wantStatus int
+	}{
+		{
+			name: "revert resource",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return &testRevertResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/revert?commitID=commit-1", nil),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				RevertResourceQuery: models.RevertResourceQuery{
+					CommitID: "commit-1",
+				},
+			},
+			wantResult: &testRevertResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "commit ID not set - should return error",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/revert", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return nil, errors.New("oops")
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/revert?commitID=commit-1", nil),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				RevertResourceQuery: models.RevertResourceQuery{
+					CommitID: "commit-1",
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/revert", ph.RevertServiceResource)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.RevertResourceCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.RevertResourceCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.RevertResourceCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.WriteResourceResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestServiceResourceHandler_PromoteServiceResources(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	testPromoteResponse := models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{Version: "my-revision"}}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.PromoteResourcesParams
+		wantResult *models.WriteResourceResponse
+		wantStatus int
+	}{
+		{
+			name: "promote resources",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.WriteResourceResponse, error) {
+						return &testPromoteResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/promote", bytes.NewBuffer([]byte(`{"targetStage": "hardening", "resourceURIs": ["slo.yaml"]}`))),
+			wantParams: &models.PromoteResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesPayload: models.PromoteResourcesPayload{
+					TargetStage:  "hardening",
+					ResourceURIs: []string{"slo.yaml"},
+				},
+			},
+			wantResult: &testPromoteResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "promote resources with commit message and author",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.WriteResourceResponse, error) {
+						return &testPromoteResponse, nil
+					},
+				},
+			},
+			request: withAuthorHeaders(httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/promote", bytes.NewBuffer([]byte(`{"targetStage": "hardening", "commitMessage": "Promote release 1.2", "authorName": "Mallory"}`))), "Jane Doe", "jane.doe@example.com"),
+			wantParams: &models.PromoteResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesPayload: models.PromoteResourcesPayload{
+					TargetStage: "hardening",
+					CommitInfo:  models.CommitInfo{CommitMessage: "Promote release 1.2", AuthorName: "Jane Doe", AuthorEmail: "jane.doe@example.com"},
+				},
+			},
+			wantResult: &testPromoteResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "target stage equals source stage - should return error",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/promote", bytes.NewBuffer([]byte(`{"targetStage": "my-stage"}`))),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "target stage not set - should return error",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/promote", bytes.NewBuffer([]byte(`{}`))),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource in parent directory - should return error",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/promote", bytes.NewBuffer([]byte(`{"targetStage": "hardening", "resourceURIs": ["../slo.yaml"]}`))),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service not found in target stage",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors2.ErrServiceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/promote", bytes.NewBuffer([]byte(`{"targetStage": "hardening"}`))),
+			wantParams: &models.PromoteResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesPayload: models.PromoteResourcesPayload{
+					TargetStage: "hardening",
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/stage/:stageName/service/:serviceName/promote", ph.PromoteServiceResources)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.PromoteResourcesCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.PromoteResourcesCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.PromoteResourcesCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.WriteResourceResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestServiceResourceHandler_UploadServiceResources(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.UploadResourcesParams
+		wantResult *models.WriteResourceResponse
+		wantStatus int
+	}{
+		{
+			name: "upload resources",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+					},
+				},
+			},
+			request: newTestUploadRequest(t, "/project/my-project/stage/my-stage/service/my-service/upload?commitMessage=my-message", "file1"),
+			wantParams: &models.UploadResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				CommitInfo: models.CommitInfo{CommitMessage: "my-message"},
+			},
+			wantResult: &models.WriteResourceResponse{CommitID: "my-commit-id"},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid resource URI",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors2.ErrResourceInvalidResourceURI
+					},
+				},
+			},
+			request: newTestUploadRequest(t, "/project/my-project/stage/my-stage/service/my-service/upload", "../file1"),
+			wantParams: &models.UploadResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not a multipart request",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/upload", bytes.NewBufferString(createResourcesTestPayload)),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid commit author",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    withAuthorHeaders(newTestUploadRequest(t, "/project/my-project/stage/my-stage/service/my-service/upload", "file1"), "keptn", ""),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/stage/:stageName/service/:serviceName/upload", ph.UploadServiceResources)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.UploadResourcesCalls(), 1)
+				params := tt.fields.ServiceResourceManager.UploadResourcesCalls()[0].Params
+				require.NotNil(t, params.Parts)
+				params.Parts = nil
+				require.Equal(t, *tt.wantParams, params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.UploadResourcesCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.WriteResourceResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestServiceResourceHandler_GetServiceResourceContent(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceParams
+		wantBody   []byte
+		wantStatus int
+	}{
+		{
+			name: "get resource content",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return newTestGetResourceContentResponse(), nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/helm%2Fmy-chart.tgz/content?gitCommitID=my-commit-id", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI:      "helm%2Fmy-chart.tgz",
+				GetResourceQuery: models.GetResourceQuery{GitCommitID: "my-commit-id"},
+			},
+			wantBody:   []byte("file-content"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/file1/content", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "file1",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return nil, errors.New("oops")
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/file1/content", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "file1",
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.UseRawPath = true
+			router.UnescapePathValues = false
+			router.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/content", ph.GetServiceResourceContent)
+
+			resp := performRequest(router, tt.request)
+
+			require.Len(t, tt.fields.ServiceResourceManager.GetResourceContentCalls(), 1)
+			require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.GetResourceContentCalls()[0].Params)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantBody != nil {
+				require.Equal(t, tt.wantBody, resp.Body.Bytes())
+				require.Equal(t, "application/octet-stream", resp.Header().Get("Content-Type"))
+				require.Equal(t, "12", resp.Header().Get("Content-Length"))
+				require.Equal(t, testResourceContentDigest, resp.Header().Get("Digest"))
+				require.Equal(t, `attachment; filename="my-chart.tgz"`, resp.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
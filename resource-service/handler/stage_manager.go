@@ -1,13 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+
 	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/common_models"
-	"github.com/keptn/keptn/resource-service/errors"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
 	"gopkg.in/yaml.v3"
-	"time"
 )
 
 //IStageManager provides an interface for stage CRUD operations
@@ -31,12 +34,14 @@ func NewStageManager(git common.IGit, credentialReader common.CredentialReader)
 }
 
 func (s BranchingStageManager) CreateStage(params models.CreateStageParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	credentials, err := s.credentialReader.GetCredentials(params.ProjectName)
 	if err != nil {
-		return fmt.Errorf(errors.ErrMsgCouldNotRetrieveCredentials, params.ProjectName, err)
+		return fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, params.ProjectName, err)
 	}
 
 	gitContext := common_models.GitContext{
@@ -45,7 +50,7 @@ func (s BranchingStageManager) CreateStage(params models.CreateStageParams) erro
 	}
 
 	if !s.git.ProjectExists(gitContext) {
-		return errors.ErrProjectNotFound
+		return kerrors.ErrProjectNotFound
 	}
 
 	defaultBranch, err := s.git.GetDefaultBranch(gitContext)
@@ -67,7 +72,43 @@ func (s BranchingStageManager) CreateStage(params models.CreateStageParams) erro
 }
 
 func (s BranchingStageManager) DeleteStage(params models.DeleteStageParams) error {
-	panic("implement me")
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	credentials, err := s.credentialReader.GetCredentials(params.ProjectName)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, params.ProjectName, err)
+	}
+
+	gitContext := common_models.GitContext{
+		Project:     params.ProjectName,
+		Credentials: credentials,
+	}
+
+	if !s.git.ProjectExists(gitContext) {
+		return kerrors.ErrProjectNotFound
+	}
+
+	defaultBranch, err := s.git.GetDefaultBranch(gitContext)
+	if err != nil {
+		return fmt.Errorf("could not determine default branch of project %s: %w", params.ProjectName, err)
+	}
+
+	// the default branch does not belong to a stage and must be kept
+	if params.StageName == strings.TrimPrefix(defaultBranch, "refs/heads/") {
+		return kerrors.ErrStageNotFound
+	}
+
+	if err := s.git.DeleteBranch(gitContext, params.StageName); err != nil {
+		if errors.Is(err, kerrors.ErrBranchNotFound) || errors.Is(err, kerrors.ErrReferenceNotFound) {
+			return kerrors.ErrStageNotFound
+		}
+		return fmt.Errorf("could not delete branch %s of project %s: %w", params.StageName, params.ProjectName, err)
+	}
+
+	return nil
 }
 
 type DirectoryStageManager struct {
@@ -82,7 +123,9 @@ func NewDirectoryStageManager(configurationContext IConfigurationContext, fileSy
 }
 
 func (dm DirectoryStageManager) CreateStage(params models.CreateStageParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, stagePath, err := dm.establishStageContext(params.Project, params.Stage)
@@ -91,7 +134,7 @@ func (dm DirectoryStageManager) CreateStage(params models.CreateStageParams) err
 	}
 
 	if dm.fileSystem.FileExists(stagePath) {
-		return errors.ErrStageAlreadyExists
+		return kerrors.ErrStageAlreadyExists
 	}
 	if err := dm.fileSystem.MakeDir(stagePath); err != nil {
 		return fmt.Errorf("could not create directory for stage %s: %w", params.StageName, err)
@@ -115,7 +158,9 @@ func (dm DirectoryStageManager) CreateStage(params models.CreateStageParams) err
 }
 
 func (dm DirectoryStageManager) DeleteStage(params models.DeleteStageParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, stagePath, err := dm.establishStageContext(params.Project, params.Stage)
@@ -124,13 +169,13 @@ func (dm DirectoryStageManager) DeleteStage(params models.DeleteStageParams) err
 	}
 
 	if !dm.fileSystem.FileExists(stagePath) {
-		return errors.ErrStageNotFound
+		return kerrors.ErrStageNotFound
 	}
 	if err := dm.fileSystem.DeleteFile(stagePath); err != nil {
 		return fmt.Errorf("could not delete directory of stage %s: %w", params.StageName, err)
 	}
 
-	if _, err := dm.git.StageAndCommitAll(*gitContext, "Added stage: "+params.StageName); err != nil {
+	if _, err := dm.git.StageAndCommitAll(*gitContext, "Deleted stage: "+params.StageName); err != nil {
 		return fmt.Errorf("could not delete stage %s: %w", params.StageName, err)
 	}
 
@@ -140,7 +185,7 @@ func (dm DirectoryStageManager) DeleteStage(params models.DeleteStageParams) err
 func (dm DirectoryStageManager) establishStageContext(project models.Project, stage models.Stage) (*common_models.GitContext, string, error) {
 	credentials, err := dm.credentialReader.GetCredentials(project.ProjectName)
 	if err != nil {
-		return nil, "", fmt.Errorf(errors.ErrMsgCouldNotRetrieveCredentials, project.ProjectName, err)
+		return nil, "", fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, project.ProjectName, err)
 	}
 
 	gitContext := common_models.GitContext{
@@ -149,7 +194,7 @@ func (dm DirectoryStageManager) establishStageContext(project models.Project, st
 	}
 
 	if !dm.git.ProjectExists(gitContext) {
-		return nil, "", errors.ErrProjectNotFound
+		return nil, "", kerrors.ErrProjectNotFound
 	}
 
 	configPath, err := dm.configurationContext.Establish(common_models.ConfigurationContextParams{
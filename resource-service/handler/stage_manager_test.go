@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
@@ -241,6 +242,82 @@ func TestStageManager_CreateStage_CannotPushBranch(t *testing.T) {
 	require.Equal(t, fields.git.CreateBranchCalls()[0].Branch, "my-stage")
 }
 
+func TestStageManager_DeleteStage(t *testing.T) {
+	params := models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	}
+
+	fields := getTestStageManagerFields()
+	s := NewStageManager(fields.git, fields.credentialReader)
+	err := s.DeleteStage(params)
+
+	require.Nil(t, err)
+
+	require.Len(t, fields.git.DeleteBranchCalls(), 1)
+	require.Equal(t, "my-project", fields.git.DeleteBranchCalls()[0].GitContext.Project)
+	require.Equal(t, "my-stage", fields.git.DeleteBranchCalls()[0].Branch)
+}
+
+func TestStageManager_DeleteStage_ProjectNotFound(t *testing.T) {
+	fields := getTestStageManagerFields()
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+	s := NewStageManager(fields.git, fields.credentialReader)
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Empty(t, fields.git.DeleteBranchCalls())
+}
+
+func TestStageManager_DeleteStage_DefaultBranch(t *testing.T) {
+	fields := getTestStageManagerFields()
+	fields.git.GetDefaultBranchFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "refs/heads/main", nil
+	}
+	s := NewStageManager(fields.git, fields.credentialReader)
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "main"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrStageNotFound)
+	require.Empty(t, fields.git.DeleteBranchCalls())
+}
+
+func TestStageManager_DeleteStage_BranchNotFound(t *testing.T) {
+	fields := getTestStageManagerFields()
+	fields.git.DeleteBranchFunc = func(gitContext common_models.GitContext, branch string) error {
+		return fmt.Errorf(errors2.ErrMsgCouldNotDelete, branch, gitContext.Project, errors2.ErrBranchNotFound)
+	}
+	s := NewStageManager(fields.git, fields.credentialReader)
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrStageNotFound)
+}
+
+func TestStageManager_DeleteStage_CannotDeleteBranch(t *testing.T) {
+	fields := getTestStageManagerFields()
+	fields.git.DeleteBranchFunc = func(gitContext common_models.GitContext, branch string) error {
+		return errors.New("oops")
+	}
+	s := NewStageManager(fields.git, fields.credentialReader)
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+
+	require.NotNil(t, err)
+	require.NotErrorIs(t, err, errors2.ErrStageNotFound)
+}
+
 func getTestStageManagerFields() stageManagerTestFields {
 	return stageManagerTestFields{
 		git: &common_mock.IGitMock{
@@ -265,6 +342,9 @@ func getTestStageManagerFields() stageManagerTestFields {
 			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
 				return nil
 			},
+			DeleteBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+				return nil
+			},
 		},
 		credentialReader: &common_mock.CredentialReaderMock{
 			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
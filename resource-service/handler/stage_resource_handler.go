@@ -16,6 +16,11 @@ type IStageResourceHandler interface {
 	GetStageResource(context *gin.Context)
 	UpdateStageResource(context *gin.Context)
 	DeleteStageResource(context *gin.Context)
+	GetStageResourceHistory(context *gin.Context)
+	GetStageResourceDiff(context *gin.Context)
+	RevertStageResource(context *gin.Context)
+	UploadStageResources(context *gin.Context)
+	GetStageResourceContent(context *gin.Context)
 }
 
 type StageResourceHandler struct {
@@ -58,6 +63,7 @@ func (ph *StageResourceHandler) CreateStageResources(c *gin.Context) {
 	}
 
 	params.CreateResourcesPayload = *createResources
+	setCommitAuthor(c, &params.CommitInfo)
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
@@ -148,6 +154,7 @@ func (ph *StageResourceHandler) UpdateStageResources(c *gin.Context) {
 	}
 
 	params.UpdateResourcesPayload = *updateResources
+	setCommitAuthor(c, &params.CommitInfo)
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
@@ -175,6 +182,7 @@ func (ph *StageResourceHandler) UpdateStageResources(c *gin.Context) {
 // @Param        stageName    path    string  true  "The name of the stage"
 // @Param        resourceURI  path  string  true    "The path of the resource file"
 // @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Param        render       query     bool    false  "Render the resource as Go template using the values.yaml file of the stage"
 // @Success      200          {object}  models.GetResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -240,6 +248,7 @@ func (ph *StageResourceHandler) UpdateStageResource(c *gin.Context) {
 	}
 
 	params.UpdateResourcePayload = *updateResource
+	setCommitAuthor(c, &params.CommitInfo)
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
@@ -266,6 +275,7 @@ func (ph *StageResourceHandler) UpdateStageResource(c *gin.Context) {
 // @Param        projectName  path    string  true  "The name of the project"
 // @Param        stageName    path    string  true  "The name of the stage"
 // @Param        resourceURI  path  string  true    "The path of the resource file"
+// @Param        commitMessage  query  string  false  "The message of the commit"
 // @Success      200          {string}  models.WriteResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -279,6 +289,15 @@ func (ph *StageResourceHandler) DeleteStageResource(c *gin.Context) {
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
 
+	commitInfo := &models.CommitInfo{}
+	if err := c.ShouldBindQuery(commitInfo); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.CommitInfo = *commitInfo
+	setCommitAuthor(c, &params.CommitInfo)
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -292,3 +311,253 @@ func (ph *StageResourceHandler) DeleteStageResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetStageResourceHistory godoc
+// @Summary      Get the history of a stage resource
+// @Description  Get the commits that changed the resource of the stage of a project, starting with the most recent one
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        pageSize     query     int     false  "The number of items to return"
+// @Param        nextPageKey  query     string  false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/history [get]
+func (ph *StageResourceHandler) GetStageResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceHistory := &models.GetResourceHistoryQuery{PageSize: 20}
+	if err := c.ShouldBindQuery(getResourceHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getResourceHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.StageResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetStageResourceDiff godoc
+// @Summary      Get the diff of a stage resource
+// @Description  Get the changes of the resource of the stage of a project between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        from         query     string  true   "The commit ID of the base revision"
+// @Param        to           query     string  false  "The commit ID of the target revision, defaults to the current revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/diff [get]
+func (ph *StageResourceHandler) GetStageResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getResourceDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getResourceDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.StageResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertStageResource godoc
+// @Summary      Reverts a stage resource
+// @Description  Restores the content the resource of the stage of a project had in the given revision and commits it as a new revision
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        commitID     query     string  true  "The commit ID of the revision to be restored"
+// @Param        commitMessage  query  string  false  "The message of the commit"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/revert [post]
+func (ph *StageResourceHandler) RevertStageResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	revertResource := &models.RevertResourceQuery{}
+	if err := c.ShouldBindQuery(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourceQuery = *revertResource
+	setCommitAuthor(c, &params.CommitInfo)
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UploadStageResources godoc
+// @Summary      Uploads stage resources
+// @Description  Creates or updates the resources of the stage of a project from a multipart/form-data request. The name of each form field is the URI of the resource,
+// @Description  its file is the raw content of the resource. Files are streamed to the service and do not need to be base64 encoded
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI    formData  file    true   "The content of the resource, named after the URI of the resource"
+// @Param        commitMessage  query     string  false  "The message of the commit"
+// @Success      201          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/upload [post]
+func (ph *StageResourceHandler) UploadStageResources(c *gin.Context) {
+	params := &models.UploadResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+	}
+
+	commitInfo := &models.CommitInfo{}
+	if err := c.ShouldBindQuery(commitInfo); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.CommitInfo = *commitInfo
+	setCommitAuthor(c, &params.CommitInfo)
+
+	parts, err := c.Request.MultipartReader()
+	if err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrInvalidUpload.Error())
+		return
+	}
+
+	params.Parts = parts
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.UploadResources(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetStageResourceContent godoc
+// @Summary      Get the content of a stage resource
+// @Description  Get the raw content of a resource of the stage of a project. The content is streamed and not base64 encoded.
+// @Description  The Digest header contains the SHA-256 checksum of the content
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Produce      application/octet-stream
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI  path   string  true   "The path of the resource file"
+// @Param        gitCommitID  query  string  false  "The commit ID to be checked out"
+// @Param        render       query  bool    false  "Render the resource as Go template using the values.yaml file of the stage"
+// @Success      200          {file}    file
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/content [get]
+func (ph *StageResourceHandler) GetStageResourceContent(c *gin.Context) {
+	params := &models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResource := &models.GetResourceQuery{}
+	if err := c.ShouldBindQuery(getResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceQuery = *getResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	content, err := ph.StageResourceManager.GetResourceContent(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	setResourceContentResponse(c, params.ResourceURI, content)
+}
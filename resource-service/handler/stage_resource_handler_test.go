@@ -641,6 +641,52 @@ func TestStageResourceHandler_GetStageResource(t *testing.T) {
 			wantResult: nil,
 			wantStatus: http.StatusBadRequest,
 		},
+		{
+			name: "get rendered resource",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+						return &testGetResourceResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/my-resource.yaml?render=true", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceQuery: models.GetResourceQuery{
+					Render: true,
+				},
+			},
+			wantResult: &testGetResourceResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "resource could not be rendered",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+						return nil, errors2.ErrResourceRenderFailed
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/my-resource.yaml?render=true", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceQuery: models.GetResourceQuery{
+					Render: true,
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name: "resource not found",
 			fields: fields{
@@ -827,3 +873,509 @@ func TestStageResourceHandler_DeleteStageResource(t *testing.T) {
 		})
 	}
 }
+
+func TestStageResourceHandler_GetStageResourceHistory(t *testing.T) {
+	type fields struct {
+		StageResourceManager *handler_mock.IResourceManagerMock
+	}
+	testHistory := models.GetResourceHistoryResponse{
+		NextPageKey: "0",
+		PageSize:    1,
+		TotalCount:  1,
+		Commits:     []models.ResourceCommit{{CommitID: "commit-id", Author: "keptn", Message: "Updated resource"}},
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceHistoryParams
+		wantResult *models.GetResourceHistoryResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource history",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return &testHistory, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/my-resource.yaml/history?pageSize=1", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 1,
+				},
+			},
+			wantResult: &testHistory,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get history of resource in parent directory - should return error",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/..my-resource.yaml/history", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/my-resource.yaml/history", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 20,
+				}},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewStageResourceHandler(tt.fields.StageResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/history", ph.GetStageResourceHistory)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.StageResourceManager.GetResourceHistoryCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.StageResourceManager.GetResourceHistoryCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.StageResourceManager.GetResourceHistoryCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceHistoryResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestStageResourceHandler_GetStageResourceDiff(t *testing.T) {
+	type fields struct {
+		StageResourceManager *handler_mock.IResourceManagerMock
+	}
+	testDiff := models.GetResourceDiffResponse{From: "commit-1", To: "commit-2", Diff: "my-diff"}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceDiffParams
+		wantResult *models.GetResourceDiffResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource diff",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return &testDiff, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/my-resource.yaml/diff?from=commit-1&to=commit-2", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "commit-1",
+					To:   "commit-2",
+				},
+			},
+			wantResult: &testDiff,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "from revision not set - should return error",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/my-resource.yaml/diff", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "revision not found",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return nil, errors2.ErrResolveRevision
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/my-resource.yaml/diff?from=unknown", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "unknown",
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewStageResourceHandler(tt.fields.StageResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/diff", ph.GetStageResourceDiff)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.StageResourceManager.GetResourceDiffCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.StageResourceManager.GetResourceDiffCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.StageResourceManager.GetResourceDiffCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceDiffResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestStageResourceHandler_RevertStageResource(t *testing.T) {
+	type fields struct {
+		StageResourceManager *handler_mock.IResourceManagerMock
+	}
+	testRevertResponse := models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{Version: "my-revision"}}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.RevertResourceParams
+		wantResult *models.WriteResourceResponse
+		wantStatus int
+	}{
+		{
+			name: "revert resource",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return &testRevertResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/resource/my-resource.yaml/revert?commitID=commit-1", nil),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "my-resource.yaml",
+				RevertResourceQuery: models.RevertResourceQuery{
+					CommitID: "commit-1",
+				},
+			},
+			wantResult: &testRevertResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "commit ID not set - should return error",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/resource/my-resource.yaml/revert", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return nil, errors.New("oops")
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/resource/my-resource.yaml/revert?commitID=commit-1", nil),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "my-resource.yaml",
+				RevertResourceQuery: models.RevertResourceQuery{
+					CommitID: "commit-1",
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewStageResourceHandler(tt.fields.StageResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/stage/:stageName/resource/:resourceURI/revert", ph.RevertStageResource)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.StageResourceManager.RevertResourceCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.StageResourceManager.RevertResourceCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.StageResourceManager.RevertResourceCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.WriteResourceResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestStageResourceHandler_UploadStageResources(t *testing.T) {
+	type fields struct {
+		StageResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.UploadResourcesParams
+		wantResult *models.WriteResourceResponse
+		wantStatus int
+	}{
+		{
+			name: "upload resources",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+					},
+				},
+			},
+			request: newTestUploadRequest(t, "/project/my-project/stage/my-stage/upload?commitMessage=my-message", "file1"),
+			wantParams: &models.UploadResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				CommitInfo: models.CommitInfo{CommitMessage: "my-message"},
+			},
+			wantResult: &models.WriteResourceResponse{CommitID: "my-commit-id"},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid resource URI",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors2.ErrResourceInvalidResourceURI
+					},
+				},
+			},
+			request: newTestUploadRequest(t, "/project/my-project/stage/my-stage/upload", "../file1"),
+			wantParams: &models.UploadResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not a multipart request",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/upload", bytes.NewBufferString(createResourcesTestPayload)),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid commit author",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					UploadResourcesFunc: func(params models.UploadResourcesParams) (*models.WriteResourceResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    withAuthorHeaders(newTestUploadRequest(t, "/project/my-project/stage/my-stage/upload", "file1"), "keptn", ""),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewStageResourceHandler(tt.fields.StageResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/stage/:stageName/upload", ph.UploadStageResources)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.StageResourceManager.UploadResourcesCalls(), 1)
+				params := tt.fields.StageResourceManager.UploadResourcesCalls()[0].Params
+				require.NotNil(t, params.Parts)
+				params.Parts = nil
+				require.Equal(t, *tt.wantParams, params)
+			} else {
+				require.Empty(t, tt.fields.StageResourceManager.UploadResourcesCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.WriteResourceResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestStageResourceHandler_GetStageResourceContent(t *testing.T) {
+	type fields struct {
+		StageResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceParams
+		wantBody   []byte
+		wantStatus int
+	}{
+		{
+			name: "get resource content",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return newTestGetResourceContentResponse(), nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/helm%2Fmy-chart.tgz/content?gitCommitID=my-commit-id", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI:      "helm%2Fmy-chart.tgz",
+				GetResourceQuery: models.GetResourceQuery{GitCommitID: "my-commit-id"},
+			},
+			wantBody:   []byte("file-content"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/file1/content", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "file1",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			fields: fields{
+				StageResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return nil, errors.New("oops")
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/resource/file1/content", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "file1",
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewStageResourceHandler(tt.fields.StageResourceManager)
+
+			router := gin.Default()
+			router.UseRawPath = true
+			router.UnescapePathValues = false
+			router.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/content", ph.GetStageResourceContent)
+
+			resp := performRequest(router, tt.request)
+
+			require.Len(t, tt.fields.StageResourceManager.GetResourceContentCalls(), 1)
+			require.Equal(t, *tt.wantParams, tt.fields.StageResourceManager.GetResourceContentCalls()[0].Params)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantBody != nil {
+				require.Equal(t, tt.wantBody, resp.Body.Bytes())
+				require.Equal(t, "application/octet-stream", resp.Header().Get("Content-Type"))
+				require.Equal(t, "12", resp.Header().Get("Content-Length"))
+				require.Equal(t, testResourceContentDigest, resp.Header().Get("Digest"))
+				require.Equal(t, `attachment; filename="my-chart.tgz"`, resp.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
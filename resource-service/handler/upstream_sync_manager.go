@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	logger "github.com/sirupsen/logrus"
+)
+
+const tmpMigrationProjectPrefix = "_keptn-tmp_"
+
+// IUpstreamSyncManager provides an interface for synchronizing the local repositories of projects with their upstream repositories
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/upstream_sync_manager_mock.go . IUpstreamSyncManager
+type IUpstreamSyncManager interface {
+	// SyncRepositories fetches the upstream repository of all projects using one of the given repository URLs and returns the names of these projects
+	SyncRepositories(repositoryURLs []string) ([]string, error)
+}
+
+type UpstreamSyncManager struct {
+	git              common.IGit
+	credentialReader common.CredentialReader
+	fileSystem       common.IFileSystem
+}
+
+func NewUpstreamSyncManager(git common.IGit, credentialReader common.CredentialReader, fileSystem common.IFileSystem) *UpstreamSyncManager {
+	return &UpstreamSyncManager{
+		git:              git,
+		credentialReader: credentialReader,
+		fileSystem:       fileSystem,
+	}
+}
+
+// Run fetches the upstream repositories of all projects in the given interval, until the context is cancelled
+func (m UpstreamSyncManager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.syncProjects(func(*common_models.GitCredentials) bool { return true }); err != nil {
+			logger.Errorf("Could not synchronize projects with their upstream repositories: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m UpstreamSyncManager) SyncRepositories(repositoryURLs []string) ([]string, error) {
+	normalizedURLs := map[string]bool{}
+	for _, repositoryURL := range repositoryURLs {
+		normalizedURLs[normalizeRepositoryURL(repositoryURL)] = true
+	}
+	return m.syncProjects(func(credentials *common_models.GitCredentials) bool {
+		return normalizedURLs[normalizeRepositoryURL(credentials.RemoteURL)]
+	})
+}
+
+// syncProjects fetches the upstream repository of all projects whose credentials match the filter and returns the names of
+// the synchronized projects. Projects that cannot be synchronized are skipped
+func (m UpstreamSyncManager) syncProjects(filter func(credentials *common_models.GitCredentials) bool) ([]string, error) {
+	projects, err := m.listProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	synced := []string{}
+	for _, project := range projects {
+		credentials, err := m.credentialReader.GetCredentials(project)
+		if err != nil {
+			logger.Debugf("Skipping synchronization of project %s: %v", project, err)
+			continue
+		}
+		if !filter(credentials) {
+			continue
+		}
+		if err := m.syncProject(common_models.GitContext{Project: project, Credentials: credentials}); err != nil {
+			logger.Errorf("Could not synchronize project %s with its upstream repository: %v", project, err)
+			continue
+		}
+		synced = append(synced, project)
+	}
+	return synced, nil
+}
+
+func (m UpstreamSyncManager) syncProject(gitContext common_models.GitContext) error {
+	if err := common.LockProject(gitContext.Project); err != nil {
+		return err
+	}
+	defer common.UnlockProject(gitContext.Project)
+
+	return m.git.Fetch(gitContext)
+}
+
+// listProjects returns the names of all projects with a local repository in the configuration directory
+func (m UpstreamSyncManager) listProjects() ([]string, error) {
+	configDir := common.GetConfigDir()
+	projects := []string{}
+	err := m.fileSystem.WalkPath(configDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == configDir || !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if !strings.HasPrefix(name, ".") && !strings.HasPrefix(name, tmpMigrationProjectPrefix) && m.git.ProjectRepoExists(name) {
+			projects = append(projects, name)
+		}
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// normalizeRepositoryURL removes the scheme, user information and the .git suffix from a repository URL, so that the HTTP and
+// SSH URLs of a repository can be compared, e.g., https://github.com/org/repo.git and git@github.com:org/repo both result in github.com/org/repo
+func normalizeRepositoryURL(repositoryURL string) string {
+	normalized := strings.ToLower(strings.TrimSpace(repositoryURL))
+	if i := strings.Index(normalized, "://"); i >= 0 {
+		normalized = normalized[i+3:]
+	} else if i := strings.Index(normalized, ":"); i >= 0 {
+		// scp-like syntax of SSH URLs, e.g. git@github.com:org/repo.git
+		normalized = normalized[:i] + "/" + normalized[i+1:]
+	}
+	if i := strings.Index(normalized, "@"); i >= 0 && i < strings.Index(normalized+"/", "/") {
+		normalized = normalized[i+1:]
+	}
+	normalized = strings.TrimSuffix(normalized, "/")
+	return strings.TrimSuffix(normalized, ".git")
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+const headerGitHubSignature = "X-Hub-Signature-256"
+const headerGiteaSignature = "X-Gitea-Signature"
+const headerGitLabToken = "X-Gitlab-Token"
+
+type IUpstreamWebhookHandler interface {
+	HandlePushEvent(context *gin.Context)
+}
+
+type UpstreamWebhookHandler struct {
+	UpstreamSyncManager IUpstreamSyncManager
+	secret              string
+}
+
+// NewUpstreamWebhookHandler creates a handler for push webhooks. Only requests signed with the secret are accepted, i.e., all requests
+// are rejected if the secret is empty
+func NewUpstreamWebhookHandler(upstreamSyncManager IUpstreamSyncManager, secret string) *UpstreamWebhookHandler {
+	return &UpstreamWebhookHandler{
+		UpstreamSyncManager: upstreamSyncManager,
+		secret:              secret,
+	}
+}
+
+// HandlePushEvent godoc
+// @Summary      Synchronizes projects with their upstream repository
+// @Description  Receives push webhooks of GitHub, GitLab and Gitea and fetches the upstream repository of all projects using the pushed repository
+// @Tags         Upstream
+// @Accept       json
+// @Produce      json
+// @Param        event  body      models.PushEvent  true  "Push webhook payload"
+// @Success      200    {object}  models.SyncUpstreamResponse
+// @Failure      400    {object}  models.Error  "Invalid payload"
+// @Failure      401    {object}  models.Error  "Invalid signature"
+// @Failure      500    {object}  models.Error  "Internal error"
+// @Router       /upstream/webhook [post]
+func (h *UpstreamWebhookHandler) HandlePushEvent(c *gin.Context) {
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	if h.secret == "" || !h.isSignatureValid(c.Request.Header, payload) {
+		SetUnauthorizedErrorResponse(c, errors.ErrInvalidWebhookSignature.Error())
+		return
+	}
+
+	event := &models.PushEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	if err := event.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	projects, err := h.UpstreamSyncManager.SyncRepositories(event.RepositoryURLs())
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SyncUpstreamResponse{Projects: projects})
+}
+
+// isSignatureValid checks the HMAC signature sent by GitHub and Gitea, or the secret token sent by GitLab
+func (h *UpstreamWebhookHandler) isSignatureValid(header http.Header, payload []byte) bool {
+	if token := header.Get(headerGitLabToken); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(h.secret)) == 1
+	}
+
+	signature := strings.TrimPrefix(header.Get(headerGitHubSignature), "sha256=")
+	if signature == "" {
+		signature = header.Get(headerGiteaSignature)
+	}
+	if signature == "" {
+		return false
+	}
+	decodedSignature, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(h.secret))
+	mac.Write(payload)
+	return hmac.Equal(decodedSignature, mac.Sum(nil))
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+const gitHubPushEventTestPayload = `{
+  "ref": "refs/heads/dev",
+  "repository": {
+    "clone_url": "https://github.com/keptn/my-project.git",
+    "ssh_url": "git@github.com:keptn/my-project.git",
+    "html_url": "https://github.com/keptn/my-project"
+  }
+}`
+
+const gitLabPushEventTestPayload = `{
+  "object_kind": "push",
+  "project": {
+    "git_http_url": "https://gitlab.com/keptn/my-project.git",
+    "git_ssh_url": "git@gitlab.com:keptn/my-project.git",
+    "web_url": "https://gitlab.com/keptn/my-project"
+  }
+}`
+
+const testWebhookSecret = "my-secret"
+
+func signPayload(payload string) string {
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newPushEventRequest(payload string, header map[string]string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/upstream/webhook", bytes.NewBufferString(payload))
+	for key, value := range header {
+		request.Header.Set(key, value)
+	}
+	return request
+}
+
+func TestUpstreamWebhookHandler_HandlePushEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		syncErr    error
+		request    *http.Request
+		wantURLs   []string
+		wantStatus int
+	}{
+		{
+			name:       "secret not configured",
+			request:    newPushEventRequest(gitHubPushEventTestPayload, map[string]string{"X-Hub-Signature-256": "sha256=" + signPayload(gitHubPushEventTestPayload)}),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "empty GitLab token and secret not configured",
+			request:    newPushEventRequest(gitLabPushEventTestPayload, map[string]string{"X-Gitlab-Token": ""}),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "GitHub push event with valid signature",
+			secret:     testWebhookSecret,
+			request:    newPushEventRequest(gitHubPushEventTestPayload, map[string]string{"X-Hub-Signature-256": "sha256=" + signPayload(gitHubPushEventTestPayload)}),
+			wantURLs:   []string{"https://github.com/keptn/my-project.git", "git@github.com:keptn/my-project.git", "https://github.com/keptn/my-project"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Gitea push event with valid signature",
+			secret:     testWebhookSecret,
+			request:    newPushEventRequest(gitHubPushEventTestPayload, map[string]string{"X-Gitea-Signature": signPayload(gitHubPushEventTestPayload)}),
+			wantURLs:   []string{"https://github.com/keptn/my-project.git", "git@github.com:keptn/my-project.git", "https://github.com/keptn/my-project"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "GitLab push event with valid token",
+			secret:     testWebhookSecret,
+			request:    newPushEventRequest(gitLabPushEventTestPayload, map[string]string{"X-Gitlab-Token": testWebhookSecret}),
+			wantURLs:   []string{"https://gitlab.com/keptn/my-project.git", "git@gitlab.com:keptn/my-project.git", "https://gitlab.com/keptn/my-project"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid signature",
+			secret:     testWebhookSecret,
+			request:    newPushEventRequest(gitHubPushEventTestPayload, map[string]string{"X-Hub-Signature-256": "sha256=" + signPayload("other-payload")}),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid GitLab token",
+			secret:     testWebhookSecret,
+			request:    newPushEventRequest(gitLabPushEventTestPayload, map[string]string{"X-Gitlab-Token": "other-secret"}),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "signature missing",
+			secret:     testWebhookSecret,
+			request:    newPushEventRequest(gitHubPushEventTestPayload, nil),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid payload",
+			secret:     testWebhookSecret,
+			request:    newPushEventRequest("invalid", map[string]string{"X-Gitlab-Token": testWebhookSecret}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "repository missing",
+			secret:     testWebhookSecret,
+			request:    newPushEventRequest(`{"ref": "refs/heads/dev"}`, map[string]string{"X-Gitlab-Token": testWebhookSecret}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "internal error",
+			secret:     testWebhookSecret,
+			syncErr:    errors.New("oops"),
+			request:    newPushEventRequest(gitHubPushEventTestPayload, map[string]string{"X-Hub-Signature-256": "sha256=" + signPayload(gitHubPushEventTestPayload)}),
+			wantURLs:   []string{"https://github.com/keptn/my-project.git", "git@github.com:keptn/my-project.git", "https://github.com/keptn/my-project"},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncManager := &handler_mock.IUpstreamSyncManagerMock{
+				SyncRepositoriesFunc: func(repositoryURLs []string) ([]string, error) {
+					if tt.syncErr != nil {
+						return nil, tt.syncErr
+					}
+					return []string{"my-project"}, nil
+				},
+			}
+			h := NewUpstreamWebhookHandler(syncManager, tt.secret)
+
+			router := gin.Default()
+			router.POST("/upstream/webhook", h.HandlePushEvent)
+
+			resp := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+			if tt.wantURLs == nil {
+				require.Empty(t, syncManager.SyncRepositoriesCalls())
+				return
+			}
+			require.Len(t, syncManager.SyncRepositoriesCalls(), 1)
+			require.Equal(t, tt.wantURLs, syncManager.SyncRepositoriesCalls()[0].RepositoryURLs)
+
+			if tt.wantStatus == http.StatusOK {
+				result := &models.SyncUpstreamResponse{}
+				require.Nil(t, json.Unmarshal(resp.Body.Bytes(), result))
+				require.Equal(t, []string{"my-project"}, result.Projects)
+			}
+		})
+	}
+}
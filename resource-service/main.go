@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"sync"
 	"syscall"
 
@@ -82,12 +84,22 @@ func main() {
 		log.Fatalf("could not create kubernetes client: %s", err.Error())
 	}
 
+	if err := setupProjectLocking(); err != nil {
+		log.Fatal(err)
+	}
+
 	credentialReader := common.NewK8sCredentialReader(kubeAPI)
 	fileSystem := common.NewFileSystem(common.GetConfigDir())
 
 	git := common.NewGit(&common.GogitReal{})
+	git.SetSigningKeyReader(common.NewK8sSigningKeyReader(kubeAPI))
+	git.SetLFS(common.NewLFSClient(), config.Global.LFSThreshold)
 	configurationContext := createConfigurationContext(git, fileSystem)
 
+	if err := setupUpstreamSync(ctx, apiV1, git, credentialReader, fileSystem); err != nil {
+		log.Fatal(err)
+	}
+
 	projectManager := handler.NewProjectManager(git, credentialReader, fileSystem)
 	projectHandler := handler.NewProjectHandler(projectManager)
 	projectController := controller.NewProjectController(projectHandler)
@@ -103,17 +115,23 @@ func main() {
 	serviceController := controller.NewServiceController(serviceHandler)
 	serviceController.Inject(apiV1)
 
+	reviewConfigReader := common.NewK8sReviewConfigReader(kubeAPI)
+	changeRequestProviders := common.NewChangeRequestProviders()
+
 	projectResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	projectResourceManager.SetReview(reviewConfigReader, changeRequestProviders)
 	projectResourceHandler := handler.NewProjectResourceHandler(projectResourceManager)
 	projectResourceController := controller.NewProjectResourceController(projectResourceHandler)
 	projectResourceController.Inject(apiV1)
 
 	stageResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	stageResourceManager.SetReview(reviewConfigReader, changeRequestProviders)
 	stageResourceHandler := handler.NewStageResourceHandler(stageResourceManager)
 	stageResourceController := controller.NewStageResourceController(stageResourceHandler)
 	stageResourceController.Inject(apiV1)
 
 	serviceResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	serviceResourceManager.SetReview(reviewConfigReader, changeRequestProviders)
 	serviceResourceHandler := handler.NewServiceResourceHandler(serviceResourceManager)
 	serviceResourceController := controller.NewServiceResourceController(serviceResourceHandler)
 	serviceResourceController.Inject(apiV1)
@@ -160,6 +178,50 @@ func createStageManager(configurationContext handler.IConfigurationContext, git
 	return stageManager
 }
 
+func setupProjectLocking() error {
+	switch config.Global.ProjectLocking {
+	case config.ProjectLockingLocal:
+		return nil
+	case config.ProjectLockingFile:
+		lockDir := filepath.Join(common.GetConfigDir(), ".locks")
+		log.Infof("Projects are locked using lock files in %s", lockDir)
+		common.SetProjectLocker(common.NewFileProjectLocker(lockDir))
+		return nil
+	}
+	return fmt.Errorf("unsupported value '%s' of 'PROJECT_LOCKING' env var, must be one of '%s', '%s'", config.Global.ProjectLocking, config.ProjectLockingLocal, config.ProjectLockingFile)
+}
+
+// setupUpstreamSync enables the upstream mirror if configured. In this case, the local repositories are synchronized with their
+// upstream in the background. The webhook endpoint for synchronizing projects on push events is only registered if a webhook secret is set
+func setupUpstreamSync(ctx context.Context, apiGroup *gin.RouterGroup, git *common.Git, credentialReader common.CredentialReader, fileSystem common.IFileSystem) error {
+	switch config.Global.UpstreamSync {
+	case config.UpstreamSyncPull:
+		return nil
+	case config.UpstreamSyncMirror:
+		if config.Global.UpstreamSyncInterval <= 0 || config.Global.UpstreamMaxStaleness <= 0 {
+			return fmt.Errorf("'UPSTREAM_SYNC_INTERVAL' and 'UPSTREAM_MAX_STALENESS' env vars must be positive durations")
+		}
+		if config.Global.UpstreamSyncInterval >= config.Global.UpstreamMaxStaleness {
+			log.Warnf("Upstream sync interval %s is not shorter than the max staleness %s, read requests will contact the upstream regularly", config.Global.UpstreamSyncInterval, config.Global.UpstreamMaxStaleness)
+		}
+		log.Infof("Serving read requests from the local repositories, which are synchronized with their upstream every %s", config.Global.UpstreamSyncInterval)
+		git.SetUpstreamMirror(common.NewUpstreamMirror(config.Global.UpstreamMaxStaleness))
+
+		upstreamSyncManager := handler.NewUpstreamSyncManager(git, credentialReader, fileSystem)
+		go upstreamSyncManager.Run(ctx, config.Global.UpstreamSyncInterval)
+
+		if config.Global.UpstreamWebhookSecret == "" {
+			log.Info("'UPSTREAM_WEBHOOK_SECRET' env var is not set, the webhook endpoint for push events is disabled")
+			return nil
+		}
+		upstreamWebhookHandler := handler.NewUpstreamWebhookHandler(upstreamSyncManager, config.Global.UpstreamWebhookSecret)
+		upstreamWebhookController := controller.NewUpstreamWebhookController(upstreamWebhookHandler)
+		upstreamWebhookController.Inject(apiGroup)
+		return nil
+	}
+	return fmt.Errorf("unsupported value '%s' of 'UPSTREAM_SYNC' env var, must be one of '%s', '%s'", config.Global.UpstreamSync, config.UpstreamSyncPull, config.UpstreamSyncMirror)
+}
+
 func gracefulShutdown(ctx context.Context, wg *sync.WaitGroup, srv *http.Server) {
 	quit := make(chan os.Signal, 1)
 
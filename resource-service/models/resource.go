@@ -2,8 +2,13 @@ package models
 
 import (
 	"encoding/base64"
-	"github.com/keptn/keptn/resource-service/errors"
+	"io"
+	"mime/multipart"
+	"net/mail"
 	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/errors"
 )
 
 type ResourceContent string
@@ -82,6 +87,8 @@ func (p GetResourcesParams) Validate() error {
 
 type GetResourceQuery struct {
 	GitCommitID string `json:"gitCommitID,omitEmpty" form:"gitCommitID"`
+	// Render applies the values of the stage to the resource, which is a Go template. The stored resource is not changed
+	Render bool `json:"render,omitempty" form:"render"`
 }
 
 type GetResourceParams struct {
@@ -100,9 +107,36 @@ func (p GetResourceParams) Validate() error {
 	return nil
 }
 
+// CommitInfo contains the optional message and author of the commit created by a request modifying resources. Only the message
+// is provided by clients, the author is taken from the headers set by the api-gateway for the authenticated caller
+type CommitInfo struct {
+	// Message of the commit. If not set, a message describing the change is used
+	CommitMessage string `json:"commitMessage,omitempty" form:"commitMessage"`
+
+	// Name of the author of the commit. If not set, the keptn user is used as author
+	AuthorName string `json:"-" form:"-"`
+
+	// Email address of the author of the commit
+	AuthorEmail string `json:"-" form:"-"`
+}
+
+func (ci CommitInfo) Validate() error {
+	if ci.AuthorName == "" && ci.AuthorEmail == "" {
+		return nil
+	}
+	if strings.TrimSpace(ci.AuthorName) == "" || strings.ContainsAny(ci.AuthorName, "<>\n") {
+		return errors.ErrInvalidCommitAuthor
+	}
+	if address, err := mail.ParseAddress(ci.AuthorEmail); err != nil || address.Address != ci.AuthorEmail {
+		return errors.ErrInvalidCommitAuthor
+	}
+	return nil
+}
+
 type DeleteResourceParams struct {
 	ResourceContext
 	ResourceURI string
+	CommitInfo
 }
 
 func (p DeleteResourceParams) Validate() error {
@@ -112,6 +146,9 @@ func (p DeleteResourceParams) Validate() error {
 	if err := validateResourceURI(p.ResourceURI); err != nil {
 		return err
 	}
+	if err := p.CommitInfo.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -122,6 +159,7 @@ type CreateResourceParams struct {
 
 type UpdateResourcePayload struct {
 	ResourceContent ResourceContent `json:"resourceContent"`
+	CommitInfo
 }
 
 type UpdateResourceParams struct {
@@ -140,11 +178,15 @@ func (p UpdateResourceParams) Validate() error {
 	if err := p.ResourceContent.Validate(); err != nil {
 		return err
 	}
+	if err := p.CommitInfo.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
 type CreateResourcesPayload struct {
 	Resources []Resource `json:"resources"`
+	CommitInfo
 }
 
 type CreateResourcesParams struct {
@@ -161,11 +203,15 @@ func (p CreateResourcesParams) Validate() error {
 			return err
 		}
 	}
+	if err := p.CommitInfo.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
 type UpdateResourcesPayload struct {
 	Resources []Resource `json:"resources"`
+	CommitInfo
 }
 
 type UpdateResourcesParams struct {
@@ -183,6 +229,9 @@ func (p UpdateResourcesParams) Validate() error {
 			return err
 		}
 	}
+	if err := p.CommitInfo.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -210,11 +259,240 @@ type GetResourcesResponse struct {
 type GetResourceResponse struct {
 	Resource
 	Metadata Version `json:"metadata"`
+
+	// Size of the resource content in bytes
+	Size int64 `json:"size"`
+
+	// Checksum of the resource content, e.g. sha256:<hex>
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// GetResourceContentResponse contains the raw content of a resource, which is streamed to the client
+type GetResourceContentResponse struct {
+	Content  io.ReadCloser
+	Size     int64
+	Checksum string
+	Metadata Version
+}
+
+// UploadResourcesParams contains the parts of a multipart upload of resources. The name of each form field is the URI of
+// the resource and its file is the content of the resource
+type UploadResourcesParams struct {
+	ResourceContext
+	CommitInfo
+	Parts *multipart.Reader
+}
+
+func (p UploadResourcesParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := p.CommitInfo.Validate(); err != nil {
+		return err
+	}
+	if p.Parts == nil {
+		return errors.ErrInvalidUpload
+	}
+	return nil
 }
 
 type WriteResourceResponse struct {
 	CommitID string  `json:"commitID"`
 	Metadata Version `json:"metadata"`
+
+	// ChangeRequest is set if the changes have been proposed as change request instead of being pushed to the upstream directly.
+	// In this case, the commit is only contained in the source branch of the change request
+	ChangeRequest *ChangeRequest `json:"changeRequest,omitempty"`
+}
+
+// ChangeRequest is a pull request or merge request opened on the upstream repository for reviewing changes
+//
+// swagger:model ChangeRequest
+type ChangeRequest struct {
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	SourceBranch string `json:"sourceBranch"`
+	TargetBranch string `json:"targetBranch"`
+}
+
+type GetResourceHistoryQuery struct {
+	NextPageKey string `json:"nextPageKey,omitempty" form:"nextPageKey"`
+	PageSize    int64  `json:"pageSize,omitempty" form:"pageSize"`
+}
+
+type GetResourceHistoryParams struct {
+	ResourceContext
+	ResourceURI string
+	GetResourceHistoryQuery
+}
+
+func (p GetResourceHistoryParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ResourceCommit is a commit that changed a resource
+//
+// swagger:model ResourceCommit
+type ResourceCommit struct {
+	CommitID    string    `json:"commitID"`
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"authorEmail,omitempty"`
+	Message     string    `json:"message"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// GetResourceHistoryResponse contains the commits that changed a resource, starting with the most recent one
+//
+// swagger:model GetResourceHistoryResponse
+type GetResourceHistoryResponse struct {
+
+	// Pointer to next page, base64 encoded
+	NextPageKey string `json:"nextPageKey,omitempty"`
+
+	// Size of returned page
+	PageSize float64 `json:"pageSize,omitempty"`
+
+	// commits
+	Commits []ResourceCommit `json:"commits"`
+
+	// Total number of commits
+	TotalCount float64 `json:"totalCount,omitempty"`
+}
+
+type GetResourceDiffQuery struct {
+	From string `json:"from" form:"from"`
+	To   string `json:"to,omitempty" form:"to"`
+}
+
+type GetResourceDiffParams struct {
+	ResourceContext
+	ResourceURI string
+	GetResourceDiffQuery
+}
+
+func (p GetResourceDiffParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.From == "" {
+		return errors.ErrRevisionMustNotBeEmpty
+	}
+	return nil
+}
+
+// GetResourceDiffResponse contains the changes of a resource between two revisions in the unified diff format
+//
+// swagger:model GetResourceDiffResponse
+type GetResourceDiffResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Diff is empty if the resource has not been changed between the two revisions
+	Diff string `json:"diff"`
+}
+
+type RevertResourceQuery struct {
+	CommitID string `json:"commitID" form:"commitID"`
+	CommitInfo
+}
+
+type RevertResourceParams struct {
+	ResourceContext
+	ResourceURI string
+	RevertResourceQuery
+}
+
+func (p RevertResourceParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.CommitID == "" {
+		return errors.ErrRevisionMustNotBeEmpty
+	}
+	if err := p.CommitInfo.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+type PromoteResourcesPayload struct {
+	// TargetStage is the stage the resources are promoted to
+	TargetStage string `json:"targetStage"`
+	// ResourceURIs are the resources to be promoted. If empty, all resources of the service are promoted and the resources
+	// that only exist in the target stage are removed
+	ResourceURIs []string `json:"resourceURIs,omitempty"`
+	CommitInfo
+}
+
+type PromoteResourcesParams struct {
+	ResourceContext
+	PromoteResourcesPayload
+}
+
+func (p PromoteResourcesParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateEntityName(p.TargetStage); err != nil {
+		return err
+	}
+	if p.Stage != nil && p.Stage.StageName == p.TargetStage {
+		return errors.ErrPromotionTargetStageInvalid
+	}
+	for _, uri := range p.ResourceURIs {
+		if err := validateResourceURI(uri); err != nil {
+			return err
+		}
+	}
+	if err := p.CommitInfo.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+type ExportResourcesParams struct {
+	Project
+}
+
+func (p ExportResourcesParams) Validate() error {
+	return p.Project.Validate()
+}
+
+type ImportResourcesParams struct {
+	Project
+	// Archive is the tar.gz archive containing the resources, as created by the export of a project
+	Archive []byte
+	// CommitInfo applies to all commits created by the import
+	CommitInfo
+}
+
+func (p ImportResourcesParams) Validate() error {
+	if err := p.Project.Validate(); err != nil {
+		return err
+	}
+	if len(p.Archive) == 0 {
+		return errors.ErrInvalidArchive
+	}
+	if err := p.CommitInfo.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+type ImportResourcesResponse struct {
+	// Commits are the commits created by the import, one for the project and one for each stage contained in the archive
+	Commits []WriteResourceResponse `json:"commits"`
 }
 
 func validateResourceURI(uri string) error {
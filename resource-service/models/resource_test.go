@@ -386,6 +386,7 @@ func TestDeleteResourceParams_Validate(t *testing.T) {
 	type fields struct {
 		ResourceContext ResourceContext
 		ResourceURI     string
+		CommitInfo      CommitInfo
 	}
 	tests := []struct {
 		name    string
@@ -428,12 +429,24 @@ func TestDeleteResourceParams_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid commit author",
+			fields: fields{
+				ResourceContext: ResourceContext{
+					Project: Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.txt",
+				CommitInfo:  CommitInfo{AuthorName: "Jane Doe"},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := DeleteResourceParams{
 				ResourceContext: tt.fields.ResourceContext,
 				ResourceURI:     tt.fields.ResourceURI,
+				CommitInfo:      tt.fields.CommitInfo,
 			}
 			if err := p.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -597,3 +610,173 @@ func TestResource_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPromoteResourcesParams_Validate(t *testing.T) {
+	type fields struct {
+		ResourceContext         ResourceContext
+		PromoteResourcesPayload PromoteResourcesPayload
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			fields: fields{
+				ResourceContext: ResourceContext{
+					Project: Project{ProjectName: "my-project"},
+					Stage:   &Stage{StageName: "my-stage"},
+					Service: &Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesPayload: PromoteResourcesPayload{
+					TargetStage:  "my-target-stage",
+					ResourceURIs: []string{"helm/my-service.tgz", "slo.yaml"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "target stage missing",
+			fields: fields{
+				ResourceContext: ResourceContext{
+					Project: Project{ProjectName: "my-project"},
+					Stage:   &Stage{StageName: "my-stage"},
+					Service: &Service{ServiceName: "my-service"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "target stage equals stage",
+			fields: fields{
+				ResourceContext: ResourceContext{
+					Project: Project{ProjectName: "my-project"},
+					Stage:   &Stage{StageName: "my-stage"},
+					Service: &Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesPayload: PromoteResourcesPayload{
+					TargetStage: "my-stage",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid resource uri",
+			fields: fields{
+				ResourceContext: ResourceContext{
+					Project: Project{ProjectName: "my-project"},
+					Stage:   &Stage{StageName: "my-stage"},
+					Service: &Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesPayload: PromoteResourcesPayload{
+					TargetStage:  "my-target-stage",
+					ResourceURIs: []string{"../slo.yaml"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := PromoteResourcesParams{
+				ResourceContext:         tt.fields.ResourceContext,
+				PromoteResourcesPayload: tt.fields.PromoteResourcesPayload,
+			}
+			if err := p.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImportResourcesParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  ImportResourcesParams
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			params: ImportResourcesParams{
+				Project: Project{ProjectName: "my-project"},
+				Archive: []byte("my-archive"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "archive missing",
+			params: ImportResourcesParams{
+				Project: Project{ProjectName: "my-project"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "project name missing",
+			params: ImportResourcesParams{
+				Archive: []byte("my-archive"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommitInfo_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		commitInfo CommitInfo
+		wantErr    bool
+	}{
+		{
+			name:       "empty",
+			commitInfo: CommitInfo{},
+		},
+		{
+			name:       "commit message only",
+			commitInfo: CommitInfo{CommitMessage: "my message"},
+		},
+		{
+			name:       "author",
+			commitInfo: CommitInfo{CommitMessage: "my message", AuthorName: "Jane Doe", AuthorEmail: "jane.doe@example.com"},
+		},
+		{
+			name:       "author name without email",
+			commitInfo: CommitInfo{AuthorName: "Jane Doe"},
+			wantErr:    true,
+		},
+		{
+			name:       "author email without name",
+			commitInfo: CommitInfo{AuthorEmail: "jane.doe@example.com"},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid author email",
+			commitInfo: CommitInfo{AuthorName: "Jane Doe", AuthorEmail: "jane.doe"},
+			wantErr:    true,
+		},
+		{
+			name:       "author email with display name",
+			commitInfo: CommitInfo{AuthorName: "Jane Doe", AuthorEmail: "Jane <jane.doe@example.com>"},
+			wantErr:    true,
+		},
+		{
+			name:       "author name with angle brackets",
+			commitInfo: CommitInfo{AuthorName: "Jane <Doe>", AuthorEmail: "jane.doe@example.com"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.commitInfo.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import "github.com/keptn/keptn/resource-service/errors"
+
+// PushEventRepository contains the URLs of a repository, as sent by GitHub, GitLab and Gitea in the payload of push webhooks
+type PushEventRepository struct {
+	// CloneURL is the HTTP clone URL sent by GitHub and Gitea
+	CloneURL string `json:"clone_url,omitempty"`
+	// SSHURL is the SSH clone URL sent by GitHub and Gitea
+	SSHURL string `json:"ssh_url,omitempty"`
+	// HTMLURL is the URL of the repository website sent by GitHub and Gitea
+	HTMLURL string `json:"html_url,omitempty"`
+	// GitHTTPURL is the HTTP clone URL sent by GitLab
+	GitHTTPURL string `json:"git_http_url,omitempty"`
+	// GitSSHURL is the SSH clone URL sent by GitLab
+	GitSSHURL string `json:"git_ssh_url,omitempty"`
+	// WebURL is the URL of the project website sent by GitLab
+	WebURL string `json:"web_url,omitempty"`
+}
+
+// PushEvent is the payload of a push webhook of a git provider
+//
+// swagger:model PushEvent
+type PushEvent struct {
+	Repository PushEventRepository `json:"repository"`
+	// Project contains the repository URLs in payloads sent by GitLab
+	Project PushEventRepository `json:"project"`
+}
+
+// RepositoryURLs returns all repository URLs contained in the payload
+func (e PushEvent) RepositoryURLs() []string {
+	urls := []string{}
+	for _, repository := range []PushEventRepository{e.Repository, e.Project} {
+		for _, url := range []string{repository.CloneURL, repository.SSHURL, repository.HTMLURL, repository.GitHTTPURL, repository.GitSSHURL, repository.WebURL} {
+			if url != "" {
+				urls = append(urls, url)
+			}
+		}
+	}
+	return urls
+}
+
+func (e PushEvent) Validate() error {
+	if len(e.RepositoryURLs()) == 0 {
+		return errors.ErrPushEventRepositoryMissing
+	}
+	return nil
+}
+
+// SyncUpstreamResponse contains the projects that have been synchronized with their upstream repository
+//
+// swagger:model SyncUpstreamResponse
+type SyncUpstreamResponse struct {
+	Projects []string `json:"projects"`
+}
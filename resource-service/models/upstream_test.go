@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushEvent_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    PushEvent
+		wantURLs []string
+		wantErr  bool
+	}{
+		{
+			name: "GitHub push event",
+			event: PushEvent{
+				Repository: PushEventRepository{CloneURL: "https://github.com/keptn/my-project.git", SSHURL: "git@github.com:keptn/my-project.git"},
+			},
+			wantURLs: []string{"https://github.com/keptn/my-project.git", "git@github.com:keptn/my-project.git"},
+		},
+		{
+			name: "GitLab push event",
+			event: PushEvent{
+				Project: PushEventRepository{GitHTTPURL: "https://gitlab.com/keptn/my-project.git", WebURL: "https://gitlab.com/keptn/my-project"},
+			},
+			wantURLs: []string{"https://gitlab.com/keptn/my-project.git", "https://gitlab.com/keptn/my-project"},
+		},
+		{
+			name:     "repository missing",
+			event:    PushEvent{},
+			wantURLs: []string{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantURLs, tt.event.RepositoryURLs())
+			if err := tt.event.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
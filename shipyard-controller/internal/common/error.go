@@ -25,6 +25,8 @@ var ErrInvalidStageChange = errors.New("stage name cannot be changed or removed"
 
 var ErrStageNotFound = errors.New("stage not found")
 
+var ErrStageHasActiveSequences = errors.New("stage has active sequences")
+
 var ErrChangesRollback = errors.New("failed to rollback changes")
 
 var ErrSequencePaused = errors.New("sequence is paused")
@@ -1,9 +1,13 @@
 package configurationstore
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/keptn/keptn/shipyard-controller/internal/common"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
@@ -12,6 +16,7 @@ import (
 
 const configServiceSvcDoesNotExistErrorMsg = "service does not exists" // [sic] this is what we get from the configuration service
 const resourceServiceSvcDoesNotExistErrorMsg = "service not found"
+const resourceServiceStageDoesNotExistErrorMsg = "stage not found"
 
 //go:generate moq -pkg common_mock -out ./fake/configurationstore_mock.go . ConfigurationStore
 type ConfigurationStore interface {
@@ -21,6 +26,7 @@ type ConfigurationStore interface {
 	UpdateProjectResource(projectName string, resource *apimodels.Resource) error
 	DeleteProject(projectName string) error
 	CreateStage(projectName string, stage string) error
+	DeleteStage(projectName string, stageName string) error
 	CreateService(projectName string, stageName string, serviceName string) error
 	GetProjectResource(projectName string, resourceURI string) (*apimodels.Resource, error)
 	GetStageResource(projectName, stageName, resourceURI string) (*apimodels.Resource, error)
@@ -32,6 +38,8 @@ type GitConfigurationStore struct {
 	stagesAPI   *keptnapi.StageHandler
 	servicesAPI *keptnapi.ServiceHandler
 	resourceAPI *keptnapi.ResourceHandler
+	endpoint    string
+	httpClient  *http.Client
 }
 
 func New(configurationServiceEndpoint string) *GitConfigurationStore {
@@ -40,6 +48,8 @@ func New(configurationServiceEndpoint string) *GitConfigurationStore {
 		stagesAPI:   keptnapi.NewStageHandler(configurationServiceEndpoint),
 		servicesAPI: keptnapi.NewServiceHandler(configurationServiceEndpoint),
 		resourceAPI: keptnapi.NewResourceHandler(configurationServiceEndpoint),
+		endpoint:    strings.TrimSuffix(configurationServiceEndpoint, "/"),
+		httpClient:  &http.Client{},
 	}
 }
 
@@ -97,6 +107,39 @@ func (g GitConfigurationStore) CreateStage(projectName string, stageName string)
 	return nil
 }
 
+// DeleteStage deletes the given stage from the git repository of the project.
+// The keptn API utils do not provide a stage deletion, therefore the request is sent to the resource-service directly
+func (g GitConfigurationStore) DeleteStage(projectName string, stageName string) error {
+	stageURL := fmt.Sprintf("%s/v1/project/%s/stage/%s", g.endpoint, url.PathEscape(projectName), url.PathEscape(stageName))
+	req, err := http.NewRequest(http.MethodDelete, stageURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	apiErr := apimodels.Error{Code: int64(resp.StatusCode)}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err == nil {
+		_ = json.Unmarshal(body, &apiErr)
+		apiErr.Code = int64(resp.StatusCode)
+	}
+	if apiErr.Message == nil {
+		apiErr.Message = common.Stringp(http.StatusText(resp.StatusCode))
+	}
+	if resp.StatusCode == http.StatusNotFound && strings.Contains(strings.ToLower(*apiErr.Message), resourceServiceStageDoesNotExistErrorMsg) {
+		return common.ErrStageNotFound
+	}
+	return g.buildErrResponse(&apiErr)
+}
+
 func (g GitConfigurationStore) CreateService(projectName string, stageName string, serviceName string) error {
 	if _, err := g.servicesAPI.CreateServiceInStage(projectName, stageName, serviceName); err != nil {
 		return g.buildErrResponse(err)
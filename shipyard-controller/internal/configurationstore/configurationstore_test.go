@@ -3,6 +3,7 @@ package configurationstore
 import (
 	"encoding/json"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/internal/common"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"net/http"
@@ -104,6 +105,42 @@ func TestConfigurationStore(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 
+	t.Run("TestDeleteStage_Success", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/v1/project/my-project/stage/my-stage", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+
+		instance := New(ts.URL)
+		err := instance.DeleteStage("my-project", "my-stage")
+		assert.Nil(t, err)
+	})
+
+	t.Run("TestDeleteStage_APIReturnsStageNotFound", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"message":"Stage not found"}`))
+		}))
+		defer ts.Close()
+
+		instance := New(ts.URL)
+		err := instance.DeleteStage("my-project", "my-stage")
+		assert.ErrorIs(t, err, common.ErrStageNotFound)
+	})
+
+	t.Run("TestDeleteStage_APIReturnsInternalServerError", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		instance := New(ts.URL)
+		err := instance.DeleteStage("my-project", "my-stage")
+		assert.NotNil(t, err)
+	})
+
 	t.Run("TestCreateService_Success", func(t *testing.T) {
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		}))
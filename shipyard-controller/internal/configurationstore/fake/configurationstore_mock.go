@@ -37,6 +37,9 @@ var _ configurationstore.ConfigurationStore = &ConfigurationStoreMock{}
 // 			DeleteServiceFunc: func(projectName string, stageName string, serviceName string) error {
 // 				panic("mock out the DeleteService method")
 // 			},
+// 			DeleteStageFunc: func(projectName string, stageName string) error {
+// 				panic("mock out the DeleteStage method")
+// 			},
 // 			GetProjectResourceFunc: func(projectName string, resourceURI string) (*apimodels.Resource, error) {
 // 				panic("mock out the GetProjectResource method")
 // 			},
@@ -74,6 +77,9 @@ type ConfigurationStoreMock struct {
 	// DeleteServiceFunc mocks the DeleteService method.
 	DeleteServiceFunc func(projectName string, stageName string, serviceName string) error
 
+	// DeleteStageFunc mocks the DeleteStage method.
+	DeleteStageFunc func(projectName string, stageName string) error
+
 	// GetProjectResourceFunc mocks the GetProjectResource method.
 	GetProjectResourceFunc func(projectName string, resourceURI string) (*apimodels.Resource, error)
 
@@ -130,6 +136,13 @@ type ConfigurationStoreMock struct {
 			// ServiceName is the serviceName argument value.
 			ServiceName string
 		}
+		// DeleteStage holds details about calls to the DeleteStage method.
+		DeleteStage []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// StageName is the stageName argument value.
+			StageName string
+		}
 		// GetProjectResource holds details about calls to the GetProjectResource method.
 		GetProjectResource []struct {
 			// ProjectName is the projectName argument value.
@@ -165,6 +178,7 @@ type ConfigurationStoreMock struct {
 	lockCreateStage           sync.RWMutex
 	lockDeleteProject         sync.RWMutex
 	lockDeleteService         sync.RWMutex
+	lockDeleteStage           sync.RWMutex
 	lockGetProjectResource    sync.RWMutex
 	lockGetStageResource      sync.RWMutex
 	lockUpdateProject         sync.RWMutex
@@ -381,6 +395,41 @@ func (mock *ConfigurationStoreMock) DeleteServiceCalls() []struct {
 	return calls
 }
 
+// DeleteStage calls DeleteStageFunc.
+func (mock *ConfigurationStoreMock) DeleteStage(projectName string, stageName string) error {
+	if mock.DeleteStageFunc == nil {
+		panic("ConfigurationStoreMock.DeleteStageFunc: method is nil but ConfigurationStore.DeleteStage was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		StageName   string
+	}{
+		ProjectName: projectName,
+		StageName:   stageName,
+	}
+	mock.lockDeleteStage.Lock()
+	mock.calls.DeleteStage = append(mock.calls.DeleteStage, callInfo)
+	mock.lockDeleteStage.Unlock()
+	return mock.DeleteStageFunc(projectName, stageName)
+}
+
+// DeleteStageCalls gets all the calls that were made to DeleteStage.
+// Check the length with:
+//     len(mockedConfigurationStore.DeleteStageCalls())
+func (mock *ConfigurationStoreMock) DeleteStageCalls() []struct {
+	ProjectName string
+	StageName   string
+} {
+	var calls []struct {
+		ProjectName string
+		StageName   string
+	}
+	mock.lockDeleteStage.RLock()
+	calls = mock.calls.DeleteStage
+	mock.lockDeleteStage.RUnlock()
+	return calls
+}
+
 // GetProjectResource calls GetProjectResourceFunc.
 func (mock *ConfigurationStoreMock) GetProjectResource(projectName string, resourceURI string) (*apimodels.Resource, error) {
 	if mock.GetProjectResourceFunc == nil {
@@ -14,6 +14,9 @@ import (
 //
 // 		// make and configure a mocked handler.IStageManager
 // 		mockedIStageManager := &IStageManagerMock{
+// 			DeleteStageFunc: func(projectName string, stageName string) error {
+// 				panic("mock out the DeleteStage method")
+// 			},
 // 			GetAllStagesFunc: func(projectName string) ([]*apimodels.ExpandedStage, error) {
 // 				panic("mock out the GetAllStages method")
 // 			},
@@ -27,6 +30,9 @@ import (
 //
 // 	}
 type IStageManagerMock struct {
+	// DeleteStageFunc mocks the DeleteStage method.
+	DeleteStageFunc func(projectName string, stageName string) error
+
 	// GetAllStagesFunc mocks the GetAllStages method.
 	GetAllStagesFunc func(projectName string) ([]*apimodels.ExpandedStage, error)
 
@@ -35,6 +41,13 @@ type IStageManagerMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// DeleteStage holds details about calls to the DeleteStage method.
+		DeleteStage []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// StageName is the stageName argument value.
+			StageName string
+		}
 		// GetAllStages holds details about calls to the GetAllStages method.
 		GetAllStages []struct {
 			// ProjectName is the projectName argument value.
@@ -48,10 +61,46 @@ type IStageManagerMock struct {
 			StageName string
 		}
 	}
+	lockDeleteStage  sync.RWMutex
 	lockGetAllStages sync.RWMutex
 	lockGetStage     sync.RWMutex
 }
 
+// DeleteStage calls DeleteStageFunc.
+func (mock *IStageManagerMock) DeleteStage(projectName string, stageName string) error {
+	if mock.DeleteStageFunc == nil {
+		panic("IStageManagerMock.DeleteStageFunc: method is nil but IStageManager.DeleteStage was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		StageName   string
+	}{
+		ProjectName: projectName,
+		StageName:   stageName,
+	}
+	mock.lockDeleteStage.Lock()
+	mock.calls.DeleteStage = append(mock.calls.DeleteStage, callInfo)
+	mock.lockDeleteStage.Unlock()
+	return mock.DeleteStageFunc(projectName, stageName)
+}
+
+// DeleteStageCalls gets all the calls that were made to DeleteStage.
+// Check the length with:
+//     len(mockedIStageManager.DeleteStageCalls())
+func (mock *IStageManagerMock) DeleteStageCalls() []struct {
+	ProjectName string
+	StageName   string
+} {
+	var calls []struct {
+		ProjectName string
+		StageName   string
+	}
+	mock.lockDeleteStage.RLock()
+	calls = mock.calls.DeleteStage
+	mock.lockDeleteStage.RUnlock()
+	return calls
+}
+
 // GetAllStages calls GetAllStagesFunc.
 func (mock *IStageManagerMock) GetAllStages(projectName string) ([]*apimodels.ExpandedStage, error) {
 	if mock.GetAllStagesFunc == nil {
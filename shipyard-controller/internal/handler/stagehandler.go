@@ -15,6 +15,7 @@ import (
 type IStageHandler interface {
 	GetAllStages(context *gin.Context)
 	GetStage(context *gin.Context)
+	DeleteStage(context *gin.Context)
 }
 
 type StageHandler struct {
@@ -121,3 +122,42 @@ func (sh *StageHandler) GetStage(c *gin.Context) {
 	c.JSON(http.StatusOK, stage)
 
 }
+
+// DeleteStage godoc
+// @Summary      Delete a stage
+// @Description  Delete a stage of a project. The stage, and the triggers on its sequences, are removed from the shipyard, and the branch or directory of the stage is removed from the upstream repository of the project.
+// @Description  The deletion is rejected as long as sequences are running in the stage.
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}stages:delete</span>
+// @Tags         Stage
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path      string                      true  "The name of the project"
+// @Param        stage    path      string                      true  "The name of the stage"
+// @Success      200      {object}  models.DeleteStageResponse  "ok"
+// @Failure      404      {object}  models.Error                "Not found"
+// @Failure      409      {object}  models.Error                "Stage has active sequences"
+// @Failure      500      {object}  models.Error                "Internal error"
+// @Router       /project/{project}/stage/{stage} [delete]
+func (sh *StageHandler) DeleteStage(c *gin.Context) {
+	projectName := c.Param("project")
+	stageName := c.Param("stage")
+
+	common.LockProject(projectName)
+	defer common.UnlockProject(projectName)
+
+	if err := sh.StageManager.DeleteStage(projectName, stageName); err != nil {
+		if errors.Is(err, common.ErrProjectNotFound) || errors.Is(err, common.ErrStageNotFound) {
+			SetNotFoundErrorResponse(c, err.Error())
+			return
+		}
+		if errors.Is(err, common.ErrStageHasActiveSequences) {
+			SetConflictErrorResponse(c, err.Error())
+			return
+		}
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.DeleteStageResponse{})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/keptn/keptn/shipyard-controller/internal/common"
 	"github.com/keptn/keptn/shipyard-controller/internal/handler/fake"
 	"io/ioutil"
@@ -283,3 +284,76 @@ func createExpandedStages() []*apimodels.ExpandedStage {
 	return []*apimodels.ExpandedStage{s1, s2, s3}
 
 }
+
+func TestDeleteStage(t *testing.T) {
+	tests := []struct {
+		name             string
+		stageManager     *fake.IStageManagerMock
+		expectHttpStatus int
+	}{
+		{
+			name: "DELETE stage",
+			stageManager: &fake.IStageManagerMock{
+				DeleteStageFunc: func(projectName string, stageName string) error {
+					return nil
+				},
+			},
+			expectHttpStatus: http.StatusOK,
+		},
+		{
+			name: "DELETE stage project not found",
+			stageManager: &fake.IStageManagerMock{
+				DeleteStageFunc: func(projectName string, stageName string) error {
+					return common.ErrProjectNotFound
+				},
+			},
+			expectHttpStatus: http.StatusNotFound,
+		},
+		{
+			name: "DELETE stage stage not found",
+			stageManager: &fake.IStageManagerMock{
+				DeleteStageFunc: func(projectName string, stageName string) error {
+					return common.ErrStageNotFound
+				},
+			},
+			expectHttpStatus: http.StatusNotFound,
+		},
+		{
+			name: "DELETE stage with active sequences",
+			stageManager: &fake.IStageManagerMock{
+				DeleteStageFunc: func(projectName string, stageName string) error {
+					return fmt.Errorf("%w: 1 sequence(s) in stage my-stage must be finished or aborted first", common.ErrStageHasActiveSequences)
+				},
+			},
+			expectHttpStatus: http.StatusConflict,
+		},
+		{
+			name: "DELETE stage internal error",
+			stageManager: &fake.IStageManagerMock{
+				DeleteStageFunc: func(projectName string, stageName string) error {
+					return errors.New("whoops")
+				},
+			},
+			expectHttpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodDelete, "", bytes.NewBuffer([]byte{}))
+			c.Params = gin.Params{
+				gin.Param{Key: "project", Value: "my-project"},
+				gin.Param{Key: "stage", Value: "my-stage"},
+			}
+			handler := NewStageHandler(tt.stageManager)
+			handler.DeleteStage(c)
+			assert.Equal(t, tt.expectHttpStatus, w.Code)
+
+			assert.Len(t, tt.stageManager.DeleteStageCalls(), 1)
+			assert.Equal(t, "my-project", tt.stageManager.DeleteStageCalls()[0].ProjectName)
+			assert.Equal(t, "my-stage", tt.stageManager.DeleteStageCalls()[0].StageName)
+		})
+	}
+}
@@ -1,24 +1,48 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/internal/common"
+	"github.com/keptn/keptn/shipyard-controller/internal/configurationstore"
 	"github.com/keptn/keptn/shipyard-controller/internal/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 )
 
+const shipyardFileName = "shipyard.yaml"
+
+// activeSequenceStates are the states of sequence executions that prevent the deletion of their stage
+var activeSequenceStates = []string{
+	apimodels.SequenceTriggeredState,
+	apimodels.SequenceStartedState,
+	apimodels.SequenceWaitingState,
+	apimodels.SequenceWaitingForApprovalState,
+}
+
 //go:generate moq -pkg fake -skip-ensure -out ./fake/stagemanager.go . IStageManager
 type IStageManager interface {
 	GetAllStages(projectName string) ([]*apimodels.ExpandedStage, error)
 	GetStage(projectName, stageName string) (*apimodels.ExpandedStage, error)
+	DeleteStage(projectName, stageName string) error
 }
 
 type StageManager struct {
-	projectMVRepo db.ProjectMVRepo
+	projectMVRepo         db.ProjectMVRepo
+	configurationStore    configurationstore.ConfigurationStore
+	sequenceExecutionRepo db.SequenceExecutionRepo
 }
 
-func NewStageManager(projectMVRepo db.ProjectMVRepo) *StageManager {
+func NewStageManager(projectMVRepo db.ProjectMVRepo, configurationStore configurationstore.ConfigurationStore, sequenceExecutionRepo db.SequenceExecutionRepo) *StageManager {
 	return &StageManager{
-		projectMVRepo: projectMVRepo,
+		projectMVRepo:         projectMVRepo,
+		configurationStore:    configurationStore,
+		sequenceExecutionRepo: sequenceExecutionRepo,
 	}
 }
 
@@ -51,3 +75,99 @@ func (sm *StageManager) GetStage(projectName, stageName string) (*apimodels.Expa
 	return nil, common.ErrStageNotFound
 
 }
+
+// DeleteStage deletes a stage from the shipyard, the upstream repository of the project and the database.
+// The deletion is rejected as long as sequences are active in the stage
+func (sm *StageManager) DeleteStage(projectName, stageName string) error {
+	log.Infof("Deleting stage %s from project %s", stageName, projectName)
+
+	if _, err := sm.GetStage(projectName, stageName); err != nil {
+		return err
+	}
+
+	activeSequences, err := sm.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: projectName,
+				Stage:   stageName,
+			},
+		},
+		Status: activeSequenceStates,
+	})
+	if err != nil {
+		return fmt.Errorf("could not retrieve active sequences of stage %s: %w", stageName, err)
+	}
+	if len(activeSequences) > 0 {
+		return fmt.Errorf("%w: %d sequence(s) in stage %s must be finished or aborted first", common.ErrStageHasActiveSequences, len(activeSequences), stageName)
+	}
+
+	// the stage is removed from the shipyard first, so that an interrupted deletion never leaves a shipyard referencing a missing stage
+	shipyardContent, err := sm.removeStageFromShipyard(projectName, stageName)
+	if err != nil {
+		return fmt.Errorf("could not remove stage %s from the shipyard of project %s: %w", stageName, projectName, err)
+	}
+
+	if err := sm.configurationStore.DeleteStage(projectName, stageName); err != nil {
+		// as for services, a stage that is already gone from the configuration store can still be removed from the db
+		if !errors.Is(err, common.ErrStageNotFound) {
+			return fmt.Errorf("could not delete stage %s from project %s: %w", stageName, projectName, err)
+		}
+		log.Infof("Stage %s has already been deleted from the configuration store", stageName)
+	}
+
+	if err := sm.projectMVRepo.DeleteStage(projectName, stageName); err != nil {
+		return fmt.Errorf("could not delete stage %s from project %s: %w", stageName, projectName, err)
+	}
+	if err := sm.projectMVRepo.UpdateShipyard(projectName, shipyardContent); err != nil {
+		return fmt.Errorf("could not update shipyard of project %s: %w", projectName, err)
+	}
+	log.Infof("Deleted stage %s from project %s", stageName, projectName)
+	return nil
+}
+
+// removeStageFromShipyard removes the stage, as well as the triggers on sequences of the stage, from the shipyard.yaml of the
+// project and commits the updated shipyard. It returns the content of the updated shipyard
+func (sm *StageManager) removeStageFromShipyard(projectName, stageName string) (string, error) {
+	resource, err := sm.configurationStore.GetProjectResource(projectName, shipyardFileName)
+	if err != nil {
+		return "", err
+	}
+	shipyard, err := common.UnmarshalShipyard(resource.ResourceContent)
+	if err != nil {
+		return "", err
+	}
+
+	stages := []keptnv2.Stage{}
+	for _, stage := range shipyard.Spec.Stages {
+		if stage.Name == stageName {
+			continue
+		}
+		for i, sequence := range stage.Sequences {
+			triggers := []keptnv2.Trigger{}
+			for _, trigger := range sequence.TriggeredOn {
+				if !strings.HasPrefix(trigger.Event, stageName+".") {
+					triggers = append(triggers, trigger)
+				}
+			}
+			stage.Sequences[i].TriggeredOn = triggers
+		}
+		stages = append(stages, stage)
+	}
+	if len(stages) == len(shipyard.Spec.Stages) {
+		// the stage has already been removed by a previous attempt
+		return resource.ResourceContent, nil
+	}
+	shipyard.Spec.Stages = stages
+
+	shipyardContent, err := yaml.Marshal(shipyard)
+	if err != nil {
+		return "", err
+	}
+	if err := sm.configurationStore.UpdateProjectResource(projectName, &apimodels.Resource{
+		ResourceContent: string(shipyardContent),
+		ResourceURI:     common.Stringp(shipyardFileName),
+	}); err != nil {
+		return "", err
+	}
+	return string(shipyardContent), nil
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"encoding/base64"
 	"errors"
 	"github.com/keptn/keptn/shipyard-controller/internal/common"
+	common_mock "github.com/keptn/keptn/shipyard-controller/internal/configurationstore/fake"
 	db_mock "github.com/keptn/keptn/shipyard-controller/internal/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	"testing"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
@@ -13,7 +16,7 @@ import (
 func TestGetAllStages_GettingProjectFromDBFails(t *testing.T) {
 
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, nil, nil)
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return nil, errors.New("whoops")
@@ -26,7 +29,7 @@ func TestGetAllStages_GettingProjectFromDBFails(t *testing.T) {
 
 func TestGetAllStages_ProjectNotFound(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, nil, nil)
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return nil, nil
@@ -40,7 +43,7 @@ func TestGetAllStages_ProjectNotFound(t *testing.T) {
 
 func TestGetAllStages(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, nil, nil)
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 
@@ -65,7 +68,7 @@ func TestGetAllStages(t *testing.T) {
 
 func TestGetStage_GettingProjectFromDBFails(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, nil, nil)
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return nil, errors.New("whoops")
@@ -78,7 +81,7 @@ func TestGetStage_GettingProjectFromDBFails(t *testing.T) {
 
 func TestGetStage_ProjectNotFound(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, nil, nil)
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return nil, nil
@@ -92,7 +95,7 @@ func TestGetStage_ProjectNotFound(t *testing.T) {
 
 func TestGetStage_StageNotFound(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, nil, nil)
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 
@@ -112,3 +115,212 @@ func TestGetStage_StageNotFound(t *testing.T) {
 	assert.Nil(t, stage)
 	assert.Equal(t, common.ErrStageNotFound, err)
 }
+
+const deleteStageTestShipyard = `apiVersion: spec.keptn.sh/0.2.3
+kind: Shipyard
+metadata:
+  name: shipyard-sockshop
+spec:
+  stages:
+    - name: dev
+      sequences:
+        - name: delivery
+          tasks:
+            - name: deployment
+    - name: prod
+      sequences:
+        - name: delivery
+          triggeredOn:
+            - event: dev.delivery.finished
+          tasks:
+            - name: deployment
+        - name: remediation
+          triggeredOn:
+            - event: prod.delivery.finished
+          tasks:
+            - name: action
+`
+
+func getDeleteStageTestMocks() (*db_mock.ProjectMVRepoMock, *common_mock.ConfigurationStoreMock, *db_mock.SequenceExecutionRepoMock) {
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			return &apimodels.ExpandedProject{
+				ProjectName: "my-project",
+				Stages:      []*apimodels.ExpandedStage{{StageName: "dev"}, {StageName: "prod"}},
+			}, nil
+		},
+		DeleteStageFunc: func(project string, stage string) error {
+			return nil
+		},
+		UpdateShipyardFunc: func(projectName string, shipyardContent string) error {
+			return nil
+		},
+	}
+	configurationStore := &common_mock.ConfigurationStoreMock{
+		GetProjectResourceFunc: func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+			return &apimodels.Resource{ResourceURI: common.Stringp("shipyard.yaml"), ResourceContent: deleteStageTestShipyard}, nil
+		},
+		UpdateProjectResourceFunc: func(projectName string, resource *apimodels.Resource) error {
+			return nil
+		},
+		DeleteStageFunc: func(projectName string, stageName string) error {
+			return nil
+		},
+	}
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return []models.SequenceExecution{}, nil
+		},
+	}
+	return projectMVRepo, configurationStore, sequenceExecutionRepo
+}
+
+func TestDeleteStage_Successful(t *testing.T) {
+	projectMVRepo, configurationStore, sequenceExecutionRepo := getDeleteStageTestMocks()
+	instance := NewStageManager(projectMVRepo, configurationStore, sequenceExecutionRepo)
+
+	err := instance.DeleteStage("my-project", "dev")
+	assert.Nil(t, err)
+
+	assert.Len(t, sequenceExecutionRepo.GetCalls(), 1)
+	assert.Equal(t, "my-project", sequenceExecutionRepo.GetCalls()[0].Filter.Scope.Project)
+	assert.Equal(t, "dev", sequenceExecutionRepo.GetCalls()[0].Filter.Scope.Stage)
+	assert.Equal(t, activeSequenceStates, sequenceExecutionRepo.GetCalls()[0].Filter.Status)
+
+	assert.Len(t, configurationStore.DeleteStageCalls(), 1)
+	assert.Equal(t, "my-project", configurationStore.DeleteStageCalls()[0].ProjectName)
+	assert.Equal(t, "dev", configurationStore.DeleteStageCalls()[0].StageName)
+
+	assert.Len(t, projectMVRepo.DeleteStageCalls(), 1)
+	assert.Equal(t, "my-project", projectMVRepo.DeleteStageCalls()[0].Project)
+	assert.Equal(t, "dev", projectMVRepo.DeleteStageCalls()[0].Stage)
+
+	assert.Len(t, configurationStore.UpdateProjectResourceCalls(), 1)
+	assert.Equal(t, "shipyard.yaml", *configurationStore.UpdateProjectResourceCalls()[0].Resource.ResourceURI)
+	shipyard, err := common.UnmarshalShipyard(configurationStore.UpdateProjectResourceCalls()[0].Resource.ResourceContent)
+	assert.Nil(t, err)
+	assert.Len(t, shipyard.Spec.Stages, 1)
+	assert.Equal(t, "prod", shipyard.Spec.Stages[0].Name)
+	assert.Empty(t, shipyard.Spec.Stages[0].Sequences[0].TriggeredOn)
+	assert.Equal(t, "prod.delivery.finished", shipyard.Spec.Stages[0].Sequences[1].TriggeredOn[0].Event)
+
+	assert.Len(t, projectMVRepo.UpdateShipyardCalls(), 1)
+	assert.Equal(t, configurationStore.UpdateProjectResourceCalls()[0].Resource.ResourceContent, projectMVRepo.UpdateShipyardCalls()[0].ShipyardContent)
+}
+
+func TestDeleteStage_FollowUpProjectUpdate(t *testing.T) {
+	projectMVRepo, configurationStore, sequenceExecutionRepo := getDeleteStageTestMocks()
+	instance := NewStageManager(projectMVRepo, configurationStore, sequenceExecutionRepo)
+
+	err := instance.DeleteStage("my-project", "dev")
+	assert.Nil(t, err)
+
+	// the project after the deletion only contains the remaining stage, and updating it with the committed shipyard must not be
+	// rejected as a change of the stages
+	projectAfterDeletion := &apimodels.ExpandedProject{
+		ProjectName: "my-project",
+		Stages:      []*apimodels.ExpandedStage{{StageName: "prod"}},
+	}
+	shipyard := base64.StdEncoding.EncodeToString([]byte(projectMVRepo.UpdateShipyardCalls()[0].ShipyardContent))
+	err = validateShipyardUpdate(&models.UpdateProjectParams{Name: common.Stringp("my-project"), Shipyard: &shipyard}, projectAfterDeletion)
+	assert.Nil(t, err)
+}
+
+func TestDeleteStage_AlreadyRemovedFromShipyard(t *testing.T) {
+	projectMVRepo, configurationStore, sequenceExecutionRepo := getDeleteStageTestMocks()
+	instance := NewStageManager(projectMVRepo, configurationStore, sequenceExecutionRepo)
+
+	err := instance.DeleteStage("my-project", "dev")
+	assert.Nil(t, err)
+
+	committedShipyard := configurationStore.UpdateProjectResourceCalls()[0].Resource.ResourceContent
+	configurationStore.GetProjectResourceFunc = func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+		return &apimodels.Resource{ResourceURI: common.Stringp("shipyard.yaml"), ResourceContent: committedShipyard}, nil
+	}
+
+	err = instance.DeleteStage("my-project", "dev")
+	assert.Nil(t, err)
+
+	assert.Len(t, configurationStore.UpdateProjectResourceCalls(), 1)
+	assert.Len(t, projectMVRepo.UpdateShipyardCalls(), 2)
+	assert.Equal(t, committedShipyard, projectMVRepo.UpdateShipyardCalls()[1].ShipyardContent)
+}
+
+func TestDeleteStage_UpdatingShipyardFails(t *testing.T) {
+	projectMVRepo, configurationStore, sequenceExecutionRepo := getDeleteStageTestMocks()
+	configurationStore.UpdateProjectResourceFunc = func(projectName string, resource *apimodels.Resource) error {
+		return errors.New("whoops")
+	}
+	instance := NewStageManager(projectMVRepo, configurationStore, sequenceExecutionRepo)
+
+	err := instance.DeleteStage("my-project", "dev")
+	assert.NotNil(t, err)
+
+	assert.Empty(t, configurationStore.DeleteStageCalls())
+	assert.Empty(t, projectMVRepo.DeleteStageCalls())
+}
+
+func TestDeleteStage_StageNotFound(t *testing.T) {
+	projectMVRepo, configurationStore, sequenceExecutionRepo := getDeleteStageTestMocks()
+	instance := NewStageManager(projectMVRepo, configurationStore, sequenceExecutionRepo)
+
+	err := instance.DeleteStage("my-project", "unknown-stage")
+	assert.ErrorIs(t, err, common.ErrStageNotFound)
+
+	assert.Empty(t, configurationStore.DeleteStageCalls())
+	assert.Empty(t, projectMVRepo.DeleteStageCalls())
+}
+
+func TestDeleteStage_ActiveSequences(t *testing.T) {
+	projectMVRepo, configurationStore, sequenceExecutionRepo := getDeleteStageTestMocks()
+	sequenceExecutionRepo.GetFunc = func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+		return []models.SequenceExecution{{ID: "my-sequence"}}, nil
+	}
+	instance := NewStageManager(projectMVRepo, configurationStore, sequenceExecutionRepo)
+
+	err := instance.DeleteStage("my-project", "dev")
+	assert.ErrorIs(t, err, common.ErrStageHasActiveSequences)
+
+	assert.Empty(t, configurationStore.DeleteStageCalls())
+	assert.Empty(t, projectMVRepo.DeleteStageCalls())
+}
+
+func TestDeleteStage_GettingSequencesFails(t *testing.T) {
+	projectMVRepo, configurationStore, sequenceExecutionRepo := getDeleteStageTestMocks()
+	sequenceExecutionRepo.GetFunc = func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+		return nil, errors.New("whoops")
+	}
+	instance := NewStageManager(projectMVRepo, configurationStore, sequenceExecutionRepo)
+
+	err := instance.DeleteStage("my-project", "dev")
+	assert.NotNil(t, err)
+
+	assert.Empty(t, configurationStore.DeleteStageCalls())
+	assert.Empty(t, projectMVRepo.DeleteStageCalls())
+}
+
+func TestDeleteStage_AlreadyDeletedFromConfigurationStore(t *testing.T) {
+	projectMVRepo, configurationStore, sequenceExecutionRepo := getDeleteStageTestMocks()
+	configurationStore.DeleteStageFunc = func(projectName string, stageName string) error {
+		return common.ErrStageNotFound
+	}
+	instance := NewStageManager(projectMVRepo, configurationStore, sequenceExecutionRepo)
+
+	err := instance.DeleteStage("my-project", "dev")
+	assert.Nil(t, err)
+
+	assert.Len(t, projectMVRepo.DeleteStageCalls(), 1)
+}
+
+func TestDeleteStage_ConfigurationStoreFails(t *testing.T) {
+	projectMVRepo, configurationStore, sequenceExecutionRepo := getDeleteStageTestMocks()
+	configurationStore.DeleteStageFunc = func(projectName string, stageName string) error {
+		return errors.New("whoops")
+	}
+	instance := NewStageManager(projectMVRepo, configurationStore, sequenceExecutionRepo)
+
+	err := instance.DeleteStage("my-project", "dev")
+	assert.NotNil(t, err)
+
+	assert.Empty(t, projectMVRepo.DeleteStageCalls())
+}
@@ -16,4 +16,5 @@ func NewStageController(stageHandler handler.IStageHandler) Controller {
 func (controller StageController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.GET("/project/:project/stage", controller.StageHandler.GetAllStages)
 	apiGroup.GET("/project/:project/stage/:stage", controller.StageHandler.GetStage)
+	apiGroup.DELETE("/project/:project/stage/:stage", controller.StageHandler.DeleteStage)
 }
@@ -138,7 +138,7 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 		uniformRepo,
 	)
 
-	stageManager := handler.NewStageManager(projectMVRepo)
+	stageManager := handler.NewStageManager(projectMVRepo, configurationstore.New(csEndpoint.String()), sequenceExecutionRepo)
 
 	debugManager := handler.NewDebugManager(createEventsRepo(), createStateRepo(), createProjectRepo(), createSequenceExecutionRepo(), createDbDumpRepo())
 
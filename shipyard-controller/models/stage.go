@@ -11,3 +11,7 @@ type GetStagesParams struct {
 	//Name of the project
 	ProjectName string `form:"-"`
 }
+
+type DeleteStageResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	logger "github.com/sirupsen/logrus"
+)
+
+const maxExecutionRecordLimit = 100
+
+// ExecutionsResponse is the response of the execution history endpoint
+type ExecutionsResponse struct {
+	Executions []lib.ExecutionRecord `json:"executions"`
+}
+
+// ErrorResponse is returned if a request to the API could not be handled
+type ErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ExecutionHandler serves the execution records kept in an IExecutionStore
+type ExecutionHandler struct {
+	executionStore lib.IExecutionStore
+}
+
+func NewExecutionHandler(executionStore lib.IExecutionStore) *ExecutionHandler {
+	return &ExecutionHandler{executionStore: executionStore}
+}
+
+// GetExecutions returns the most recent webhook executions, optionally filtered by project, stage, service and subscriptionID
+func (eh *ExecutionHandler) GetExecutions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	query := r.URL.Query()
+	filter := lib.ExecutionFilter{
+		Project:        query.Get("project"),
+		Stage:          query.Get("stage"),
+		Service:        query.Get("service"),
+		SubscriptionID: query.Get("subscriptionID"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit <= 0 || parsedLimit > maxExecutionRecordLimit {
+			writeError(w, http.StatusBadRequest, "limit must be a number between 1 and "+strconv.Itoa(maxExecutionRecordLimit))
+			return
+		}
+		filter.Limit = parsedLimit
+	}
+	writeJSON(w, http.StatusOK, ExecutionsResponse{Executions: eh.executionStore.List(filter)})
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, ErrorResponse{Code: code, Message: message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.WithError(err).Error("could not write response")
+	}
+}
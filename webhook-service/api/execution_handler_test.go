@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/api"
+	apifake "github.com/keptn/keptn/webhook-service/api/fake"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionHandler_GetExecutions(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		wantStatus int
+		wantFilter *lib.ExecutionFilter
+	}{
+		{
+			name:       "all filters",
+			method:     http.MethodGet,
+			query:      "?project=podtato&stage=dev&service=head&subscriptionID=sub-1&limit=5",
+			wantStatus: http.StatusOK,
+			wantFilter: &lib.ExecutionFilter{Project: "podtato", Stage: "dev", Service: "head", SubscriptionID: "sub-1", Limit: 5},
+		},
+		{
+			name:       "no filter",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantFilter: &lib.ExecutionFilter{},
+		},
+		{
+			name:       "invalid limit",
+			method:     http.MethodGet,
+			query:      "?limit=abc",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "limit too high",
+			method:     http.MethodGet,
+			query:      "?limit=1000",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fake.IExecutionStoreMock{
+				ListFunc: func(filter lib.ExecutionFilter) []lib.ExecutionRecord {
+					return []lib.ExecutionRecord{{ID: "1", SubscriptionID: "sub-1", Status: lib.ExecutionSucceeded}}
+				},
+			}
+			router := api.NewRouter(api.NewExecutionHandler(store), api.NewTestFireHandler(&apifake.WebhookTesterMock{}, nil))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, "/v1/executions"+tt.query, nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantFilter == nil {
+				assert.Empty(t, store.ListCalls())
+				return
+			}
+			require.Len(t, store.ListCalls(), 1)
+			assert.Equal(t, *tt.wantFilter, store.ListCalls()[0].Filter)
+
+			response := api.ExecutionsResponse{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Len(t, response.Executions, 1)
+			assert.Equal(t, "sub-1", response.Executions[0].SubscriptionID)
+		})
+	}
+}
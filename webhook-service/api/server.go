@@ -0,0 +1,13 @@
+package api
+
+import (
+	"net/http"
+)
+
+// NewRouter creates the handler serving the API of the webhook service
+func NewRouter(executionHandler *ExecutionHandler, testFireHandler *TestFireHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/executions", executionHandler.GetExecutions)
+	mux.HandleFunc("/v1/test", testFireHandler.TestWebhook)
+	return mux
+}
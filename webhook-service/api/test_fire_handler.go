@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+)
+
+// maxTestFireRequestSize limits the size of the sample event sent to the test endpoint
+const maxTestFireRequestSize = 1 << 20
+
+//go:generate moq  -pkg fake -out ./fake/webhook_tester_mock.go . WebhookTester
+type WebhookTester interface {
+	TestFire(resourceHandler sdk.ResourceHandler, testRequest lib.TestFireRequest) (*lib.TestFireResult, error)
+}
+
+// TestFireHandler renders and, optionally, executes the requests of a webhook using a sample event
+type TestFireHandler struct {
+	webhookTester   WebhookTester
+	resourceHandler sdk.ResourceHandler
+}
+
+func NewTestFireHandler(webhookTester WebhookTester, resourceHandler sdk.ResourceHandler) *TestFireHandler {
+	return &TestFireHandler{
+		webhookTester:   webhookTester,
+		resourceHandler: resourceHandler,
+	}
+}
+
+// TestWebhook validates the webhook config and returns the rendered requests of the webhook, as well as their responses if they have been executed
+func (th *TestFireHandler) TestWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	testRequest := lib.TestFireRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTestFireRequestSize)).Decode(&testRequest); err != nil {
+		writeError(w, http.StatusBadRequest, "could not parse request: "+err.Error())
+		return
+	}
+	result, err := th.webhookTester.TestFire(th.resourceHandler, testRequest)
+	if err != nil {
+		if errors.Is(err, lib.ErrInvalidTestFireRequest) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
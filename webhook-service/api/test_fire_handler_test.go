@@ -0,0 +1,105 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/api"
+	apifake "github.com/keptn/keptn/webhook-service/api/fake"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestFireHandler_TestWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		testFireErr error
+		wantStatus  int
+		wantRequest *lib.TestFireRequest
+	}{
+		{
+			name:       "valid request",
+			method:     http.MethodPost,
+			body:       `{"project": "podtato", "stage": "dev", "service": "head", "subscriptionID": "sub-1", "execute": true, "event": {"type": "sh.keptn.event.webhook.triggered"}}`,
+			wantStatus: http.StatusOK,
+			wantRequest: &lib.TestFireRequest{
+				Project:        "podtato",
+				Stage:          "dev",
+				Service:        "head",
+				SubscriptionID: "sub-1",
+				Execute:        true,
+			},
+		},
+		{
+			name:        "invalid test request",
+			method:      http.MethodPost,
+			body:        `{"project": "podtato"}`,
+			testFireErr: fmt.Errorf("%w: subscriptionID must be set", lib.ErrInvalidTestFireRequest),
+			wantStatus:  http.StatusBadRequest,
+			wantRequest: &lib.TestFireRequest{Project: "podtato"},
+		},
+		{
+			name:        "internal error",
+			method:      http.MethodPost,
+			body:        `{"project": "podtato", "subscriptionID": "sub-1"}`,
+			testFireErr: errors.New("oops"),
+			wantStatus:  http.StatusInternalServerError,
+			wantRequest: &lib.TestFireRequest{Project: "podtato", SubscriptionID: "sub-1"},
+		},
+		{
+			name:       "invalid JSON",
+			method:     http.MethodPost,
+			body:       `{"project": `,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tester := &apifake.WebhookTesterMock{
+				TestFireFunc: func(resourceHandler sdk.ResourceHandler, testRequest lib.TestFireRequest) (*lib.TestFireResult, error) {
+					if tt.testFireErr != nil {
+						return nil, tt.testFireErr
+					}
+					return &lib.TestFireResult{Valid: true, Requests: []lib.TestFireRequestResult{{Method: "GET", URL: "http://my-target"}}}, nil
+				},
+			}
+			router := api.NewRouter(api.NewExecutionHandler(&fake.IExecutionStoreMock{}), api.NewTestFireHandler(tester, nil))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, "/v1/test", strings.NewReader(tt.body)))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantRequest == nil {
+				assert.Empty(t, tester.TestFireCalls())
+				return
+			}
+			require.Len(t, tester.TestFireCalls(), 1)
+			testRequest := tester.TestFireCalls()[0].TestRequest
+			testRequest.Event = tt.wantRequest.Event
+			assert.Equal(t, *tt.wantRequest, testRequest)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			result := lib.TestFireResult{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+			assert.True(t, result.Valid)
+			require.Len(t, result.Requests, 1)
+			assert.Equal(t, "http://my-target", result.Requests[0].URL)
+		})
+	}
+}
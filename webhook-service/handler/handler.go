@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	keptn "github.com/keptn/go-utils/pkg/api/utils"
 
 	"github.com/keptn/go-utils/pkg/api/models"
@@ -24,17 +27,49 @@ type SecretEnv struct {
 type TaskHandler struct {
 	templateEngine   lib.ITemplateEngine
 	curlExecutor     lib.ICurlExecutor
+	httpExecutor     lib.IHTTPExecutor
 	requestValidator lib.RequestValidator
 	secretReader     lib.ISecretReader
+	executionStore   lib.IExecutionStore
+	ctx              context.Context
 }
 
-func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, requestValidator lib.RequestValidator, secretReader lib.ISecretReader) *TaskHandler {
-	return &TaskHandler{
+type TaskHandlerOption func(th *TaskHandler)
+
+// WithHTTPExecutor sets the executor used for v1beta1 requests.
+// Requests that define curl options are still executed with the curl executor
+func WithHTTPExecutor(httpExecutor lib.IHTTPExecutor) TaskHandlerOption {
+	return func(th *TaskHandler) {
+		th.httpExecutor = httpExecutor
+	}
+}
+
+// WithExecutionStore sets the store in which a record of each webhook execution is kept
+func WithExecutionStore(executionStore lib.IExecutionStore) TaskHandlerOption {
+	return func(th *TaskHandler) {
+		th.executionStore = executionStore
+	}
+}
+
+// WithContext sets the context whose cancellation aborts the polling of running jobs, e.g. when the service is shutting down
+func WithContext(ctx context.Context) TaskHandlerOption {
+	return func(th *TaskHandler) {
+		th.ctx = ctx
+	}
+}
+
+func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, requestValidator lib.RequestValidator, secretReader lib.ISecretReader, opts ...TaskHandlerOption) *TaskHandler {
+	th := &TaskHandler{
 		templateEngine:   templateEngine,
 		curlExecutor:     curlExecutor,
 		requestValidator: requestValidator,
 		secretReader:     secretReader,
+		ctx:              context.Background(),
 	}
+	for _, o := range opts {
+		o(th)
+	}
+	return th
 }
 
 func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (interface{}, *sdk.Error) {
@@ -48,9 +83,16 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		logger.Infof("will not handle event: %s", err.Error())
 		return nil, nil
 	}
-	webhook, err := th.getWebHookConfig(keptnHandler, eventAdapter, subscriptionID, event.GitCommitID)
+	record := newExecutionRecord(event, eventAdapter, subscriptionID)
+	secretEnvVars := map[string]string{}
+	defer func() {
+		th.storeExecutionRecord(record, secretEnvVars)
+	}()
+
+	webhook, err := th.getWebHookConfig(keptnHandler.GetResourceHandler(), eventAdapter, subscriptionID, event.GitCommitID)
 	if err != nil {
 		err = fmt.Errorf("could not retrieve Webhook config: %w", err)
+		record.Message = err.Error()
 		th.onPreExecutionError(keptnHandler, event, eventAdapter, err)
 		return nil, sdkError(err.Error(), err)
 	}
@@ -63,17 +105,26 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 
 	onError := th.getErrorCallbackForWebhookConfig(keptnHandler, event, eventAdapter, webhook)
 
-	secretEnvVars, err := th.gatherSecretEnvVars(*webhook)
+	secretEnvVars, err = th.gatherSecretEnvVars(*webhook)
 	if err != nil {
+		record.Message = err.Error()
 		onError(err, secretEnvVars)
 		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
 	}
 	eventAdapter.Add("env", secretEnvVars)
-	responses, err = th.performWebhookRequests(*webhook, eventAdapter, responses)
-	if err != nil {
+	responses, outputs, err := th.performWebhookRequests(*webhook, eventAdapter, responses, record)
+	var failedErr *lib.WebhookFailedError
+	if err != nil && !errors.As(err, &failedErr) {
+		record.Message = err.Error()
 		onError(err, secretEnvVars)
 		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
 	}
+	if failedErr != nil {
+		record.Status = lib.ExecutionFailed
+		record.Message = failedErr.Error()
+	} else {
+		record.Status = lib.ExecutionSucceeded
+	}
 
 	// check if the incoming event was a task.triggered event, and if the 'sendFinished'  property of the webhook was set to true
 	// only in this case, the result should be sent back to Keptn in the form of a .finished event
@@ -82,21 +133,34 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		if err != nil {
 			return nil, sdkError(fmt.Sprintf("could not derive task name from event type %s", *event.Type), err)
 		}
+		// the outputs extracted from the responses are added next to the responses
+		taskResult := map[string]interface{}{}
+		for name, value := range outputs {
+			taskResult[name] = value
+		}
+		taskResult[lib.ResponsesKey] = responses
 		result := map[string]interface{}{
 			"project": eventAdapter.Project(),
 			"stage":   eventAdapter.Stage(),
 			"service": eventAdapter.Service(),
 			"labels":  eventAdapter.Labels(),
-			taskName: map[string]interface{}{
-				"responses": responses,
-			},
+			taskName:  taskResult,
+		}
+		if failedErr != nil {
+			result["result"] = keptnv2.ResultFailed
+			result["message"] = removeSecretsFromMessage(failedErr.Error(), secretEnvVars)
 		}
 		err = keptnHandler.SendFinishedEvent(event, result)
 		if err != nil {
+			record.Status = lib.ExecutionErrored
+			record.Message = fmt.Sprintf("could not send finished event: %s", err.Error())
 			return nil, sdkError(fmt.Sprintf("could not send finished event: %s", err.Error()), err)
 		}
 		return result, nil
 	}
+	if failedErr != nil {
+		return nil, sdkError(removeSecretsFromMessage(failedErr.Error(), secretEnvVars), failedErr)
+	}
 
 	return nil, nil
 }
@@ -154,6 +218,49 @@ func (th *TaskHandler) getErrorCallbackForWebhookConfig(keptnHandler sdk.IKeptn,
 	}
 }
 
+func newExecutionRecord(event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, subscriptionID string) *lib.ExecutionRecord {
+	record := &lib.ExecutionRecord{
+		ID:             uuid.New().String(),
+		Time:           time.Now().UTC(),
+		SubscriptionID: subscriptionID,
+		Project:        eventAdapter.Project(),
+		Stage:          eventAdapter.Stage(),
+		Service:        eventAdapter.Service(),
+		KeptnContext:   event.Shkeptncontext,
+		TriggeredID:    event.ID,
+		Status:         lib.ExecutionErrored,
+		Requests:       []lib.RequestRecord{},
+	}
+	if event.Type != nil {
+		record.EventType = *event.Type
+	}
+	return record
+}
+
+// storeExecutionRecord adds the given record to the execution store, after the values of the given secrets have been removed from it
+func (th *TaskHandler) storeExecutionRecord(record *lib.ExecutionRecord, secrets map[string]string) {
+	if th.executionStore == nil {
+		return
+	}
+	record.Message = removeSecretsFromMessage(record.Message, secrets)
+	for i := range record.Requests {
+		request := &record.Requests[i]
+		request.URL = removeSecretsFromMessage(redactURL(request.URL), secrets)
+		request.Command = removeSecretsFromMessage(request.Command, secrets)
+		request.Error = removeSecretsFromMessage(request.Error, secrets)
+	}
+	th.executionStore.Add(*record)
+}
+
+// redactURL replaces the password contained in the given URL
+func redactURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return parsedURL.Redacted()
+}
+
 func removeSecretsFromMessage(errMsg string, secrets map[string]string) string {
 	result := errMsg
 	for _, val := range secrets {
@@ -200,37 +307,294 @@ func (th *TaskHandler) onStartedWebhookExecution(keptnHandler sdk.IKeptn, event
 	return nil
 }
 
-func (th *TaskHandler) performWebhookRequests(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter, responses []string) ([]string, error) {
+func (th *TaskHandler) performWebhookRequests(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter, responses []string, record *lib.ExecutionRecord) ([]string, map[string]interface{}, error) {
 	executedRequests := 0
+	outputs := map[string]interface{}{}
+	// the responses of previous requests can be referenced in the templates of subsequent requests, e.g. '{{.responses.0.body.token}}'
+	previousResponses := []interface{}{}
 	logger.Infof("executing webhooks for subscriptionID %s", webhook.SubscriptionID)
 	for _, req := range webhook.Requests {
-		request, err := th.CreateRequest(req)
-		if err != nil {
-			logger.Infof("creating CURL request failed: %s", err.Error())
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("creating CURL request failed: %s", err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+		eventAdapter.Add(lib.ResponsesKey, previousResponses)
+		var response *lib.HTTPResponse
+		var err error
+		requestRecord := lib.RequestRecord{}
+		start := time.Now()
+		if th.shouldUseHTTPExecutor(req) {
+			response, err = th.performHTTPRequest(lib.ConvertToRequest(req), eventAdapter, &requestRecord)
+		} else {
+			response, err = th.performCurlRequest(req, eventAdapter, &requestRecord)
 		}
-		// parse the data from the event, together with the secret env vars
-		parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), request)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not parse request '%s' : %s", request, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			record.Requests = append(record.Requests, completeRequestRecord(requestRecord, start, response, err))
+			return nil, nil, lib.NewWebhookExecutionError(true, err, lib.WithNrOfExecutedRequests(executedRequests))
 		}
-		// perform the request
-		response, err := th.curlExecutor.Curl(parsedCurlCommand)
+		requestOutputs, err := th.evaluateResponse(req, response)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not execute request '%s': %s", request, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			record.Requests = append(record.Requests, completeRequestRecord(requestRecord, start, response, err))
+			return nil, nil, lib.NewWebhookExecutionError(true, err, lib.WithNrOfExecutedRequests(executedRequests))
+		}
+		if request, ok := req.(lib.Request); ok && request.Poll != nil {
+			initialResponse := response
+			response, requestOutputs, err = th.pollUntilCompleted(request, eventAdapter, requestOutputs)
+			// the duration of the recorded request includes the time spent waiting for the job to complete
+			record.Requests = append(record.Requests, completeRequestRecord(requestRecord, start, initialResponse, err))
+			var failedErr *lib.WebhookFailedError
+			if errors.As(err, &failedErr) {
+				// the job has been executed, but failed - the responses gathered so far are still reported
+				responses = append(responses, response.Body)
+				mergeOutputs(outputs, requestOutputs)
+				return responses, outputs, err
+			} else if err != nil {
+				return nil, nil, lib.NewWebhookExecutionError(true, err, lib.WithNrOfExecutedRequests(executedRequests))
+			}
+		} else {
+			record.Requests = append(record.Requests, completeRequestRecord(requestRecord, start, response, nil))
 		}
 		executedRequests = executedRequests + 1
-		responses = append(responses, response)
+		responses = append(responses, response.Body)
+		mergeOutputs(outputs, requestOutputs)
+		if requestOutputs == nil {
+			requestOutputs = map[string]interface{}{}
+		}
+		previousResponses = append(previousResponses, map[string]interface{}{
+			"statusCode": response.StatusCode,
+			"body":       lib.ParseResponseBody(response.Body),
+			"outputs":    requestOutputs,
+		})
 	}
-	return responses, nil
+	return responses, outputs, nil
+}
+
+func completeRequestRecord(requestRecord lib.RequestRecord, start time.Time, response *lib.HTTPResponse, err error) lib.RequestRecord {
+	requestRecord.DurationMs = time.Since(start).Milliseconds()
+	if response != nil {
+		requestRecord.StatusCode = response.StatusCode
+	}
+	if err != nil {
+		requestRecord.Error = err.Error()
+	}
+	return requestRecord
+}
+
+func mergeOutputs(outputs, requestOutputs map[string]interface{}) {
+	for name, value := range requestOutputs {
+		outputs[name] = value
+	}
+}
+
+// pollUntilCompleted polls the status URL of the given request until its success or failure condition is fulfilled, its timeout has elapsed
+// or the context of the task handler is cancelled.
+// It returns the last status response together with the outputs of the initial request and the outputs extracted from the last status response
+func (th *TaskHandler) pollUntilCompleted(request lib.Request, eventAdapter *lib.EventDataAdapter, outputs map[string]interface{}) (*lib.HTTPResponse, map[string]interface{}, error) {
+	if th.httpExecutor == nil {
+		return nil, nil, fmt.Errorf("could not poll status of request '%s': polling is not supported", request.URL)
+	}
+	poll := request.Poll
+	templateData := map[string]interface{}{}
+	for key, value := range eventAdapter.Get() {
+		templateData[key] = value
+	}
+	templateData["outputs"] = outputs
+
+	statusRequest := lib.Request{
+		URL:             poll.URL,
+		Method:          poll.GetMethod(),
+		Headers:         poll.Headers,
+		Timeout:         request.Timeout,
+		MaxResponseSize: request.MaxResponseSize,
+		TLS:             request.TLS,
+	}
+	renderedRequest, err := th.renderRequest(statusRequest, templateData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse poll request '%s' : %s", poll.URL, err.Error())
+	}
+
+	deadline := time.Now().Add(poll.GetTimeout())
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-th.ctx.Done():
+			return nil, nil, fmt.Errorf("polling of request '%s' has been aborted: %w", request.URL, th.ctx.Err())
+		case <-timer.C:
+		}
+		response, err := th.httpExecutor.Execute(renderedRequest)
+		if err != nil {
+			// transient errors, e.g. a temporarily unavailable status endpoint, do not abort the polling before the timeout has elapsed
+			if !lib.IsTransientError(err) || time.Now().Add(poll.GetInterval()).After(deadline) {
+				return nil, nil, fmt.Errorf("could not execute poll request '%s': %s", poll.URL, err.Error())
+			}
+			logger.Infof("could not execute poll request '%s', retrying in %s: %s", poll.URL, poll.GetInterval(), err.Error())
+			timer.Reset(poll.GetInterval())
+			continue
+		}
+		conditionData := map[string]interface{}{
+			"statusCode": response.StatusCode,
+			"body":       lib.ParseResponseBody(response.Body),
+			"outputs":    outputs,
+		}
+
+		failed := false
+		if poll.FailureCondition != "" {
+			failed, err = th.evaluateCondition(poll.FailureCondition, conditionData)
+			if err != nil {
+				return nil, nil, fmt.Errorf("could not evaluate failure condition of poll request '%s': %s", poll.URL, err.Error())
+			}
+		}
+		succeeded := false
+		if !failed {
+			succeeded, err = th.evaluateCondition(poll.SuccessCondition, conditionData)
+			if err != nil {
+				return nil, nil, fmt.Errorf("could not evaluate success condition of poll request '%s': %s", poll.URL, err.Error())
+			}
+		}
+
+		if failed || succeeded {
+			pollOutputs, err := (&lib.ResponseConfig{Outputs: poll.Outputs}).ExtractOutputs(response.Body)
+			if err != nil {
+				return nil, nil, fmt.Errorf("could not evaluate response of poll request '%s': %s", poll.URL, err.Error())
+			}
+			result := map[string]interface{}{}
+			mergeOutputs(result, outputs)
+			mergeOutputs(result, pollOutputs)
+			if failed {
+				return response, result, lib.NewWebhookFailedError(fmt.Errorf("job of request '%s' has failed.\nResponse: \n%s", request.URL, response.Body))
+			}
+			return response, result, nil
+		}
+
+		if time.Now().Add(poll.GetInterval()).After(deadline) {
+			return nil, nil, fmt.Errorf("job of request '%s' did not complete within %s", request.URL, poll.GetTimeout())
+		}
+		timer.Reset(poll.GetInterval())
+	}
+}
+
+// evaluateCondition determines whether the given condition template evaluates to 'true'
+func (th *TaskHandler) evaluateCondition(condition string, data interface{}) (bool, error) {
+	result, err := th.templateEngine.ParseTemplate(data, condition)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(result) == "true", nil
+}
+
+func (th *TaskHandler) performCurlRequest(req interface{}, eventAdapter *lib.EventDataAdapter, requestRecord *lib.RequestRecord) (*lib.HTTPResponse, error) {
+	request, err := th.CreateRequest(req)
+	if err != nil {
+		logger.Infof("creating CURL request failed: %s", err.Error())
+		return nil, fmt.Errorf("creating CURL request failed: %s", err.Error())
+	}
+	// parse the data from the event, together with the secret env vars
+	parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), request)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse request '%s' : %s", request, err.Error())
+	}
+	requestRecord.Command = parsedCurlCommand
+	// perform the request
+	response, err := th.curlExecutor.Curl(parsedCurlCommand)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute request '%s': %w", request, err)
+	}
+	return &lib.HTTPResponse{Body: response}, nil
+}
+
+// evaluateResponse extracts the outputs defined in the response section of the given request and checks its success condition
+func (th *TaskHandler) evaluateResponse(req interface{}, response *lib.HTTPResponse) (map[string]interface{}, error) {
+	if _, ok := req.(string); ok {
+		return nil, nil
+	}
+	request := lib.ConvertToRequest(req)
+	if request.Response == nil {
+		return nil, nil
+	}
+	outputs, err := request.Response.ExtractOutputs(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not evaluate response of request '%s': %s", request.URL, err.Error())
+	}
+	if request.Response.SuccessCondition == "" {
+		return outputs, nil
+	}
+	conditionData := map[string]interface{}{
+		"statusCode": response.StatusCode,
+		"body":       lib.ParseResponseBody(response.Body),
+		"outputs":    outputs,
+	}
+	succeeded, err := th.evaluateCondition(request.Response.SuccessCondition, conditionData)
+	if err != nil {
+		return nil, fmt.Errorf("could not evaluate success condition of request '%s': %s", request.URL, err.Error())
+	}
+	if !succeeded {
+		return nil, fmt.Errorf("response of request '%s' does not fulfill the success condition.\nResponse: \n%s", request.URL, response.Body)
+	}
+	return outputs, nil
+}
+
+// shouldUseHTTPExecutor determines whether the given request can be executed with the HTTP executor.
+// This is the case for v1beta1 requests that do not rely on curl options
+func (th *TaskHandler) shouldUseHTTPExecutor(request interface{}) bool {
+	if th.httpExecutor == nil {
+		return false
+	}
+	if _, ok := request.(string); ok {
+		return false
+	}
+	return lib.ConvertToRequest(request).Options == ""
+}
+
+func (th *TaskHandler) performHTTPRequest(request lib.Request, eventAdapter *lib.EventDataAdapter, requestRecord *lib.RequestRecord) (*lib.HTTPResponse, error) {
+	requestRecord.Method = request.Method
+	renderedRequest, err := th.renderRequest(request, eventAdapter.Get())
+	if err != nil {
+		return nil, fmt.Errorf("could not parse request '%s' : %s", request.URL, err.Error())
+	}
+	requestRecord.URL = renderedRequest.URL
+	if err := th.requestValidator.Validate(renderedRequest); err != nil {
+		return nil, fmt.Errorf("could not execute request '%s': %s", request.URL, err.Error())
+	}
+	response, err := th.httpExecutor.Execute(renderedRequest)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute request '%s': %w", request.URL, err)
+	}
+	return response, nil
+}
+
+// renderRequest parses the templates contained in the URL, headers, payload and TLS options of the given request
+func (th *TaskHandler) renderRequest(request lib.Request, data interface{}) (lib.Request, error) {
+	rendered := request
+	var err error
+	parse := func(templateStr string) string {
+		if err != nil || templateStr == "" {
+			return templateStr
+		}
+		var result string
+		result, err = th.templateEngine.ParseTemplate(data, templateStr)
+		return result
+	}
+
+	rendered.URL = parse(request.URL)
+	rendered.Payload = parse(request.Payload)
+	rendered.Headers = make([]lib.Header, 0, len(request.Headers))
+	for _, header := range request.Headers {
+		rendered.Headers = append(rendered.Headers, lib.Header{Key: parse(header.Key), Value: parse(header.Value)})
+	}
+	if request.TLS != nil {
+		rendered.TLS = &lib.TLSConfig{
+			InsecureSkipVerify: request.TLS.InsecureSkipVerify,
+			CACert:             parse(request.TLS.CACert),
+			ClientCert:         parse(request.TLS.ClientCert),
+			ClientKey:          parse(request.TLS.ClientKey),
+		}
+	}
+	return rendered, err
 }
 
 func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook) (map[string]string, error) {
 	secretEnvVars := map[string]string{}
 	for _, secretRef := range webhook.EnvFrom {
-		secretValue, err := th.secretReader.ReadSecret(secretRef.SecretRef.Name, secretRef.SecretRef.Key)
+		secretValue, err := th.secretReader.ReadSecret(secretRef.SecretRef)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not read secret %s.%s", secretRef.SecretRef.Name, secretRef.SecretRef.Key))
+			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not read secret %s: %s", secretRef.SecretRef, err.Error()))
 		}
 		secretEnvVars[secretRef.Name] = secretValue
 	}
@@ -304,55 +668,58 @@ func sdkError(msg string, err error) *sdk.Error {
 	}
 }
 
-func (th *TaskHandler) getWebHookConfig(keptnHandler sdk.IKeptn, eventAdapter *lib.EventDataAdapter, subscriptionID string, commitID string) (*lib.Webhook, error) {
-	// first try to retrieve the webhook config at the service level
+func (th *TaskHandler) getWebHookConfig(resourceHandler sdk.ResourceHandler, eventAdapter *lib.EventDataAdapter, subscriptionID string, commitID string) (*lib.Webhook, error) {
 	commitOption := url.Values{}
 	if commitID != "" {
 		commitOption.Add("commitID", commitID)
 	}
-	resourceScope := *keptn.NewResourceScope().Project(eventAdapter.Project()).Stage(eventAdapter.Stage()).Service(eventAdapter.Service()).Resource(webhookConfigFileName)
-	resource, err := keptnHandler.GetResourceHandler().GetResource(resourceScope, keptn.AppendQuery(commitOption))
-	logger.Debug("searching for webhook config at service level...")
-	if err == nil && resource != nil {
-		if matchingWebhook := getMatchingWebhookFromResource(resource, subscriptionID); matchingWebhook != nil {
-			return matchingWebhook, nil
+	// the webhook config is first searched at the service level, then at the stage level, and finally at the project level
+	scopes := []struct {
+		level string
+		scope *keptn.ResourceScope
+	}{
+		{level: "service", scope: keptn.NewResourceScope().Project(eventAdapter.Project()).Stage(eventAdapter.Stage()).Service(eventAdapter.Service()).Resource(webhookConfigFileName)},
+		{level: "stage", scope: keptn.NewResourceScope().Project(eventAdapter.Project()).Stage(eventAdapter.Stage()).Resource(webhookConfigFileName)},
+		{level: "project", scope: keptn.NewResourceScope().Project(eventAdapter.Project()).Resource(webhookConfigFileName)},
+	}
+
+	var err error
+	// invalid webhook configs are skipped, but reported if no matching webhook could be found
+	invalidConfigs := []string{}
+	for _, s := range scopes {
+		logger.Debugf("searching for webhook config at %s level...", s.level)
+		var resource *models.Resource
+		resource, err = resourceHandler.GetResource(*s.scope, keptn.AppendQuery(commitOption))
+		if err != nil || resource == nil {
+			continue
 		}
-	}
-
-	// if we didn't find a config in the service directory, look at the stage
-	resourceScope = *keptn.NewResourceScope().Project(eventAdapter.Project()).Stage(eventAdapter.Stage()).Resource(webhookConfigFileName)
-	resource, err = keptnHandler.GetResourceHandler().GetResource(resourceScope, keptn.AppendQuery(commitOption))
-	logger.Debug("searching for webhook config at stage level...")
-	if err == nil && resource != nil {
-		if matchingWebhook := getMatchingWebhookFromResource(resource, subscriptionID); matchingWebhook != nil {
-			return matchingWebhook, nil
+		matchingWebhook, decodeErr := getMatchingWebhookFromResource(resource, subscriptionID)
+		if decodeErr != nil {
+			invalidConfigs = append(invalidConfigs, fmt.Sprintf("%s level: %s", s.level, decodeErr.Error()))
+			continue
 		}
-	}
-
-	// finally, look at project level
-	resourceScope = *keptn.NewResourceScope().Project(eventAdapter.Project()).Resource(webhookConfigFileName)
-	resource, err = keptnHandler.GetResourceHandler().GetResource(resourceScope, keptn.AppendQuery(commitOption))
-	logger.Debug("searching for webhook config at project level...")
-	if err == nil && resource != nil {
-		if matchingWebhook := getMatchingWebhookFromResource(resource, subscriptionID); matchingWebhook != nil {
+		if matchingWebhook != nil {
 			return matchingWebhook, nil
 		}
 	}
 	if err != nil {
 		logger.Debugf("no webhook config found, err: %s", err.Error())
 	}
+	if len(invalidConfigs) > 0 {
+		return nil, fmt.Errorf("no webhook config found, skipped invalid webhook configs: %s", strings.Join(invalidConfigs, "; "))
+	}
 	return nil, errors.New("no webhook config found")
 }
 
-func getMatchingWebhookFromResource(resource *models.Resource, subscriptionID string) *lib.Webhook {
+func getMatchingWebhookFromResource(resource *models.Resource, subscriptionID string) (*lib.Webhook, error) {
 	whConfig, err := lib.DecodeWebHookConfigYAML([]byte(resource.ResourceContent))
 	if err != nil {
-		return nil
+		return nil, err
 	}
 	for _, webhook := range whConfig.Spec.Webhooks {
 		if webhook.SubscriptionID == subscriptionID {
-			return &webhook
+			return &webhook, nil
 		}
 	}
-	return nil
+	return nil, nil
 }
@@ -1,6 +1,7 @@
 package handler_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -311,6 +312,91 @@ spec:
       - url: http://local:8080 {{.data.project}} {{.env.mysecret}}
         method: GET`
 
+const webHookContentWithHTTPExecutor_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      envFrom:
+        - secretRef:
+          name: mysecret
+      requests:
+      - url: http://local:8080/{{.data.project}}
+        method: POST
+        timeout: 10s
+        headers:
+          - key: x-token
+            value: "{{.env.mysecret}}"
+        payload: '{"project": "{{.data.project}}", "command": "$(echo 1) | cat"}'
+      - url: http://local:8080/{{.data.project}}
+        method: GET
+        options: --connect-timeout 5`
+
+const webHookContentWithResponseOutputs_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+      - url: http://local:8080/{{.data.project}}
+        method: POST
+        response:
+          successCondition: '{{ eq .body.status "queued" }}'
+          outputs:
+            jobId: $.id`
+
+const webHookContentWithPolling_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+      - url: http://local:8080/jobs
+        method: POST
+        response:
+          outputs:
+            jobId: $.id
+        poll:
+          url: http://local:8080/jobs/{{.outputs.jobId}}
+          interval: 10ms
+          timeout: 1s
+          successCondition: '{{ eq .body.state "success" }}'
+          failureCondition: '{{ eq .body.state "failed" }}'
+          outputs:
+            reportURL: $.report`
+
+const webHookContentWithChainedRequests_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+      - url: http://local:8080/oauth/token
+        method: POST
+      - url: http://local:8080/api/{{.data.project}}
+        method: GET
+        headers:
+          - key: Authorization
+            value: "Bearer {{.responses.0.body.access_token}}"
+      - url: http://local:8080/api/{{(index .responses 1).body.id}}
+        method: GET
+        options: --connect-timeout 5`
+
 func newWebhookTriggeredEvent(filename string) models.KeptnContextExtendedCE {
 	content, err := ioutil.ReadFile(filename)
 	if err != nil {
@@ -331,7 +417,7 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -369,7 +455,7 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -405,6 +491,362 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 
 }
 
+func Test_HandleIncomingTriggeredEvent_HTTPExecutor(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+
+	secretReaderMock := &fake.ISecretReaderMock{}
+	secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
+		return "my-secret-value", nil
+	}
+
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
+		return "success", nil
+	}
+
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
+	}
+
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock, handler.WithHTTPExecutor(httpExecutorMock))
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithHTTPExecutor_BETA})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+
+	// requests without curl options are executed by the HTTP executor
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+	require.Equal(t, lib.Request{
+		URL:     "http://local:8080/myproject",
+		Method:  "POST",
+		Timeout: "10s",
+		Headers: []lib.Header{{Key: "x-token", Value: "my-secret-value"}},
+		Payload: `{"project": "myproject", "command": "$(echo 1) | cat"}`,
+	}, httpExecutorMock.ExecuteCalls()[0].Request)
+	require.Equal(t, "curl --request GET --connect-timeout 5 http://local:8080/myproject", curlExecutorMock.CurlCalls()[0].CurlCmd)
+
+	//verify sent events
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 0, keptnv2.GetStartedEventType("webhook"))
+	fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+}
+
+func Test_HandleIncomingTriggeredEvent_ExecutionHistory(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(ref lib.WebHookSecretRef) (string, error) {
+		return "my-secret-value", nil
+	}}
+	curlExecutorMock := &fake.ICurlExecutorMock{CurlFunc: func(curlCmd string) (string, error) {
+		return "", errors.New("token my-secret-value has been rejected")
+	}}
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	executionStore := lib.NewInMemoryExecutionStore(10)
+
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock, handler.WithHTTPExecutor(httpExecutorMock), handler.WithExecutionStore(executionStore))
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithHTTPExecutor_BETA})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	require.Eventually(t, func() bool {
+		return len(executionStore.List(lib.ExecutionFilter{SubscriptionID: "my-subscription-id"})) == 1
+	}, 30*time.Second, time.Millisecond*10)
+
+	record := executionStore.List(lib.ExecutionFilter{SubscriptionID: "my-subscription-id"})[0]
+	assert.NotEmpty(t, record.ID)
+	assert.Equal(t, "myproject", record.Project)
+	assert.Equal(t, "sh.keptn.event.webhook.triggered", record.EventType)
+	assert.Equal(t, lib.ExecutionErrored, record.Status)
+	assert.NotContains(t, record.Message, "my-secret-value")
+
+	require.Len(t, record.Requests, 2)
+	assert.Equal(t, "POST", record.Requests[0].Method)
+	assert.Equal(t, "http://local:8080/myproject", record.Requests[0].URL)
+	assert.Equal(t, 200, record.Requests[0].StatusCode)
+	assert.Empty(t, record.Requests[0].Error)
+	assert.Equal(t, "curl --request GET --connect-timeout 5 http://local:8080/myproject", record.Requests[1].Command)
+	assert.Contains(t, record.Requests[1].Error, "token *** has been rejected")
+}
+
+func Test_HandleIncomingTriggeredEvent_ResponseOutputs(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseBody string
+		wantStatus   keptnv2.StatusType
+		wantResult   keptnv2.ResultType
+		wantData     map[string]interface{}
+	}{
+		{
+			name:         "outputs are added to finished event",
+			responseBody: `{"id": "job-1", "status": "queued"}`,
+			wantStatus:   keptnv2.StatusSucceeded,
+			wantResult:   keptnv2.ResultPass,
+			wantData: map[string]interface{}{
+				"jobId":     "job-1",
+				"responses": []interface{}{`{"id": "job-1", "status": "queued"}`},
+			},
+		},
+		{
+			name:         "success condition not fulfilled",
+			responseBody: `{"id": "job-1", "status": "rejected"}`,
+			wantStatus:   keptnv2.StatusErrored,
+			wantResult:   keptnv2.ResultFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+				tplE := &lib.TemplateEngine{}
+				return tplE.ParseTemplate(data, templateStr)
+			}}
+			httpExecutorMock := &fake.IHTTPExecutorMock{}
+			httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+				return &lib.HTTPResponse{StatusCode: 200, Body: tt.responseBody}, nil
+			}
+			requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+				return nil
+			}}
+
+			taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, requestValidatorMock, &fake.ISecretReaderMock{}, handler.WithHTTPExecutor(httpExecutorMock))
+
+			fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+			fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithResponseOutputs_BETA})
+			fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+			fakeKeptn.SetAutomaticResponse(false)
+
+			fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+			require.Eventually(t, func() bool { return len(fakeKeptn.SentEvents) == 2 }, 30*time.Second, time.Millisecond*10)
+
+			fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+			fakeKeptn.AssertSentEventStatus(t, 1, tt.wantStatus)
+			fakeKeptn.AssertSentEventResult(t, 1, tt.wantResult)
+			if tt.wantData != nil {
+				eventData := map[string]interface{}{}
+				require.NoError(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData))
+				require.Equal(t, tt.wantData, eventData["webhook"])
+			}
+		})
+	}
+}
+
+func Test_HandleIncomingTriggeredEvent_Polling(t *testing.T) {
+	tests := []struct {
+		name        string
+		finalState  string
+		wantStatus  keptnv2.StatusType
+		wantResult  keptnv2.ResultType
+		wantOutputs map[string]interface{}
+	}{
+		{
+			name:       "job succeeds",
+			finalState: `{"state": "success", "report": "http://report"}`,
+			wantStatus: keptnv2.StatusSucceeded,
+			wantResult: keptnv2.ResultPass,
+			wantOutputs: map[string]interface{}{
+				"jobId":     "job-1",
+				"reportURL": "http://report",
+				"responses": []interface{}{`{"state": "success", "report": "http://report"}`},
+			},
+		},
+		{
+			name:       "job fails",
+			finalState: `{"state": "failed", "report": "http://report"}`,
+			wantStatus: keptnv2.StatusSucceeded,
+			wantResult: keptnv2.ResultFailed,
+			wantOutputs: map[string]interface{}{
+				"jobId":     "job-1",
+				"reportURL": "http://report",
+				"responses": []interface{}{`{"state": "failed", "report": "http://report"}`},
+			},
+		},
+		{
+			name:       "job does not complete",
+			finalState: `{"state": "running"}`,
+			wantStatus: keptnv2.StatusErrored,
+			wantResult: keptnv2.ResultFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+				tplE := &lib.TemplateEngine{}
+				return tplE.ParseTemplate(data, templateStr)
+			}}
+			httpExecutorMock := &fake.IHTTPExecutorMock{}
+			httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+				switch {
+				case request.URL == "http://local:8080/jobs":
+					return &lib.HTTPResponse{StatusCode: 201, Body: `{"id": "job-1"}`}, nil
+				case request.URL == "http://local:8080/jobs/job-1" && len(httpExecutorMock.ExecuteCalls()) < 4:
+					return &lib.HTTPResponse{StatusCode: 200, Body: `{"state": "running"}`}, nil
+				case request.URL == "http://local:8080/jobs/job-1":
+					return &lib.HTTPResponse{StatusCode: 200, Body: tt.finalState}, nil
+				}
+				return nil, errors.New("unexpected request")
+			}
+			requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+				return nil
+			}}
+
+			taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, requestValidatorMock, &fake.ISecretReaderMock{}, handler.WithHTTPExecutor(httpExecutorMock))
+
+			fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+			fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithPolling_BETA})
+			fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+			fakeKeptn.SetAutomaticResponse(false)
+
+			fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+			require.Eventually(t, func() bool { return len(fakeKeptn.SentEvents) == 2 }, 30*time.Second, time.Millisecond*10)
+
+			// the webhook service sends the .finished event, since the status of the job is polled
+			fakeKeptn.AssertSentEventType(t, 0, keptnv2.GetStartedEventType("webhook"))
+			fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+			fakeKeptn.AssertSentEventStatus(t, 1, tt.wantStatus)
+			fakeKeptn.AssertSentEventResult(t, 1, tt.wantResult)
+			require.Greater(t, len(httpExecutorMock.ExecuteCalls()), 3)
+			if tt.wantOutputs != nil {
+				eventData := map[string]interface{}{}
+				require.NoError(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData))
+				require.Equal(t, tt.wantOutputs, eventData["webhook"])
+			}
+		})
+	}
+}
+
+func Test_HandleIncomingTriggeredEvent_PollingRetriesTransientErrors(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		switch {
+		case request.URL == "http://local:8080/jobs":
+			return &lib.HTTPResponse{StatusCode: 201, Body: `{"id": "job-1"}`}, nil
+		case request.URL == "http://local:8080/jobs/job-1" && len(httpExecutorMock.ExecuteCalls()) < 4:
+			return nil, lib.NewCurlError(errors.New("connection refused"), lib.RequestError)
+		case request.URL == "http://local:8080/jobs/job-1":
+			return &lib.HTTPResponse{StatusCode: 200, Body: `{"state": "success", "report": "http://report"}`}, nil
+		}
+		return nil, errors.New("unexpected request")
+	}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+
+	taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, requestValidatorMock, &fake.ISecretReaderMock{}, handler.WithHTTPExecutor(httpExecutorMock))
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithPolling_BETA})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	require.Eventually(t, func() bool { return len(fakeKeptn.SentEvents) == 2 }, 30*time.Second, time.Millisecond*10)
+
+	fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 4)
+}
+
+func Test_HandleIncomingTriggeredEvent_PollingAbortedByContext(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		if request.URL == "http://local:8080/jobs" {
+			return &lib.HTTPResponse{StatusCode: 201, Body: `{"id": "job-1"}`}, nil
+		}
+		// the service is shut down while the job is still running
+		cancel()
+		return &lib.HTTPResponse{StatusCode: 200, Body: `{"state": "running"}`}, nil
+	}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+
+	taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, requestValidatorMock, &fake.ISecretReaderMock{}, handler.WithHTTPExecutor(httpExecutorMock), handler.WithContext(ctx))
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithPolling_BETA})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	require.Eventually(t, func() bool { return len(fakeKeptn.SentEvents) == 2 }, 30*time.Second, time.Millisecond*10)
+
+	fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 2)
+}
+
+func Test_HandleIncomingTriggeredEvent_ChainedRequests(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		if request.URL == "http://local:8080/oauth/token" {
+			return &lib.HTTPResponse{StatusCode: 200, Body: `{"access_token": "my-token"}`}, nil
+		}
+		return &lib.HTTPResponse{StatusCode: 200, Body: `{"id": "my-id"}`}, nil
+	}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
+		return "success", nil
+	}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, &fake.ISecretReaderMock{}, handler.WithHTTPExecutor(httpExecutorMock))
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithChainedRequests_BETA})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	require.Eventually(t, func() bool { return len(fakeKeptn.SentEvents) == 2 }, 30*time.Second, time.Millisecond*10)
+
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 2)
+	require.Equal(t, []lib.Header{{Key: "Authorization", Value: "Bearer my-token"}}, httpExecutorMock.ExecuteCalls()[1].Request.Headers)
+	require.Len(t, curlExecutorMock.CurlCalls(), 1)
+	require.Equal(t, "curl --request GET --connect-timeout 5 http://local:8080/api/my-id", curlExecutorMock.CurlCalls()[0].CurlCmd)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+}
+
 func Test_HandleIncomingStartedEvent(t *testing.T) {
 
 	t.Run("Test_HandleIncomingStartedEvent - ALPHA", func(t *testing.T) {
@@ -414,7 +856,7 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -446,7 +888,7 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -455,7 +897,7 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 			return "success", nil
 		}
 
-		requestValidatorMock := &fake.RequestValidatorMock{func(request lib.Request) error {
+		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
@@ -484,7 +926,7 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -519,7 +961,7 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -558,7 +1000,7 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -592,7 +1034,7 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -632,7 +1074,7 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -667,7 +1109,7 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -707,7 +1149,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -747,7 +1189,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -794,7 +1236,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -837,7 +1279,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -884,7 +1326,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -922,7 +1364,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -965,7 +1407,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1015,7 +1457,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1070,7 +1512,7 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1108,7 +1550,7 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1234,7 +1676,7 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 	t.Run("TestTaskHandler_CannotReadSecret - ALPHA", func(t *testing.T) {
 		templateEngineMock := &fake.ITemplateEngineMock{}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "", errors.New("unable to read secret :(")
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1261,8 +1703,8 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 	t.Run("TestTaskHandler_CannotReadSecret - BETA", func(t *testing.T) {
 		templateEngineMock := &fake.ITemplateEngineMock{}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
-			return "", errors.New("unable to read secret :(")
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
+			return "", errors.New("secret 'mysecret' does not exist")
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
@@ -1285,6 +1727,11 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 		fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
 		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
 		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+
+		eventData := keptnv2.EventData{}
+		require.NoError(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData))
+		assert.Contains(t, eventData.Message, "could not read secret")
+		assert.Contains(t, eventData.Message, "secret 'mysecret' does not exist")
 	})
 
 }
@@ -1296,7 +1743,7 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1332,7 +1779,7 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1374,7 +1821,7 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1412,7 +1859,7 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1454,7 +1901,7 @@ func TestTaskHandler_RequestValidatorFails(t *testing.T) {
 		return tplE.ParseTemplate(data, templateStr)
 	}}
 	secretReaderMock := &fake.ISecretReaderMock{}
-	secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+	secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 		return "my-secret-value", nil
 	}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1495,7 +1942,7 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1537,7 +1984,7 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1585,7 +2032,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1638,7 +2085,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1697,7 +2144,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1762,7 +2209,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1832,7 +2279,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1903,7 +2350,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(ref lib.WebHookSecretRef) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
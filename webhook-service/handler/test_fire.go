@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+)
+
+// secretPlaceholder replaces the values of secrets in the requests reported by TestFire
+const secretPlaceholder = "***"
+
+// TestFire loads the webhook with the given subscription ID the same way it is loaded for incoming events, and renders its requests using the sample event.
+// The rendered requests are validated against the deny list and, if requested, executed. In contrast to the handling of incoming events, no Keptn events are sent
+// and the status of requests with a poll section is not polled
+func (th *TaskHandler) TestFire(resourceHandler sdk.ResourceHandler, testRequest lib.TestFireRequest) (*lib.TestFireResult, error) {
+	if testRequest.SubscriptionID == "" {
+		return nil, fmt.Errorf("%w: subscriptionID must be set", lib.ErrInvalidTestFireRequest)
+	}
+	event, err := newSampleEvent(testRequest)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", lib.ErrInvalidTestFireRequest, err.Error())
+	}
+	eventAdapter, err := lib.NewEventDataAdapter(event)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", lib.ErrInvalidTestFireRequest, err.Error())
+	}
+
+	result := &lib.TestFireResult{Requests: []lib.TestFireRequestResult{}}
+	webhook, err := th.getWebHookConfig(resourceHandler, eventAdapter, testRequest.SubscriptionID, testRequest.GitCommitID)
+	if err != nil {
+		result.Error = fmt.Sprintf("could not retrieve Webhook config: %s", err.Error())
+		return result, nil
+	}
+	result.Type = webhook.Type
+
+	secretEnvVars, err := th.gatherSecretEnvVars(*webhook)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	eventAdapter.Add("env", secretEnvVars)
+	// the requests reported in the result are rendered with placeholders instead of the values of the secrets, since the values might
+	// be transformed by template functions and could therefore not be removed from the rendered requests
+	placeholderEnvVars := map[string]string{}
+	for name := range secretEnvVars {
+		placeholderEnvVars[name] = secretPlaceholder
+	}
+
+	result.Valid = true
+	previousResponses := []interface{}{}
+	for _, req := range webhook.Requests {
+		eventAdapter.Add(lib.ResponsesKey, previousResponses)
+		previewData := map[string]interface{}{}
+		for key, value := range eventAdapter.Get() {
+			previewData[key] = value
+		}
+		previewData["env"] = placeholderEnvVars
+		requestResult := th.testFireRequest(req, eventAdapter, previewData, testRequest.Execute)
+		result.Requests = append(result.Requests, redactTestFireRequestResult(requestResult, secretEnvVars))
+		if requestResult.Error != "" {
+			result.Valid = false
+			if testRequest.Execute {
+				// subsequent requests might depend on the response of the failed request
+				break
+			}
+			continue
+		}
+		if requestResult.Response != nil {
+			outputs := requestResult.Outputs
+			if outputs == nil {
+				outputs = map[string]interface{}{}
+			}
+			previousResponses = append(previousResponses, map[string]interface{}{
+				"statusCode": requestResult.Response.StatusCode,
+				"body":       lib.ParseResponseBody(requestResult.Response.Body),
+				"outputs":    outputs,
+			})
+		}
+	}
+	return result, nil
+}
+
+// testFireRequest renders the given request and, if requested, executes it. The result contains the request rendered with the given preview data,
+// while the request is validated and executed as rendered with the data of the event adapter
+func (th *TaskHandler) testFireRequest(req interface{}, eventAdapter *lib.EventDataAdapter, previewData map[string]interface{}, execute bool) lib.TestFireRequestResult {
+	result := lib.TestFireRequestResult{}
+	var response *lib.HTTPResponse
+	if th.shouldUseHTTPExecutor(req) {
+		request := lib.ConvertToRequest(req)
+		renderedRequest, err := th.renderRequest(request, eventAdapter.Get())
+		if err != nil {
+			result.Error = fmt.Sprintf("could not parse request '%s' : %s", request.URL, err.Error())
+			return result
+		}
+		previewRequest, err := th.renderRequest(request, previewData)
+		if err != nil {
+			// template functions might not accept the placeholders, e.g. 'b64dec', in which case the templates are reported as they are
+			previewRequest = request
+		}
+		result.Method = previewRequest.Method
+		result.URL = previewRequest.URL
+		result.Headers = previewRequest.Headers
+		result.Payload = previewRequest.Payload
+		if err := th.requestValidator.Validate(renderedRequest); err != nil {
+			result.Error = fmt.Sprintf("request '%s' is not allowed: %s", request.URL, strings.ReplaceAll(err.Error(), renderedRequest.URL, previewRequest.URL))
+			return result
+		}
+		if !execute {
+			return result
+		}
+		result.Executed = true
+		response, err = th.httpExecutor.Execute(renderedRequest)
+		if err != nil {
+			result.Error = fmt.Sprintf("could not execute request '%s': %s", request.URL, strings.ReplaceAll(err.Error(), renderedRequest.URL, previewRequest.URL))
+			return result
+		}
+	} else {
+		request, err := th.CreateRequest(req)
+		if err != nil {
+			result.Error = fmt.Sprintf("creating CURL request failed: %s", err.Error())
+			return result
+		}
+		parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), request)
+		if err != nil {
+			result.Error = fmt.Sprintf("could not parse request '%s' : %s", request, err.Error())
+			return result
+		}
+		previewCommand, err := th.templateEngine.ParseTemplate(previewData, request)
+		if err != nil {
+			previewCommand = request
+		}
+		result.Command = previewCommand
+		if !execute {
+			return result
+		}
+		result.Executed = true
+		curlResponse, err := th.curlExecutor.Curl(parsedCurlCommand)
+		if err != nil {
+			result.Error = fmt.Sprintf("could not execute request '%s': %s", request, strings.ReplaceAll(err.Error(), parsedCurlCommand, previewCommand))
+			return result
+		}
+		response = &lib.HTTPResponse{Body: curlResponse}
+	}
+
+	result.Response = response
+	outputs, err := th.evaluateResponse(req, response)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Outputs = outputs
+	return result
+}
+
+// newSampleEvent creates the event used to render the webhook requests, using the project, stage and service of the given test request
+func newSampleEvent(testRequest lib.TestFireRequest) (sdk.KeptnEvent, error) {
+	event := sdk.KeptnEvent(testRequest.Event)
+	data := map[string]interface{}{}
+	if event.Data != nil {
+		if err := keptnv2.Decode(event.Data, &data); err != nil {
+			return event, fmt.Errorf("could not decode event data: %w", err)
+		}
+	}
+	if testRequest.Project != "" {
+		data["project"] = testRequest.Project
+	}
+	if testRequest.Stage != "" {
+		data["stage"] = testRequest.Stage
+	}
+	if testRequest.Service != "" {
+		data["service"] = testRequest.Service
+	}
+	event.Data = data
+	if event.Type == nil {
+		eventType := ""
+		event.Type = &eventType
+	}
+	return event, nil
+}
+
+// redactTestFireRequestResult removes the values of the given secrets from the rendered request and its response. The requests are rendered with
+// placeholders for the secrets already, this is a safeguard for values that are not rendered from templates, e.g. error messages and responses
+func redactTestFireRequestResult(result lib.TestFireRequestResult, secrets map[string]string) lib.TestFireRequestResult {
+	result.URL = removeSecretsFromMessage(result.URL, secrets)
+	result.Payload = removeSecretsFromMessage(result.Payload, secrets)
+	result.Command = removeSecretsFromMessage(result.Command, secrets)
+	result.Error = removeSecretsFromMessage(result.Error, secrets)
+	if len(result.Headers) > 0 {
+		headers := make([]lib.Header, 0, len(result.Headers))
+		for _, header := range result.Headers {
+			headers = append(headers, lib.Header{Key: header.Key, Value: removeSecretsFromMessage(header.Value, secrets)})
+		}
+		result.Headers = headers
+	}
+	if result.Response != nil {
+		result.Response = &lib.HTTPResponse{StatusCode: result.Response.StatusCode, Body: removeSecretsFromMessage(result.Response.Body, secrets)}
+	}
+	return result
+}
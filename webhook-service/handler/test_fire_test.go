@@ -0,0 +1,245 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/handler"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const invalidWebHookContent = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+      - url: http://local:8080
+        method: GET
+        timeout: not-a-duration`
+
+const webHookContentWithTransformedSecrets = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      envFrom:
+        - secretRef:
+          name: mysecret
+      requests:
+      - url: http://local:8080/{{ upper .env.mysecret }}
+        method: POST
+        headers:
+          - key: Authorization
+            value: "Basic {{ b64enc .env.mysecret }}"
+          - key: x-signature
+            value: "{{ sha256 .env.mysecret }}"
+        payload: '{"token": {{ quote .env.mysecret }}}'
+      - url: http://local:8080/{{ b64dec .env.mysecret }}
+        method: GET
+      - url: http://local:8080/{{ b64enc .env.mysecret }}
+        method: GET
+        options: --connect-timeout 5`
+
+func TestTaskHandler_TestFire(t *testing.T) {
+	eventType := "sh.keptn.event.webhook.triggered"
+	sampleEvent := models.KeptnContextExtendedCE{Type: &eventType, Data: map[string]interface{}{"labels": map[string]string{"owner": "team-a"}}}
+
+	tests := []struct {
+		name            string
+		webhookConfig   string
+		testRequest     lib.TestFireRequest
+		validateErr     error
+		want            *lib.TestFireResult
+		wantErr         bool
+		wantNrOfExecute int
+	}{
+		{
+			name:          "render requests without executing them",
+			webhookConfig: webHookContentWithHTTPExecutor_BETA,
+			testRequest:   lib.TestFireRequest{Project: "myproject", Stage: "mystage", Service: "myservice", SubscriptionID: "my-subscription-id", Event: sampleEvent},
+			want: &lib.TestFireResult{
+				Valid: true,
+				Type:  "sh.keptn.event.webhook.triggered",
+				Requests: []lib.TestFireRequestResult{
+					{
+						Method:  "POST",
+						URL:     "http://local:8080/myproject",
+						Headers: []lib.Header{{Key: "x-token", Value: "***"}},
+						Payload: `{"project": "myproject", "command": "$(echo 1) | cat"}`,
+					},
+					{
+						Command: "curl --request GET --connect-timeout 5 http://local:8080/myproject",
+					},
+				},
+			},
+		},
+		{
+			name:          "execute requests",
+			webhookConfig: webHookContentWithHTTPExecutor_BETA,
+			testRequest:   lib.TestFireRequest{Project: "myproject", Stage: "mystage", Service: "myservice", SubscriptionID: "my-subscription-id", Event: sampleEvent, Execute: true},
+			want: &lib.TestFireResult{
+				Valid: true,
+				Type:  "sh.keptn.event.webhook.triggered",
+				Requests: []lib.TestFireRequestResult{
+					{
+						Method:   "POST",
+						URL:      "http://local:8080/myproject",
+						Headers:  []lib.Header{{Key: "x-token", Value: "***"}},
+						Payload:  `{"project": "myproject", "command": "$(echo 1) | cat"}`,
+						Executed: true,
+						Response: &lib.HTTPResponse{StatusCode: 200, Body: "token *** accepted"},
+					},
+					{
+						Command:  "curl --request GET --connect-timeout 5 http://local:8080/myproject",
+						Executed: true,
+						Response: &lib.HTTPResponse{Body: "success"},
+					},
+				},
+			},
+			wantNrOfExecute: 1,
+		},
+		{
+			name:          "denied request",
+			webhookConfig: webHookContentWithHTTPExecutor_BETA,
+			testRequest:   lib.TestFireRequest{Project: "myproject", Stage: "mystage", Service: "myservice", SubscriptionID: "my-subscription-id", Event: sampleEvent, Execute: true},
+			validateErr:   errors.New("denied"),
+			want: &lib.TestFireResult{
+				Valid: false,
+				Type:  "sh.keptn.event.webhook.triggered",
+				Requests: []lib.TestFireRequestResult{
+					{
+						Method:  "POST",
+						URL:     "http://local:8080/myproject",
+						Headers: []lib.Header{{Key: "x-token", Value: "***"}},
+						Payload: `{"project": "myproject", "command": "$(echo 1) | cat"}`,
+						Error:   "request 'http://local:8080/{{.data.project}}' is not allowed: denied",
+					},
+				},
+			},
+		},
+		{
+			name:          "invalid webhook config",
+			webhookConfig: invalidWebHookContent,
+			testRequest:   lib.TestFireRequest{Project: "myproject", Stage: "mystage", Service: "myservice", SubscriptionID: "my-subscription-id", Event: sampleEvent},
+			want: &lib.TestFireResult{
+				Valid:    false,
+				Error:    "could not retrieve Webhook config: no webhook config found, skipped invalid webhook configs: service level: Webhook configuration invalid: invalid webhook request timeout 'not-a-duration'; stage level: Webhook configuration invalid: invalid webhook request timeout 'not-a-duration'; project level: Webhook configuration invalid: invalid webhook request timeout 'not-a-duration'",
+				Requests: []lib.TestFireRequestResult{},
+			},
+		},
+		{
+			name:          "missing subscriptionID",
+			webhookConfig: webHookContentWithHTTPExecutor_BETA,
+			testRequest:   lib.TestFireRequest{Project: "myproject", Stage: "mystage", Service: "myservice", Event: sampleEvent},
+			wantErr:       true,
+		},
+		{
+			name:          "missing service",
+			webhookConfig: webHookContentWithHTTPExecutor_BETA,
+			testRequest:   lib.TestFireRequest{Project: "myproject", Stage: "mystage", SubscriptionID: "my-subscription-id", Event: sampleEvent},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+				tplE := &lib.TemplateEngine{}
+				return tplE.ParseTemplate(data, templateStr)
+			}}
+			secretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(ref lib.WebHookSecretRef) (string, error) {
+				return "my-secret-value", nil
+			}}
+			curlExecutorMock := &fake.ICurlExecutorMock{CurlFunc: func(curlCmd string) (string, error) {
+				return "success", nil
+			}}
+			httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+				return &lib.HTTPResponse{StatusCode: 200, Body: "token my-secret-value accepted"}, nil
+			}}
+			requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+				return tt.validateErr
+			}}
+			taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock, handler.WithHTTPExecutor(httpExecutorMock))
+
+			result, err := taskHandler.TestFire(sdk.StringResourceHandler{ResourceContent: tt.webhookConfig}, tt.testRequest)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, lib.ErrInvalidTestFireRequest))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+			assert.Len(t, httpExecutorMock.ExecuteCalls(), tt.wantNrOfExecute)
+		})
+	}
+}
+
+func TestTaskHandler_TestFire_SecretsTransformedByTemplateFunctions(t *testing.T) {
+	eventType := "sh.keptn.event.webhook.triggered"
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(ref lib.WebHookSecretRef) (string, error) {
+		// base64 encoded 'my-secret-value'
+		return "bXktc2VjcmV0LXZhbHVl", nil
+	}}
+	curlExecutorMock := &fake.ICurlExecutorMock{CurlFunc: func(curlCmd string) (string, error) {
+		return "", errors.New("could not execute " + curlCmd)
+	}}
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 200, Body: "ok"}, nil
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock, handler.WithHTTPExecutor(httpExecutorMock))
+
+	result, err := taskHandler.TestFire(sdk.StringResourceHandler{ResourceContent: webHookContentWithTransformedSecrets}, lib.TestFireRequest{
+		Project:        "myproject",
+		Stage:          "mystage",
+		Service:        "myservice",
+		SubscriptionID: "my-subscription-id",
+		Event:          models.KeptnContextExtendedCE{Type: &eventType},
+		Execute:        true,
+	})
+	require.NoError(t, err)
+
+	// the requests are executed with the values of the secrets
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 2)
+	require.Equal(t, "http://local:8080/BXKTC2VJCMV0LXZHBHVL", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+	require.Equal(t, "Basic YlhrdGMyVmpjbVYwTFhaaGJIVmw=", httpExecutorMock.ExecuteCalls()[0].Request.Headers[0].Value)
+	require.Equal(t, "http://local:8080/my-secret-value", httpExecutorMock.ExecuteCalls()[1].Request.URL)
+	require.Len(t, curlExecutorMock.CurlCalls(), 1)
+
+	// the result contains the requests rendered with placeholders
+	require.Len(t, result.Requests, 3)
+	assert.Equal(t, "http://local:8080/***", result.Requests[0].URL)
+	assert.Equal(t, []lib.Header{
+		{Key: "Authorization", Value: "Basic Kioq"},
+		{Key: "x-signature", Value: "596f4162a52f315b2ad0fa53fd30a2769d02a41ed7439123790966eee4ceb5cd"},
+	}, result.Requests[0].Headers)
+	assert.Equal(t, `{"token": "***"}`, result.Requests[0].Payload)
+	// the placeholder cannot be decoded, therefore the template is reported
+	assert.Equal(t, "http://local:8080/{{ b64dec .env.mysecret }}", result.Requests[1].URL)
+	assert.Equal(t, "curl --request GET --connect-timeout 5 http://local:8080/Kioq", result.Requests[2].Command)
+	assert.Equal(t, "could not execute request 'curl --request GET --connect-timeout 5 http://local:8080/{{ b64enc .env.mysecret }}': could not execute curl --request GET --connect-timeout 5 http://local:8080/Kioq", result.Requests[2].Error)
+
+	encoded, err := json.Marshal(result)
+	require.NoError(t, err)
+	for _, secret := range []string{"bXktc2VjcmV0LXZhbHVl", "my-secret-value", "BXKTC2VJCMV0LXZHBHVL", "YlhrdGMyVmpjbVYwTFhaaGJIVmw"} {
+		assert.NotContains(t, string(encoded), secret)
+	}
+}
@@ -6,9 +6,13 @@ import (
 )
 
 const (
-	WebhookConfigMap        = "keptn-webhook-config"
-	KubernetesSvcHostEnvVar = "KUBERNETES_SERVICE_HOST"
-	KubernetesAPIPortEnvVar = "KUBERNETES_SERVICE_PORT"
+	WebhookConfigMap = "keptn-webhook-config"
+	// ExecutionHistoryConfigMap is the ConfigMap in which the execution records of the webhooks are persisted
+	ExecutionHistoryConfigMap = "keptn-webhook-executions"
+	KubernetesSvcHostEnvVar   = "KUBERNETES_SERVICE_HOST"
+	KubernetesAPIPortEnvVar   = "KUBERNETES_SERVICE_PORT"
+	// ResponsesKey is the property of the finished event data that contains the responses of the webhook requests
+	ResponsesKey = "responses"
 )
 
 type AdrDomainNameMapping map[string][]string
@@ -0,0 +1,196 @@
+package lib
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// maxExecutionHistoryDataSize limits the size of the persisted records, since the size of a ConfigMap must not exceed 1MiB
+const maxExecutionHistoryDataSize = 900 * 1024
+
+const defaultExecutionHistoryFlushInterval = 5 * time.Second
+
+// ConfigMapExecutionStore persists the most recent execution records of each subscription in the keptn-webhook-executions ConfigMap,
+// i.e., the records are kept when the webhook service is restarted. The records of each subscription are stored as JSON list in
+// the entry named after the hash of the subscription ID. If the records exceed the size limit of the ConfigMap, the oldest ones are removed.
+// Added records are written to the ConfigMap in batches by Run, so that the execution of webhooks does not wait for the Kubernetes API
+type ConfigMapExecutionStore struct {
+	*InMemoryExecutionStore
+	kubeClient    kubernetes.Interface
+	flushInterval time.Duration
+	// mutex guards dirty and the consistency of the records while they are serialized
+	mutex sync.Mutex
+	dirty bool
+	// flushMutex ensures that the ConfigMap is not written concurrently, since an older state could overwrite a newer one
+	flushMutex sync.Mutex
+	changed    chan struct{}
+}
+
+type ConfigMapExecutionStoreOption func(store *ConfigMapExecutionStore)
+
+// WithFlushInterval sets the duration for which added records are collected before they are written to the ConfigMap
+func WithFlushInterval(flushInterval time.Duration) ConfigMapExecutionStoreOption {
+	return func(store *ConfigMapExecutionStore) {
+		store.flushInterval = flushInterval
+	}
+}
+
+// NewConfigMapExecutionStore creates a store containing the records that have been persisted previously
+func NewConfigMapExecutionStore(kubeClient kubernetes.Interface, maxRecordsPerSubscription int, opts ...ConfigMapExecutionStoreOption) *ConfigMapExecutionStore {
+	store := &ConfigMapExecutionStore{
+		InMemoryExecutionStore: NewInMemoryExecutionStore(maxRecordsPerSubscription),
+		kubeClient:             kubeClient,
+		flushInterval:          defaultExecutionHistoryFlushInterval,
+		changed:                make(chan struct{}, 1),
+	}
+	for _, o := range opts {
+		o(store)
+	}
+	store.load()
+	return store
+}
+
+// Add stores the record in memory, it is written to the ConfigMap with the next flush
+func (s *ConfigMapExecutionStore) Add(record ExecutionRecord) {
+	s.mutex.Lock()
+	s.InMemoryExecutionStore.Add(record)
+	s.dirty = true
+	s.mutex.Unlock()
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+		// a flush is already pending
+	}
+}
+
+// Run writes the added records to the ConfigMap, at most once per flush interval, until the context is done.
+// Records that have not been written yet are flushed before Run returns
+func (s *ConfigMapExecutionStore) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			s.Flush()
+			return
+		case <-s.changed:
+		}
+
+		timer := time.NewTimer(s.flushInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		s.Flush()
+	}
+}
+
+// Flush writes the records to the ConfigMap, if records have been added since the last flush
+func (s *ConfigMapExecutionStore) Flush() {
+	s.flushMutex.Lock()
+	defer s.flushMutex.Unlock()
+
+	s.mutex.Lock()
+	if !s.dirty {
+		s.mutex.Unlock()
+		return
+	}
+	data, err := s.serialize()
+	s.dirty = false
+	s.mutex.Unlock()
+	if err != nil {
+		logger.Errorf("Unable to serialize execution records: %s", err.Error())
+		return
+	}
+
+	if err := s.persist(data); err != nil {
+		logger.Errorf("Unable to persist execution records in ConfigMap %s: %s", ExecutionHistoryConfigMap, err.Error())
+		// the records are written with the next flush
+		s.mutex.Lock()
+		s.dirty = true
+		s.mutex.Unlock()
+	}
+}
+
+func (s *ConfigMapExecutionStore) load() {
+	configMap, err := s.configMaps().Get(context.TODO(), ExecutionHistoryConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Errorf("Unable to get ConfigMap %s content: %s", ExecutionHistoryConfigMap, err.Error())
+		}
+		return
+	}
+	for key, data := range configMap.Data {
+		records := []ExecutionRecord{}
+		if err := json.Unmarshal([]byte(data), &records); err != nil {
+			logger.Errorf("Unable to parse execution records of entry %s: %s", key, err.Error())
+			continue
+		}
+		for _, record := range records {
+			s.InMemoryExecutionStore.Add(record)
+		}
+	}
+}
+
+// serialize encodes the records of each subscription, removing the oldest records until the size limit is met
+func (s *ConfigMapExecutionStore) serialize() (map[string]string, error) {
+	for {
+		data := map[string]string{}
+		size := 0
+		for subscriptionID, records := range s.InMemoryExecutionStore.snapshot() {
+			encoded, err := json.Marshal(records)
+			if err != nil {
+				return nil, err
+			}
+			key := executionHistoryKey(subscriptionID)
+			data[key] = string(encoded)
+			size += len(key) + len(encoded)
+		}
+		if size <= maxExecutionHistoryDataSize {
+			return data, nil
+		}
+		s.InMemoryExecutionStore.removeOldest()
+	}
+}
+
+// executionHistoryKey returns the key of the ConfigMap entry containing the records of the subscription. The subscription ID is hashed,
+// since it is not validated and might contain characters that are not allowed in keys of a ConfigMap
+func executionHistoryKey(subscriptionID string) string {
+	hash := sha256.Sum256([]byte(subscriptionID))
+	return hex.EncodeToString(hash[:])
+}
+
+func (s *ConfigMapExecutionStore) persist(data map[string]string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := s.configMaps().Get(context.TODO(), ExecutionHistoryConfigMap, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			_, err = s.configMaps().Create(context.TODO(), &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: ExecutionHistoryConfigMap, Namespace: GetNamespaceFromEnvVar()},
+				Data:       data,
+			}, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		configMap.Data = data
+		_, err = s.configMaps().Update(context.TODO(), configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (s *ConfigMapExecutionStore) configMaps() corev1client.ConfigMapInterface {
+	return s.kubeClient.CoreV1().ConfigMaps(GetNamespaceFromEnvVar())
+}
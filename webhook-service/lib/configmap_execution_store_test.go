@@ -0,0 +1,128 @@
+package lib
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapExecutionStore_KeepsRecordsAfterRestart(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	start := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset()
+
+	store := NewConfigMapExecutionStore(client, 2)
+	store.Add(ExecutionRecord{ID: "1", Time: start, SubscriptionID: "sub-1", Project: "podtato", Status: ExecutionSucceeded})
+	store.Add(ExecutionRecord{ID: "2", Time: start.Add(1 * time.Minute), SubscriptionID: "sub-2", Project: "sockshop", Status: ExecutionFailed})
+	store.Add(ExecutionRecord{ID: "3", Time: start.Add(2 * time.Minute), SubscriptionID: "sub-1", Project: "podtato", Status: ExecutionErrored})
+	store.Flush()
+
+	configMap, err := client.CoreV1().ConfigMaps("keptn").Get(context.TODO(), ExecutionHistoryConfigMap, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, configMap.Data, 2)
+
+	restarted := NewConfigMapExecutionStore(client, 2)
+	records := restarted.List(ExecutionFilter{})
+	require.Len(t, records, 3)
+	require.Equal(t, "3", records[0].ID)
+	require.Equal(t, ExecutionErrored, records[0].Status)
+	require.Equal(t, "1", records[2].ID)
+
+	// the maximum number of records per subscription applies to the persisted records as well
+	restarted.Add(ExecutionRecord{ID: "4", Time: start.Add(3 * time.Minute), SubscriptionID: "sub-1", Project: "podtato"})
+	restarted.Flush()
+	records = NewConfigMapExecutionStore(client, 2).List(ExecutionFilter{SubscriptionID: "sub-1"})
+	require.Len(t, records, 2)
+	require.Equal(t, "4", records[0].ID)
+	require.Equal(t, "3", records[1].ID)
+}
+
+func TestConfigMapExecutionStore_RemovesOldestRecordsExceedingSizeLimit(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	start := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset()
+	message := strings.Repeat("x", maxExecutionHistoryDataSize/3)
+
+	store := NewConfigMapExecutionStore(client, 10)
+	store.Add(ExecutionRecord{ID: "1", Time: start, SubscriptionID: "sub-1", Message: message})
+	store.Add(ExecutionRecord{ID: "2", Time: start.Add(1 * time.Minute), SubscriptionID: "sub-2", Message: message})
+	store.Add(ExecutionRecord{ID: "3", Time: start.Add(2 * time.Minute), SubscriptionID: "sub-1", Message: message})
+	store.Flush()
+
+	records := NewConfigMapExecutionStore(client, 10).List(ExecutionFilter{})
+	require.Len(t, records, 2)
+	require.Equal(t, "3", records[0].ID)
+	require.Equal(t, "2", records[1].ID)
+}
+
+func TestConfigMapExecutionStore_IgnoresInvalidEntries(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ExecutionHistoryConfigMap, Namespace: "keptn"},
+		Data: map[string]string{
+			"sub-1": `[{"id": "1", "subscriptionID": "sub-1", "status": "succeeded"}]`,
+			"sub-2": `invalid`,
+		},
+	})
+
+	records := NewConfigMapExecutionStore(client, 10).List(ExecutionFilter{})
+	require.Len(t, records, 1)
+	require.Equal(t, "1", records[0].ID)
+}
+
+func TestConfigMapExecutionStore_WritesRecordsInBackground(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	client := fake.NewSimpleClientset()
+	store := NewConfigMapExecutionStore(client, 10, WithFlushInterval(10*time.Millisecond))
+
+	// adding a record does not wait for the ConfigMap to be written
+	store.Add(ExecutionRecord{ID: "1", Time: time.Now(), SubscriptionID: "sub-1"})
+	_, err := client.CoreV1().ConfigMaps("keptn").Get(context.TODO(), ExecutionHistoryConfigMap, metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		store.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(NewConfigMapExecutionStore(client, 10).List(ExecutionFilter{})) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// pending records are written when the store is stopped
+	store.Add(ExecutionRecord{ID: "2", Time: time.Now(), SubscriptionID: "sub-2"})
+	cancel()
+	<-done
+	require.Len(t, NewConfigMapExecutionStore(client, 10).List(ExecutionFilter{}), 2)
+}
+
+func TestConfigMapExecutionStore_HashesSubscriptionIDs(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	client := fake.NewSimpleClientset()
+	subscriptionID := "../my subscription?"
+
+	store := NewConfigMapExecutionStore(client, 10)
+	store.Add(ExecutionRecord{ID: "1", Time: time.Now(), SubscriptionID: subscriptionID})
+	store.Flush()
+
+	configMap, err := client.CoreV1().ConfigMaps("keptn").Get(context.TODO(), ExecutionHistoryConfigMap, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, configMap.Data, 1)
+	for key := range configMap.Data {
+		require.Empty(t, validation.IsConfigMapKey(key))
+	}
+
+	records := NewConfigMapExecutionStore(client, 10).List(ExecutionFilter{SubscriptionID: subscriptionID})
+	require.Len(t, records, 1)
+	require.Equal(t, "1", records[0].ID)
+}
@@ -1,9 +1,14 @@
 package lib
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type errType int
@@ -13,11 +18,16 @@ const (
 	InvalidCommandError
 	DeniedURLError
 	RequestError
+	CircuitOpenError
 )
 
 type CurlError struct {
 	err    error
 	reason errType
+	// statusCode is the status code of the failed request, if it has been received
+	statusCode int
+	// permanent is set for request errors that are known to not be caused by the connection to the target host, even though no status code has been received
+	permanent bool
 }
 
 func (c *CurlError) Error() string {
@@ -63,22 +73,71 @@ func IsRequestError(err error) bool {
 	return false
 }
 
+// IsCircuitOpenError determines whether the request has not been executed because the circuit breaker of its target host is open
+func IsCircuitOpenError(err error) bool {
+	var curlErr *CurlError
+	if errors.As(err, &curlErr) {
+		return curlErr.reason == CircuitOpenError
+	}
+	return false
+}
+
+// IsTransientError determines whether the request failed due to an error that might not occur again, i.e.,
+// a connection error, a timeout or a response with status code 429 or 5xx
+func IsTransientError(err error) bool {
+	var curlErr *CurlError
+	if !errors.As(err, &curlErr) || curlErr.reason != RequestError {
+		return false
+	}
+	if curlErr.statusCode == 0 {
+		return !curlErr.permanent
+	}
+	return curlErr.statusCode == http.StatusTooManyRequests || curlErr.statusCode >= http.StatusInternalServerError
+}
+
+// curlConnectionErrorCodes are the exit codes of curl that indicate a failed connection to the target host, or a timeout
+var curlConnectionErrorCodes = map[int]bool{
+	5:  true, // could not resolve proxy
+	6:  true, // could not resolve host
+	7:  true, // failed to connect to host
+	16: true, // HTTP/2 framing layer error
+	28: true, // operation timeout
+	35: true, // TLS handshake failed
+	52: true, // empty reply from server
+	55: true, // failed sending network data
+	56: true, // failure in receiving network data
+	92: true, // HTTP/2 stream error
+}
+
+// curlStatusCodeFormat is appended as --write-out option to curl commands that do not define one, in order to receive the status code of the response
+const curlStatusCodeFormat = "\\n%{http_code}"
+
 //go:generate moq  -pkg fake -out ./fake/curl_executor_mock.go . ICurlExecutor
 type ICurlExecutor interface {
 	Curl(curlCmd string) (string, error)
 }
 
 type CmdCurlExecutor struct {
+	defaultTimeout   time.Duration
 	deniedCharacters []string
 	deniedOptions    []string
 	requiredOptions  []string
 	commandExecutor  ICommandExecutor
+	hostGuard        IHostGuard
 }
 
 type CmdCurlExecutorOption func(executor *CmdCurlExecutor)
 
+// WithCurlHostGuard sets the guard that limits the rate of requests per host and fails requests to hosts with an open circuit breaker
+func WithCurlHostGuard(hostGuard IHostGuard) CmdCurlExecutorOption {
+	return func(executor *CmdCurlExecutor) {
+		executor.hostGuard = hostGuard
+	}
+}
+
 func NewCmdCurlExecutor(cmdExecutor ICommandExecutor, opts ...CmdCurlExecutorOption) *CmdCurlExecutor {
 	executor := &CmdCurlExecutor{
+		defaultTimeout:   defaultRequestTimeout,
 		deniedCharacters: []string{"$", "|", ";", ">", "$(", " &", "&&", "`", "/var/run"},
 		deniedOptions:    []string{"-o", "--output", "-F", "--form", "-T", "--upload-file", "-K", "--config"},
 		requiredOptions:  []string{"--fail-with-body"},
@@ -96,13 +155,104 @@ func (ce *CmdCurlExecutor) Curl(curlCmd string) (string, error) {
 		return "", err
 	}
 
+	host := getTargetHost(args)
+	if ce.hostGuard != nil && host != "" {
+		// do not wait for the rate limit of the host longer than the request itself may take
+		ctx, cancel := context.WithTimeout(context.Background(), ce.getTimeout(args))
+		err := ce.hostGuard.Acquire(ctx, host)
+		cancel()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	writesStatusCode := !hasOption(args, "-w", "--write-out")
+	if writesStatusCode {
+		args = append(args, "--write-out", curlStatusCodeFormat)
+	}
 	resp, err := ce.commandExecutor.ExecuteCommand("curl", args[1:]...)
+	statusCode := 0
+	if writesStatusCode {
+		resp, statusCode = splitStatusCode(resp)
+	}
+	if err != nil {
+		err = newCurlRequestError(err, resp, statusCode)
+	}
+	if ce.hostGuard != nil && host != "" {
+		ce.hostGuard.Report(host, err)
+	}
 	if err != nil {
-		return "", &CurlError{err: fmt.Errorf("error during curl request execution: %s.\nResponse: \n%s", err.Error(), resp), reason: RequestError}
+		return "", err
 	}
 	return resp, nil
 }
 
+// newCurlRequestError creates the error of a failed curl command. If the status code of the response is unknown,
+// the exit code of curl determines whether the command failed due to the connection to the target host
+func newCurlRequestError(err error, resp string, statusCode int) *CurlError {
+	curlErr := &CurlError{
+		err:        fmt.Errorf("error during curl request execution: %s.\nResponse: \n%s", err.Error(), resp),
+		reason:     RequestError,
+		statusCode: statusCode,
+	}
+	if statusCode == 0 {
+		var exitErr interface{ ExitCode() int }
+		curlErr.permanent = !errors.As(err, &exitErr) || !curlConnectionErrorCodes[exitErr.ExitCode()]
+	}
+	return curlErr
+}
+
+// splitStatusCode removes the status code written by curlStatusCodeFormat from the given output of curl, and returns it.
+// A status code of 0 is returned if no response has been received
+func splitStatusCode(resp string) (string, int) {
+	i := strings.LastIndex(resp, "\n")
+	if i < 0 {
+		return resp, 0
+	}
+	statusCode, err := strconv.Atoi(resp[i+1:])
+	if err != nil {
+		return resp, 0
+	}
+	return resp[:i], statusCode
+}
+
+// getTimeout returns the maximum time of the given curl arguments, or the default timeout if none is set
+func (ce *CmdCurlExecutor) getTimeout(args []string) time.Duration {
+	for i, arg := range args {
+		if (arg == "-m" || arg == "--max-time") && i+1 < len(args) {
+			if seconds, err := strconv.ParseFloat(args[i+1], 64); err == nil && seconds > 0 {
+				return time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+	return ce.defaultTimeout
+}
+
+// hasOption determines whether the given curl arguments contain one of the given options
+func hasOption(args []string, options ...string) bool {
+	for _, arg := range args {
+		for _, o := range options {
+			if arg == o || strings.HasPrefix(arg, o+"=") || (len(o) == 2 && strings.HasPrefix(arg, o)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getTargetHost returns the host of the first URL contained in the given curl arguments
+func getTargetHost(args []string) string {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "http://") && !strings.HasPrefix(arg, "https://") {
+			continue
+		}
+		if parsedURL, err := url.Parse(arg); err == nil {
+			return parsedURL.Host
+		}
+	}
+	return ""
+}
+
 func (ce *CmdCurlExecutor) parseArgs(curlCmd string) ([]string, error) {
 	cmdArr := strings.Split(curlCmd, " ")
 	if len(cmdArr) == 0 || len(cmdArr) == 1 && cmdArr[0] == "" {
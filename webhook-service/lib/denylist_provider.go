@@ -3,42 +3,106 @@ package lib
 import (
 	"context"
 	"strings"
+	"sync"
+	"time"
 
 	logger "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+const defaultDenyListRefreshInterval = 10 * time.Second
+
 type DenyListProvider interface {
 	Get() []string
+	// GetAllowList returns the targets webhook requests are restricted to. If empty, every target that is not denied is allowed
+	GetAllowList() []string
 }
 
+// denyListProvider reads the deny and allow list from the keptn-webhook-config ConfigMap. The content of the ConfigMap is cached
+// and reloaded after the refresh interval, i.e., changes are applied without restarting the service
 type denyListProvider struct {
-	getDeniedURLs GetDeniedURLsFunc
-	kubeClient    kubernetes.Interface
+	getDeniedURLs   GetDeniedURLsFunc
+	kubeClient      kubernetes.Interface
+	refreshInterval time.Duration
+
+	mu              sync.Mutex
+	lastRefresh     time.Time
+	resourceVersion string
+	denyList        []string
+	allowList       []string
 }
 
 type GetDeniedURLsFunc func(env map[string]string) []string
 
-func NewDenyListProvider(kubeClient kubernetes.Interface) DenyListProvider {
-	return denyListProvider{
-		getDeniedURLs: GetDeniedURLs,
-		kubeClient:    kubeClient,
+type DenyListProviderOption func(provider *denyListProvider)
+
+// WithRefreshInterval sets the duration after which the ConfigMap is reloaded
+func WithRefreshInterval(refreshInterval time.Duration) DenyListProviderOption {
+	return func(provider *denyListProvider) {
+		provider.refreshInterval = refreshInterval
+	}
+}
+
+func NewDenyListProvider(kubeClient kubernetes.Interface, opts ...DenyListProviderOption) DenyListProvider {
+	provider := &denyListProvider{
+		getDeniedURLs:   GetDeniedURLs,
+		kubeClient:      kubeClient,
+		refreshInterval: defaultDenyListRefreshInterval,
 	}
+	for _, o := range opts {
+		o(provider)
+	}
+	return provider
 }
 
-func (d denyListProvider) Get() []string {
-	denyList := d.getDeniedURLs(GetEnv())
+func (d *denyListProvider) Get() []string {
+	d.refresh()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append(d.getDeniedURLs(GetEnv()), d.denyList...)
+}
+
+func (d *denyListProvider) GetAllowList() []string {
+	d.refresh()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string{}, d.allowList...)
+}
+
+// refresh reloads the ConfigMap if the refresh interval has elapsed. If the ConfigMap cannot be retrieved, the previously loaded lists are kept
+func (d *denyListProvider) refresh() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.lastRefresh.IsZero() && time.Since(d.lastRefresh) < d.refreshInterval {
+		return
+	}
+	d.lastRefresh = time.Now()
 
 	configMap, err := d.kubeClient.CoreV1().ConfigMaps(GetNamespaceFromEnvVar()).Get(context.TODO(), WebhookConfigMap, metav1.GetOptions{})
 	if err != nil {
 		logger.Errorf("Unable to get ConfigMap %s content: %s", WebhookConfigMap, err.Error())
-		return denyList
+		return
 	}
+	if configMap.ResourceVersion != "" && configMap.ResourceVersion == d.resourceVersion {
+		return
+	}
+	d.resourceVersion = configMap.ResourceVersion
+	d.denyList = strings.Fields(configMap.Data["denyList"])
+	d.allowList = strings.Fields(configMap.Data["allowList"])
+	logInvalidEntries("denyList", d.denyList)
+	logInvalidEntries("allowList", d.allowList)
+}
 
-	denyListString := configMap.Data["denyList"]
-	denyListConfig := strings.Fields(denyListString)
-	return append(denyList, denyListConfig...)
+func logInvalidEntries(list string, entries []string) {
+	_, errs := ParseTargetRules(entries)
+	for _, err := range errs {
+		if list == "allowList" {
+			logger.Errorf("Invalid entry in %s of ConfigMap %s, all webhook requests will be denied: %s", list, WebhookConfigMap, err.Error())
+			continue
+		}
+		logger.Errorf("Invalid entry in %s of ConfigMap %s will deny every request target containing it: %s", list, WebhookConfigMap, err.Error())
+	}
 }
 
 func GetDeniedURLs(env map[string]string) []string {
@@ -1,8 +1,10 @@
 package lib
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -57,7 +59,7 @@ func TestGetDenyList(t *testing.T) {
 	}{
 		{
 			name: "valid empty configmap",
-			denyListProvider: denyListProvider{
+			denyListProvider: &denyListProvider{
 				getDeniedURLs: func(env map[string]string) []string {
 					return []string{"1.2.3.4", "kubernetes:9876"}
 				},
@@ -74,7 +76,7 @@ func TestGetDenyList(t *testing.T) {
 		},
 		{
 			name: "valid",
-			denyListProvider: denyListProvider{
+			denyListProvider: &denyListProvider{
 				getDeniedURLs: func(env map[string]string) []string {
 					return []string{"1.2.3.4", "kubernetes:9876"}
 				},
@@ -98,3 +100,57 @@ func TestGetDenyList(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllowList(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "keptn-webhook-config",
+			},
+			Data: map[string]string{
+				"denyList":  "10.0.0.0/8",
+				"allowList": "*.example.com\n192.168.0.0/16"},
+		})
+	provider := NewDenyListProvider(client)
+
+	require.Equal(t, []string{"*.example.com", "192.168.0.0/16"}, provider.GetAllowList())
+	require.Contains(t, provider.Get(), "10.0.0.0/8")
+}
+
+func TestDenyListProvider_Reload(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "keptn-webhook-config",
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{
+			"denyList": "some-host"},
+	}
+	client := fake.NewSimpleClientset(configMap)
+	provider := &denyListProvider{
+		getDeniedURLs: func(env map[string]string) []string {
+			return []string{}
+		},
+		kubeClient:      client,
+		refreshInterval: time.Hour,
+	}
+	require.Equal(t, []string{"some-host"}, provider.Get())
+
+	updated := configMap.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Data["denyList"] = "some-host\nother-host"
+	_, err := client.CoreV1().ConfigMaps("").Update(context.TODO(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// the cached list is used until the refresh interval has elapsed
+	require.Equal(t, []string{"some-host"}, provider.Get())
+
+	provider.refreshInterval = 0
+	require.Equal(t, []string{"some-host", "other-host"}, provider.Get())
+
+	// the previous list is kept if the ConfigMap cannot be retrieved
+	client.PrependReactor("get", "configmaps", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, nil, fmt.Errorf("cannot get configmap")
+	})
+	require.Equal(t, []string{"some-host", "other-host"}, provider.Get())
+}
@@ -31,3 +31,16 @@ func NewWebhookExecutionError(preExec bool, err error, opts ...WebhookExecutionE
 func (whe WebhookExecutionError) Error() string {
 	return whe.ErrorObj.Error()
 }
+
+// WebhookFailedError indicates that the webhook requests have been executed, but the job started by them has failed
+type WebhookFailedError struct {
+	ErrorObj error
+}
+
+func NewWebhookFailedError(err error) *WebhookFailedError {
+	return &WebhookFailedError{ErrorObj: err}
+}
+
+func (wfe WebhookFailedError) Error() string {
+	return wfe.ErrorObj.Error()
+}
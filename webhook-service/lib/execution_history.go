@@ -0,0 +1,156 @@
+package lib
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRecordsPerSubscription = 100
+	defaultExecutionRecordLimit      = 20
+)
+
+const (
+	ExecutionSucceeded = "succeeded"
+	// ExecutionFailed indicates that the requests have been executed, but the webhook target reported a failure
+	ExecutionFailed = "failed"
+	// ExecutionErrored indicates that the webhook could not be executed
+	ExecutionErrored = "errored"
+)
+
+// ExecutionRecord describes the execution of a webhook for an event
+type ExecutionRecord struct {
+	ID             string          `json:"id"`
+	Time           time.Time       `json:"time"`
+	SubscriptionID string          `json:"subscriptionID"`
+	Project        string          `json:"project"`
+	Stage          string          `json:"stage"`
+	Service        string          `json:"service"`
+	KeptnContext   string          `json:"keptnContext"`
+	EventType      string          `json:"eventType"`
+	TriggeredID    string          `json:"triggeredID"`
+	Status         string          `json:"status"`
+	Message        string          `json:"message,omitempty"`
+	Requests       []RequestRecord `json:"requests"`
+}
+
+// RequestRecord describes a single request executed for a webhook
+type RequestRecord struct {
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url,omitempty"`
+	// Command is the curl command of requests that are executed using curl
+	Command    string `json:"command,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ExecutionFilter restricts the execution records returned by an IExecutionStore
+type ExecutionFilter struct {
+	Project        string
+	Stage          string
+	Service        string
+	SubscriptionID string
+	Limit          int
+}
+
+//go:generate moq  -pkg fake -out ./fake/execution_store_mock.go . IExecutionStore
+type IExecutionStore interface {
+	Add(record ExecutionRecord)
+	List(filter ExecutionFilter) []ExecutionRecord
+}
+
+// InMemoryExecutionStore keeps the most recent execution records of each subscription in memory
+type InMemoryExecutionStore struct {
+	mutex                     sync.RWMutex
+	records                   map[string][]ExecutionRecord
+	maxRecordsPerSubscription int
+}
+
+func NewInMemoryExecutionStore(maxRecordsPerSubscription int) *InMemoryExecutionStore {
+	if maxRecordsPerSubscription <= 0 {
+		maxRecordsPerSubscription = defaultMaxRecordsPerSubscription
+	}
+	return &InMemoryExecutionStore{
+		records:                   map[string][]ExecutionRecord{},
+		maxRecordsPerSubscription: maxRecordsPerSubscription,
+	}
+}
+
+func (s *InMemoryExecutionStore) Add(record ExecutionRecord) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	records := append(s.records[record.SubscriptionID], record)
+	if len(records) > s.maxRecordsPerSubscription {
+		records = records[len(records)-s.maxRecordsPerSubscription:]
+	}
+	s.records[record.SubscriptionID] = records
+}
+
+// List returns the records matching the given filter, starting with the most recent one
+func (s *InMemoryExecutionStore) List(filter ExecutionFilter) []ExecutionRecord {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := []ExecutionRecord{}
+	for subscriptionID, records := range s.records {
+		if filter.SubscriptionID != "" && filter.SubscriptionID != subscriptionID {
+			continue
+		}
+		for _, record := range records {
+			if filter.matches(record) {
+				result = append(result, record)
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.After(result[j].Time)
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultExecutionRecordLimit
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// snapshot returns a copy of the records of each subscription
+func (s *InMemoryExecutionStore) snapshot() map[string][]ExecutionRecord {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	result := make(map[string][]ExecutionRecord, len(s.records))
+	for subscriptionID, records := range s.records {
+		result[subscriptionID] = append([]ExecutionRecord{}, records...)
+	}
+	return result
+}
+
+// removeOldest removes the oldest record of all subscriptions
+func (s *InMemoryExecutionStore) removeOldest() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	oldest := ""
+	for subscriptionID, records := range s.records {
+		if oldest == "" || records[0].Time.Before(s.records[oldest][0].Time) {
+			oldest = subscriptionID
+		}
+	}
+	if oldest == "" {
+		return
+	}
+	if len(s.records[oldest]) == 1 {
+		delete(s.records, oldest)
+		return
+	}
+	s.records[oldest] = s.records[oldest][1:]
+}
+
+func (f ExecutionFilter) matches(record ExecutionRecord) bool {
+	return (f.Project == "" || f.Project == record.Project) &&
+		(f.Stage == "" || f.Stage == record.Stage) &&
+		(f.Service == "" || f.Service == record.Service)
+}
@@ -0,0 +1,65 @@
+package lib_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryExecutionStore_List(t *testing.T) {
+	start := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	store := lib.NewInMemoryExecutionStore(2)
+	store.Add(lib.ExecutionRecord{ID: "1", Time: start, SubscriptionID: "sub-1", Project: "podtato", Stage: "dev", Service: "head"})
+	store.Add(lib.ExecutionRecord{ID: "2", Time: start.Add(1 * time.Minute), SubscriptionID: "sub-1", Project: "podtato", Stage: "hardening", Service: "head"})
+	store.Add(lib.ExecutionRecord{ID: "3", Time: start.Add(2 * time.Minute), SubscriptionID: "sub-2", Project: "sockshop", Stage: "dev", Service: "carts"})
+	// exceeds the maximum number of records of sub-1, therefore the oldest record is removed
+	store.Add(lib.ExecutionRecord{ID: "4", Time: start.Add(3 * time.Minute), SubscriptionID: "sub-1", Project: "podtato", Stage: "dev", Service: "head"})
+
+	tests := []struct {
+		name    string
+		filter  lib.ExecutionFilter
+		wantIDs []string
+	}{
+		{
+			name:    "no filter",
+			filter:  lib.ExecutionFilter{},
+			wantIDs: []string{"4", "3", "2"},
+		},
+		{
+			name:    "filter by subscriptionID",
+			filter:  lib.ExecutionFilter{SubscriptionID: "sub-1"},
+			wantIDs: []string{"4", "2"},
+		},
+		{
+			name:    "filter by project and stage",
+			filter:  lib.ExecutionFilter{Project: "podtato", Stage: "dev"},
+			wantIDs: []string{"4"},
+		},
+		{
+			name:    "filter by service",
+			filter:  lib.ExecutionFilter{Service: "carts"},
+			wantIDs: []string{"3"},
+		},
+		{
+			name:    "limit",
+			filter:  lib.ExecutionFilter{Limit: 1},
+			wantIDs: []string{"4"},
+		},
+		{
+			name:    "no match",
+			filter:  lib.ExecutionFilter{SubscriptionID: "unknown"},
+			wantIDs: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []string{}
+			for _, record := range store.List(tt.filter) {
+				ids = append(ids, record.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
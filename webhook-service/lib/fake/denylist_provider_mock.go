@@ -1,7 +1,8 @@
 package fake
 
 type DenyListProviderMock struct {
-	GetDenyListFunc func() []string
+	GetDenyListFunc  func() []string
+	GetAllowListFunc func() []string
 }
 
 func (r DenyListProviderMock) Get() []string {
@@ -10,3 +11,10 @@ func (r DenyListProviderMock) Get() []string {
 	}
 	panic("implement me")
 }
+
+func (r DenyListProviderMock) GetAllowList() []string {
+	if r.GetAllowListFunc != nil {
+		return r.GetAllowListFunc()
+	}
+	return []string{}
+}
@@ -1,9 +1,14 @@
 package fake
 
-import "github.com/keptn/keptn/webhook-service/lib"
+import (
+	"net"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+)
 
 type IPResolverMock struct {
 	ResolveIPAdressesFunc func(curlURL string) (lib.AdrDomainNameMapping, error)
+	LookupHostsFunc       func(ips []net.IP) lib.AdrDomainNameMapping
 }
 
 func (r IPResolverMock) Resolve(curlURL string) (lib.AdrDomainNameMapping, error) {
@@ -12,3 +17,10 @@ func (r IPResolverMock) Resolve(curlURL string) (lib.AdrDomainNameMapping, error
 	}
 	panic("implement me")
 }
+
+func (r IPResolverMock) LookupHosts(ips []net.IP) lib.AdrDomainNameMapping {
+	if r.LookupHostsFunc != nil {
+		return r.LookupHostsFunc(ips)
+	}
+	panic("implement me")
+}
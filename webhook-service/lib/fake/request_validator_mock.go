@@ -1,9 +1,14 @@
 package fake
 
-import "github.com/keptn/keptn/webhook-service/lib"
+import (
+	"net"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+)
 
 type RequestValidatorMock struct {
-	ValidateFunc func(request lib.Request) error
+	ValidateFunc        func(request lib.Request) error
+	ValidateAddressFunc func(address string, ips []net.IP) error
 }
 
 func (r RequestValidatorMock) Validate(request lib.Request) error {
@@ -12,3 +17,10 @@ func (r RequestValidatorMock) Validate(request lib.Request) error {
 	}
 	panic("implement me")
 }
+
+func (r RequestValidatorMock) ValidateAddress(address string, ips []net.IP) error {
+	if r.ValidateAddressFunc != nil {
+		return r.ValidateAddressFunc(address, ips)
+	}
+	panic("implement me")
+}
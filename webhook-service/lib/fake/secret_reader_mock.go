@@ -18,7 +18,7 @@ var _ lib.ISecretReader = &ISecretReaderMock{}
 //
 // 		// make and configure a mocked lib.ISecretReader
 // 		mockedISecretReader := &ISecretReaderMock{
-// 			ReadSecretFunc: func(name string, key string) (string, error) {
+// 			ReadSecretFunc: func(ref lib.WebHookSecretRef) (string, error) {
 // 				panic("mock out the ReadSecret method")
 // 			},
 // 		}
@@ -29,49 +29,43 @@ var _ lib.ISecretReader = &ISecretReaderMock{}
 // 	}
 type ISecretReaderMock struct {
 	// ReadSecretFunc mocks the ReadSecret method.
-	ReadSecretFunc func(name string, key string) (string, error)
+	ReadSecretFunc func(ref lib.WebHookSecretRef) (string, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
 		// ReadSecret holds details about calls to the ReadSecret method.
 		ReadSecret []struct {
-			// Name is the name argument value.
-			Name string
-			// Key is the key argument value.
-			Key string
+			// Ref is the ref argument value.
+			Ref lib.WebHookSecretRef
 		}
 	}
 	lockReadSecret sync.RWMutex
 }
 
 // ReadSecret calls ReadSecretFunc.
-func (mock *ISecretReaderMock) ReadSecret(name string, key string) (string, error) {
+func (mock *ISecretReaderMock) ReadSecret(ref lib.WebHookSecretRef) (string, error) {
 	if mock.ReadSecretFunc == nil {
 		panic("ISecretReaderMock.ReadSecretFunc: method is nil but ISecretReader.ReadSecret was just called")
 	}
 	callInfo := struct {
-		Name string
-		Key  string
+		Ref lib.WebHookSecretRef
 	}{
-		Name: name,
-		Key:  key,
+		Ref: ref,
 	}
 	mock.lockReadSecret.Lock()
 	mock.calls.ReadSecret = append(mock.calls.ReadSecret, callInfo)
 	mock.lockReadSecret.Unlock()
-	return mock.ReadSecretFunc(name, key)
+	return mock.ReadSecretFunc(ref)
 }
 
 // ReadSecretCalls gets all the calls that were made to ReadSecret.
 // Check the length with:
 //     len(mockedISecretReader.ReadSecretCalls())
 func (mock *ISecretReaderMock) ReadSecretCalls() []struct {
-	Name string
-	Key  string
+	Ref lib.WebHookSecretRef
 } {
 	var calls []struct {
-		Name string
-		Key  string
+		Ref lib.WebHookSecretRef
 	}
 	mock.lockReadSecret.RLock()
 	calls = mock.calls.ReadSecret
@@ -0,0 +1,183 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultCircuitBreakerThreshold    = 5
+	defaultCircuitBreakerOpenDuration = 30 * time.Second
+	defaultHostIdleTimeout            = 10 * time.Minute
+)
+
+//go:generate moq  -pkg fake -out ./fake/host_guard_mock.go . IHostGuard
+type IHostGuard interface {
+	// Acquire blocks until a request to the given host is allowed by the rate limit, or fails if the circuit breaker of the host is open
+	Acquire(ctx context.Context, host string) error
+	// Report records the result of a request to the given host that has been allowed by Acquire
+	Report(host string, err error)
+}
+
+// HostGuard limits the rate of requests per target host and opens a circuit breaker for hosts whose requests repeatedly fail with
+// a transient error. While the circuit of a host is open, requests to it fail immediately. After the open duration has elapsed,
+// a single trial request is allowed, which either closes the circuit again or keeps it open for another open duration.
+// The state is shared by all webhooks executed by the service. The state of hosts that have not been requested within the idle timeout
+// is removed, unless their circuit is open
+type HostGuard struct {
+	mu               sync.Mutex
+	hosts            map[string]*hostState
+	rateLimit        rate.Limit
+	burst            int
+	failureThreshold int
+	openDuration     time.Duration
+	idleTimeout      time.Duration
+	lastEviction     time.Time
+}
+
+type hostState struct {
+	limiter             *rate.Limiter
+	consecutiveFailures int
+	openUntil           time.Time
+	trialInProgress     bool
+	lastUsed            time.Time
+}
+
+type HostGuardOption func(guard *HostGuard)
+
+// WithRateLimit limits the requests per host to the given number of requests per second, allowing bursts of the given size.
+// A rate limit of 0 disables rate limiting
+func WithRateLimit(requestsPerSecond float64, burst int) HostGuardOption {
+	return func(guard *HostGuard) {
+		guard.rateLimit = rate.Limit(requestsPerSecond)
+		guard.burst = burst
+	}
+}
+
+// WithCircuitBreaker opens the circuit of a host after the given number of consecutive transient failures, for the given duration.
+// A threshold of 0 disables the circuit breaker
+func WithCircuitBreaker(failureThreshold int, openDuration time.Duration) HostGuardOption {
+	return func(guard *HostGuard) {
+		guard.failureThreshold = failureThreshold
+		guard.openDuration = openDuration
+	}
+}
+
+// WithIdleTimeout sets the duration after which the state of a host that has not been requested is removed
+func WithIdleTimeout(idleTimeout time.Duration) HostGuardOption {
+	return func(guard *HostGuard) {
+		guard.idleTimeout = idleTimeout
+	}
+}
+
+func NewHostGuard(opts ...HostGuardOption) *HostGuard {
+	guard := &HostGuard{
+		hosts:            map[string]*hostState{},
+		failureThreshold: defaultCircuitBreakerThreshold,
+		openDuration:     defaultCircuitBreakerOpenDuration,
+		idleTimeout:      defaultHostIdleTimeout,
+		lastEviction:     time.Now(),
+	}
+	for _, o := range opts {
+		o(guard)
+	}
+	if guard.burst < 1 {
+		guard.burst = 1
+	}
+	return guard
+}
+
+func (hg *HostGuard) Acquire(ctx context.Context, host string) error {
+	hg.mu.Lock()
+	state := hg.getState(host)
+	isTrial, err := hg.checkCircuit(host, state)
+	if err != nil {
+		hg.mu.Unlock()
+		return err
+	}
+	limiter := state.limiter
+	hg.mu.Unlock()
+
+	if limiter == nil {
+		return nil
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		if isTrial {
+			hg.mu.Lock()
+			// the trial request has not been sent, so another request may be used as trial
+			state.trialInProgress = false
+			hg.mu.Unlock()
+		}
+		return &CurlError{err: fmt.Errorf("rate limit of host '%s' exceeded: %w", host, err), reason: RequestError}
+	}
+	return nil
+}
+
+func (hg *HostGuard) Report(host string, err error) {
+	if hg.failureThreshold <= 0 {
+		return
+	}
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+	state := hg.getState(host)
+	wasTrial := state.trialInProgress
+	state.trialInProgress = false
+	if !IsTransientError(err) {
+		state.consecutiveFailures = 0
+		state.openUntil = time.Time{}
+		return
+	}
+	state.consecutiveFailures++
+	if wasTrial || state.consecutiveFailures >= hg.failureThreshold {
+		state.openUntil = time.Now().Add(hg.openDuration)
+	}
+}
+
+// checkCircuit returns an error if the circuit of the given host is open. If the open duration has elapsed, the request is allowed as trial request,
+// which is indicated by the returned bool
+func (hg *HostGuard) checkCircuit(host string, state *hostState) (bool, error) {
+	if hg.failureThreshold <= 0 || state.openUntil.IsZero() {
+		return false, nil
+	}
+	if time.Now().Before(state.openUntil) || state.trialInProgress {
+		return false, &CurlError{
+			err:    fmt.Errorf("circuit breaker of host '%s' is open after %d consecutive failures", host, state.consecutiveFailures),
+			reason: CircuitOpenError,
+		}
+	}
+	state.trialInProgress = true
+	return true, nil
+}
+
+func (hg *HostGuard) getState(host string) *hostState {
+	now := time.Now()
+	hg.evictIdleHosts(now)
+	state, ok := hg.hosts[host]
+	if !ok {
+		state = &hostState{}
+		if hg.rateLimit > 0 {
+			state.limiter = rate.NewLimiter(hg.rateLimit, hg.burst)
+		}
+		hg.hosts[host] = state
+	}
+	state.lastUsed = now
+	return state
+}
+
+// evictIdleHosts removes the state of the hosts that have not been requested within the idle timeout, at most once per idle timeout.
+// Since the limiter of an idle host has been refilled, and its circuit is closed, the removed state equals the state of a new host
+func (hg *HostGuard) evictIdleHosts(now time.Time) {
+	if hg.idleTimeout <= 0 || now.Sub(hg.lastEviction) < hg.idleTimeout {
+		return
+	}
+	hg.lastEviction = now
+	for host, state := range hg.hosts {
+		if now.Sub(state.lastUsed) >= hg.idleTimeout && !state.trialInProgress && now.After(state.openUntil) {
+			delete(hg.hosts, host)
+		}
+	}
+}
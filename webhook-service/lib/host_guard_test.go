@@ -0,0 +1,120 @@
+package lib_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostGuard_CircuitBreaker(t *testing.T) {
+	guard := lib.NewHostGuard(lib.WithCircuitBreaker(2, 50*time.Millisecond))
+	transientErr := lib.NewCurlError(errors.New("connection refused"), lib.RequestError)
+
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+	guard.Report("my-host", transientErr)
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+	guard.Report("my-host", transientErr)
+
+	err := guard.Acquire(context.Background(), "my-host")
+	require.Error(t, err)
+	assert.True(t, lib.IsCircuitOpenError(err))
+	assert.Contains(t, err.Error(), "my-host")
+
+	// other hosts are not affected
+	require.NoError(t, guard.Acquire(context.Background(), "other-host"))
+
+	// after the open duration, a single trial request is allowed
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+	assert.True(t, lib.IsCircuitOpenError(guard.Acquire(context.Background(), "my-host")))
+
+	// a failed trial request opens the circuit again
+	guard.Report("my-host", transientErr)
+	assert.True(t, lib.IsCircuitOpenError(guard.Acquire(context.Background(), "my-host")))
+
+	// a successful trial request closes the circuit
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+	guard.Report("my-host", nil)
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+}
+
+func TestHostGuard_IgnoresNonTransientErrors(t *testing.T) {
+	guard := lib.NewHostGuard(lib.WithCircuitBreaker(1, time.Minute))
+
+	guard.Report("my-host", lib.NewCurlError(errors.New("denied"), lib.DeniedURLError))
+	guard.Report("my-host", errors.New("oops"))
+
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+}
+
+func TestHostGuard_RateLimit(t *testing.T) {
+	guard := lib.NewHostGuard(lib.WithRateLimit(1, 1))
+
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+	require.NoError(t, guard.Acquire(context.Background(), "other-host"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := guard.Acquire(ctx, "my-host")
+	require.Error(t, err)
+	assert.True(t, lib.IsRequestError(err))
+	assert.Contains(t, err.Error(), "rate limit of host 'my-host' exceeded")
+}
+
+func TestHostGuard_EvictsIdleHosts(t *testing.T) {
+	guard := lib.NewHostGuard(lib.WithCircuitBreaker(2, time.Minute), lib.WithIdleTimeout(20*time.Millisecond))
+	transientErr := lib.NewCurlError(errors.New("connection refused"), lib.RequestError)
+
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+	guard.Report("my-host", transientErr)
+	require.NoError(t, guard.Acquire(context.Background(), "my-open-host"))
+	guard.Report("my-open-host", transientErr)
+	guard.Report("my-open-host", transientErr)
+
+	// the state of the idle host is removed, i.e. its failures are not counted anymore
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+	guard.Report("my-host", transientErr)
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+
+	// the state of hosts with an open circuit is kept
+	assert.True(t, lib.IsCircuitOpenError(guard.Acquire(context.Background(), "my-open-host")))
+}
+
+func TestHostGuard_CanceledWaitKeepsTrialOfOtherRequest(t *testing.T) {
+	guard := lib.NewHostGuard(lib.WithRateLimit(1, 1), lib.WithCircuitBreaker(1, 20*time.Millisecond))
+	transientErr := lib.NewCurlError(errors.New("connection refused"), lib.RequestError)
+
+	require.NoError(t, guard.Acquire(context.Background(), "my-host"))
+
+	// a request that has been allowed before the circuit opened waits for the rate limit
+	waitingCtx, cancelWaiting := context.WithCancel(context.Background())
+	waitingErr := make(chan error)
+	go func() {
+		waitingErr <- guard.Acquire(waitingCtx, "my-host")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	guard.Report("my-host", transientErr)
+
+	// after the open duration, the trial request waits for the rate limit as well
+	time.Sleep(30 * time.Millisecond)
+	trialCtx, cancelTrial := context.WithCancel(context.Background())
+	defer cancelTrial()
+	go func() {
+		_ = guard.Acquire(trialCtx, "my-host")
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancelWaiting()
+	require.Error(t, <-waitingErr)
+
+	// the canceled request was not the trial request, so the trial is still in progress
+	assert.True(t, lib.IsCircuitOpenError(guard.Acquire(context.Background(), "my-host")))
+}
@@ -0,0 +1,270 @@
+package lib
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRequestTimeout          = 30 * time.Second
+	defaultMaxResponseSize   int64 = 1 << 20
+	maxNumberOfHTTPRedirects       = 10
+)
+
+//go:generate moq  -pkg fake -out ./fake/http_executor_mock.go . IHTTPExecutor
+type IHTTPExecutor interface {
+	Execute(request Request) (*HTTPResponse, error)
+}
+
+type LookupIPAddrFunc func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+// HTTPExecutor executes webhook requests of the v1beta1 format using net/http.
+// The target of each connection, including connections opened for redirects, is validated against the deny list
+// after it has been resolved, and the connection is established to the validated IP addresses only
+type HTTPExecutor struct {
+	requestValidator       RequestValidator
+	hostGuard              IHostGuard
+	lookupIPAddr           LookupIPAddrFunc
+	defaultTimeout         time.Duration
+	defaultMaxResponseSize int64
+}
+
+type HTTPExecutorOption func(executor *HTTPExecutor)
+
+// WithDefaultTimeout sets the timeout used for requests that do not define a timeout
+func WithDefaultTimeout(timeout time.Duration) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.defaultTimeout = timeout
+	}
+}
+
+// WithDefaultMaxResponseSize sets the response size limit used for requests that do not define a maxResponseSize
+func WithDefaultMaxResponseSize(size int64) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.defaultMaxResponseSize = size
+	}
+}
+
+// WithLookupIPAddrFunc sets the function used to resolve the host of a request
+func WithLookupIPAddrFunc(lookupIPAddr LookupIPAddrFunc) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.lookupIPAddr = lookupIPAddr
+	}
+}
+
+// WithHostGuard sets the guard that limits the rate of requests per host and fails requests to hosts with an open circuit breaker
+func WithHostGuard(hostGuard IHostGuard) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.hostGuard = hostGuard
+	}
+}
+
+func NewHTTPExecutor(requestValidator RequestValidator, opts ...HTTPExecutorOption) *HTTPExecutor {
+	executor := &HTTPExecutor{
+		requestValidator:       requestValidator,
+		lookupIPAddr:           net.DefaultResolver.LookupIPAddr,
+		defaultTimeout:         defaultRequestTimeout,
+		defaultMaxResponseSize: defaultMaxResponseSize,
+	}
+	for _, o := range opts {
+		o(executor)
+	}
+	return executor
+}
+
+func (he *HTTPExecutor) Execute(request Request) (*HTTPResponse, error) {
+	if request.URL == "" {
+		return nil, &CurlError{err: errors.New("no URL provided"), reason: NoCommandError}
+	}
+	parsedURL, err := neturl.Parse(request.URL)
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not parse URL: %w", err), reason: InvalidCommandError}
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, &CurlError{err: fmt.Errorf("unsupported URL scheme '%s'", parsedURL.Scheme), reason: InvalidCommandError}
+	}
+
+	timeout := he.defaultTimeout
+	if request.Timeout != "" {
+		timeout, err = time.ParseDuration(request.Timeout)
+		if err != nil {
+			return nil, &CurlError{err: fmt.Errorf("invalid timeout '%s'", request.Timeout), reason: InvalidCommandError}
+		}
+	}
+	maxResponseSize := he.defaultMaxResponseSize
+	if request.MaxResponseSize > 0 {
+		maxResponseSize = request.MaxResponseSize
+	}
+
+	client, err := he.newClient(request.TLS)
+	if err != nil {
+		return nil, &CurlError{err: err, reason: InvalidCommandError}
+	}
+
+	maxAttempts := request.Retry.GetMaxAttempts()
+	for attempt := 1; ; attempt++ {
+		response, err := he.executeAttempt(client, request, parsedURL.Host, timeout, maxResponseSize)
+		if err == nil {
+			return response, nil
+		}
+		if attempt >= maxAttempts || !IsTransientError(err) {
+			if attempt > 1 {
+				return nil, withAttempts(err, attempt)
+			}
+			return nil, err
+		}
+		time.Sleep(request.Retry.GetBackoff(attempt))
+	}
+}
+
+// executeAttempt sends the given request once. If a host guard is set, the request waits for the rate limit of the host
+// and fails immediately if the circuit breaker of the host is open
+func (he *HTTPExecutor) executeAttempt(client *http.Client, request Request, host string, timeout time.Duration, maxResponseSize int64) (*HTTPResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if he.hostGuard != nil {
+		if err := he.hostGuard.Acquire(ctx, host); err != nil {
+			return nil, err
+		}
+	}
+	response, err := he.send(ctx, client, request, maxResponseSize)
+	if he.hostGuard != nil {
+		he.hostGuard.Report(host, err)
+	}
+	return response, err
+}
+
+func (he *HTTPExecutor) send(ctx context.Context, client *http.Client, request Request, maxResponseSize int64) (*HTTPResponse, error) {
+
+	httpRequest, err := http.NewRequestWithContext(ctx, request.Method, request.URL, strings.NewReader(request.Payload))
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not create request: %w", err), reason: InvalidCommandError}
+	}
+	for _, header := range request.Headers {
+		httpRequest.Header.Add(header.Key, header.Value)
+	}
+
+	resp, err := client.Do(httpRequest)
+	if err != nil {
+		var curlErr *CurlError
+		if errors.As(err, &curlErr) {
+			return nil, curlErr
+		}
+		return nil, &CurlError{err: fmt.Errorf("error during request execution: %w", err), reason: RequestError}
+	}
+	defer resp.Body.Close()
+
+	// read one byte more than allowed to detect responses exceeding the limit
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not read response: %w", err), reason: RequestError}
+	}
+	if int64(len(body)) > maxResponseSize {
+		return nil, &CurlError{err: fmt.Errorf("response exceeds the maximum size of %d bytes", maxResponseSize), reason: RequestError}
+	}
+	if !request.Response.IsSuccessStatusCode(resp.StatusCode) {
+		return nil, &CurlError{err: fmt.Errorf("request failed with status code %d.\nResponse: \n%s", resp.StatusCode, string(body)), reason: RequestError, statusCode: resp.StatusCode}
+	}
+	return &HTTPResponse{StatusCode: resp.StatusCode, Body: string(body)}, nil
+}
+
+// withAttempts adds the number of attempts to the message of the given error, keeping its reason
+func withAttempts(err error, attempts int) error {
+	var curlErr *CurlError
+	if errors.As(err, &curlErr) {
+		return &CurlError{err: fmt.Errorf("%w (after %d attempts)", curlErr.err, attempts), reason: curlErr.reason, statusCode: curlErr.statusCode}
+	}
+	return fmt.Errorf("%w (after %d attempts)", err, attempts)
+}
+
+func (he *HTTPExecutor) newClient(tlsOptions *TLSConfig) (*http.Client, error) {
+	tlsConfig, err := newTLSConfig(tlsOptions)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{}
+	return &http.Client{
+		Transport: &http.Transport{
+			// no proxy is used, since the deny list can only be enforced for connections to the webhook target itself
+			Proxy:             nil,
+			DialContext:       he.dialContext(dialer),
+			TLSClientConfig:   tlsConfig,
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxNumberOfHTTPRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxNumberOfHTTPRedirects)
+			}
+			return nil
+		},
+	}, nil
+}
+
+// dialContext resolves the host of the given address and validates the resulting IP addresses before connecting to them.
+// Since the connection is established to the validated IP addresses, the host cannot be re-resolved to a denied address
+func (he *HTTPExecutor) dialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		ipAddrs, err := he.lookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(ipAddrs) == 0 {
+			return nil, fmt.Errorf("no IP address found for host '%s'", host)
+		}
+		ips := make([]net.IP, 0, len(ipAddrs))
+		for _, ipAddr := range ipAddrs {
+			ips = append(ips, ipAddr.IP)
+		}
+		if err := he.requestValidator.ValidateAddress(address, ips); err != nil {
+			return nil, &CurlError{err: err, reason: DeniedURLError}
+		}
+
+		var dialErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			dialErr = err
+		}
+		return nil, dialErr
+	}
+}
+
+func newTLSConfig(tlsOptions *TLSConfig) (*tls.Config, error) {
+	if tlsOptions == nil {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: tlsOptions.InsecureSkipVerify, //nolint:gosec
+	}
+	if tlsOptions.CACert != "" {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(tlsOptions.CACert)) {
+			return nil, errors.New("could not parse CA certificate")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+	if tlsOptions.ClientCert != "" || tlsOptions.ClientKey != "" {
+		certificate, err := tls.X509KeyPair([]byte(tlsOptions.ClientCert), []byte(tlsOptions.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,255 @@
+package lib_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lookupLocalhost resolves every host to the loopback address of the test server
+func lookupLocalhost(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return []net.IPAddr{{IP: net.ParseIP("127.0.0.1")}}, nil
+}
+
+func newTestServerURL(t *testing.T, handler http.HandlerFunc) string {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return strings.Replace(server.URL, "127.0.0.1", "my-webhook-target", 1)
+}
+
+func TestHTTPExecutor_Execute(t *testing.T) {
+	var receivedRequest *http.Request
+	var receivedBody string
+	url := newTestServerURL(t, func(w http.ResponseWriter, r *http.Request) {
+		receivedRequest = r
+		body, _ := ioutil.ReadAll(r.Body)
+		receivedBody = string(body)
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	})
+
+	var validatedAddress string
+	executor := lib.NewHTTPExecutor(fake.RequestValidatorMock{
+		ValidateAddressFunc: func(address string, ips []net.IP) error {
+			validatedAddress = address
+			return nil
+		},
+	}, lib.WithLookupIPAddrFunc(lookupLocalhost))
+
+	response, err := executor.Execute(lib.Request{
+		URL:     url + "/hook",
+		Method:  "POST",
+		Headers: []lib.Header{{Key: "Content-Type", Value: "application/json"}},
+		Payload: `{"data": "$(echo 'characters denied by curl'); |"}`,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"status": "ok"}`}, response)
+	assert.Equal(t, "POST", receivedRequest.Method)
+	assert.Equal(t, "/hook", receivedRequest.URL.Path)
+	assert.Equal(t, "application/json", receivedRequest.Header.Get("Content-Type"))
+	assert.Equal(t, `{"data": "$(echo 'characters denied by curl'); |"}`, receivedBody)
+	assert.True(t, strings.HasPrefix(validatedAddress, "my-webhook-target:"))
+}
+
+func TestHTTPExecutor_ExecuteWithSuccessCodes(t *testing.T) {
+	url := newTestServerURL(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("not found"))
+	})
+	executor := lib.NewHTTPExecutor(fake.RequestValidatorMock{
+		ValidateAddressFunc: func(address string, ips []net.IP) error {
+			return nil
+		},
+	}, lib.WithLookupIPAddrFunc(lookupLocalhost))
+
+	response, err := executor.Execute(lib.Request{
+		URL:      url,
+		Method:   "GET",
+		Response: &lib.ResponseConfig{SuccessCodes: []int{http.StatusNotFound}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusNotFound, Body: "not found"}, response)
+
+	_, err = executor.Execute(lib.Request{
+		URL:      url,
+		Method:   "GET",
+		Response: &lib.ResponseConfig{SuccessCodes: []int{http.StatusOK}},
+	})
+	require.Error(t, err)
+	assert.True(t, lib.IsRequestError(err))
+}
+
+func TestHTTPExecutor_ExecuteErrors(t *testing.T) {
+	url := newTestServerURL(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("something went wrong"))
+		case "/large":
+			_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+		case "/redirect":
+			http.Redirect(w, r, "http://denied-target/", http.StatusFound)
+		}
+	})
+
+	executor := lib.NewHTTPExecutor(fake.RequestValidatorMock{
+		ValidateAddressFunc: func(address string, ips []net.IP) error {
+			if strings.HasPrefix(address, "denied-target") {
+				return errors.New("denied")
+			}
+			return nil
+		},
+	}, lib.WithLookupIPAddrFunc(lookupLocalhost))
+
+	tests := []struct {
+		name      string
+		request   lib.Request
+		checkErr  func(err error) bool
+		errSubstr string
+	}{
+		{
+			name:     "empty URL",
+			request:  lib.Request{Method: "GET"},
+			checkErr: lib.IsNoCommandError,
+		},
+		{
+			name:     "unsupported scheme",
+			request:  lib.Request{URL: "file:///etc/passwd", Method: "GET"},
+			checkErr: lib.IsInvalidCommandError,
+		},
+		{
+			name:      "error status code",
+			request:   lib.Request{URL: url + "/error", Method: "GET"},
+			checkErr:  lib.IsRequestError,
+			errSubstr: "something went wrong",
+		},
+		{
+			name:      "response too large",
+			request:   lib.Request{URL: url + "/large", Method: "GET", MaxResponseSize: 10},
+			checkErr:  lib.IsRequestError,
+			errSubstr: "maximum size of 10 bytes",
+		},
+		{
+			name:     "timeout",
+			request:  lib.Request{URL: url + "/slow", Method: "GET", Timeout: "100ms"},
+			checkErr: lib.IsRequestError,
+		},
+		{
+			name:     "denied target",
+			request:  lib.Request{URL: "http://denied-target/", Method: "GET"},
+			checkErr: lib.IsDeniedURLError,
+		},
+		{
+			name:     "redirect to denied target",
+			request:  lib.Request{URL: url + "/redirect", Method: "GET"},
+			checkErr: lib.IsDeniedURLError,
+		},
+		{
+			name:     "invalid CA certificate",
+			request:  lib.Request{URL: url, Method: "GET", TLS: &lib.TLSConfig{CACert: "invalid"}},
+			checkErr: lib.IsInvalidCommandError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := executor.Execute(tt.request)
+			require.Error(t, err)
+			assert.Nil(t, response)
+			assert.True(t, tt.checkErr(err), err.Error())
+			if tt.errSubstr != "" {
+				assert.Contains(t, err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestHTTPExecutor_ExecuteWithRetry(t *testing.T) {
+	attempts := 0
+	url := newTestServerURL(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch {
+		case r.URL.Path == "/not-found":
+			w.WriteHeader(http.StatusNotFound)
+		case attempts < 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	})
+	executor := lib.NewHTTPExecutor(fake.RequestValidatorMock{
+		ValidateAddressFunc: func(address string, ips []net.IP) error {
+			return nil
+		},
+	}, lib.WithLookupIPAddrFunc(lookupLocalhost))
+
+	response, err := executor.Execute(lib.Request{
+		URL:    url,
+		Method: "GET",
+		Retry:  &lib.RetryConfig{MaxAttempts: 3, Backoff: "1ms"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "ok"}, response)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	_, err = executor.Execute(lib.Request{
+		URL:    url,
+		Method: "GET",
+		Retry:  &lib.RetryConfig{MaxAttempts: 2, Backoff: "1ms"},
+	})
+	require.Error(t, err)
+	assert.True(t, lib.IsRequestError(err))
+	assert.Contains(t, err.Error(), "after 2 attempts")
+	assert.Equal(t, 2, attempts)
+
+	// requests failing with a status code other than 429 or 5xx are not retried
+	attempts = 0
+	_, err = executor.Execute(lib.Request{
+		URL:    url + "/not-found",
+		Method: "GET",
+		Retry:  &lib.RetryConfig{MaxAttempts: 3, Backoff: "1ms"},
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestHTTPExecutor_ExecuteWithOpenCircuit(t *testing.T) {
+	attempts := 0
+	url := newTestServerURL(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	executor := lib.NewHTTPExecutor(fake.RequestValidatorMock{
+		ValidateAddressFunc: func(address string, ips []net.IP) error {
+			return nil
+		},
+	}, lib.WithLookupIPAddrFunc(lookupLocalhost), lib.WithHostGuard(lib.NewHostGuard(lib.WithCircuitBreaker(2, time.Minute))))
+
+	_, err := executor.Execute(lib.Request{
+		URL:    url,
+		Method: "GET",
+		Retry:  &lib.RetryConfig{MaxAttempts: 5, Backoff: "1ms"},
+	})
+	require.Error(t, err)
+	// the retries are stopped once the circuit has been opened
+	assert.True(t, lib.IsCircuitOpenError(err), err.Error())
+	assert.Equal(t, 2, attempts)
+
+	_, err = executor.Execute(lib.Request{URL: url + "/other", Method: "GET"})
+	require.Error(t, err)
+	assert.True(t, lib.IsCircuitOpenError(err))
+	assert.Equal(t, 2, attempts)
+}
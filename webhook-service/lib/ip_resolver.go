@@ -8,6 +8,7 @@ import (
 
 type IPResolver interface {
 	Resolve(url string) (AdrDomainNameMapping, error)
+	LookupHosts(ips []net.IP) AdrDomainNameMapping
 }
 
 type LookupFunc func(host string) ([]net.IP, error)
@@ -41,14 +42,20 @@ func (i ipResolver) Resolve(url string) (AdrDomainNameMapping, error) {
 		logger.Errorf("Unable to look up IP for URL: %s", url)
 		return ipAddresses, err
 	}
+	return i.LookupHosts(ips), nil
+}
+
+// LookupHosts returns the domains of each of the given IP addresses
+func (i ipResolver) LookupHosts(ips []net.IP) AdrDomainNameMapping {
+	ipAddresses := make(AdrDomainNameMapping, 0)
 	for _, ip := range ips {
 
 		// for each ip get all its domains to check if they are among the denied
 		hosts, err := i.lookupAddr(ip.String())
 		if err != nil {
-			logger.Errorf("Unable to look up domains for URL: %s", url)
+			logger.Errorf("Unable to look up domains for IP: %s", ip.String())
 		}
 		ipAddresses[ip.String()] = hosts
 	}
-	return ipAddresses, nil
+	return ipAddresses
 }
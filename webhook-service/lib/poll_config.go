@@ -0,0 +1,81 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultPollInterval = 10 * time.Second
+	defaultPollTimeout  = 10 * time.Minute
+)
+
+// PollConfig defines a status URL that is polled after a webhook request has been executed, until the job started by the
+// request has completed. The URL and headers can reference the outputs of the initial request, e.g. '{{.outputs.jobId}}'
+type PollConfig struct {
+	URL     string   `yaml:"url"`
+	Method  string   `yaml:"method,omitempty"`
+	Headers []Header `yaml:"headers,omitempty"`
+	// Interval is the duration between two status requests, defaults to 10s
+	Interval string `yaml:"interval,omitempty"`
+	// Timeout is the maximum duration of polling, defaults to 10m
+	Timeout string `yaml:"timeout,omitempty"`
+	// SuccessCondition is a template that evaluates to 'true' once the job has completed successfully
+	SuccessCondition string `yaml:"successCondition"`
+	// FailureCondition is a template that evaluates to 'true' once the job has failed
+	FailureCondition string `yaml:"failureCondition,omitempty"`
+	// Outputs are extracted from the last status response, in addition to the outputs of the initial request
+	Outputs map[string]string `yaml:"outputs,omitempty"`
+}
+
+// GetMethod returns the HTTP method of the status requests
+func (pc *PollConfig) GetMethod() string {
+	if pc.Method == "" {
+		return "GET"
+	}
+	return pc.Method
+}
+
+// GetInterval returns the duration between two status requests
+func (pc *PollConfig) GetInterval() time.Duration {
+	return parseDurationOrDefault(pc.Interval, defaultPollInterval)
+}
+
+// GetTimeout returns the maximum duration of polling
+func (pc *PollConfig) GetTimeout() time.Duration {
+	return parseDurationOrDefault(pc.Timeout, defaultPollTimeout)
+}
+
+func parseDurationOrDefault(duration string, defaultDuration time.Duration) time.Duration {
+	parsed, err := time.ParseDuration(duration)
+	if err != nil || parsed <= 0 {
+		return defaultDuration
+	}
+	return parsed
+}
+
+func verifyPollConfig(request Request) error {
+	pc := request.Poll
+	if pc == nil {
+		return nil
+	}
+	if pc.URL == "" {
+		return errors.New(webhookConfInvalid + "webhook poll URL empty")
+	}
+	if !isMethodSupported(pc.GetMethod()) {
+		return errors.New(webhookConfInvalid + "unsupported webhook poll method")
+	}
+	if pc.SuccessCondition == "" {
+		return errors.New(webhookConfInvalid + "webhook poll successCondition empty")
+	}
+	for _, duration := range []string{pc.Interval, pc.Timeout} {
+		if duration == "" {
+			continue
+		}
+		if parsed, err := time.ParseDuration(duration); err != nil || parsed <= 0 {
+			return fmt.Errorf(webhookConfInvalid+"invalid webhook poll duration '%s'", duration)
+		}
+	}
+	return verifyOutputs(pc.Outputs)
+}
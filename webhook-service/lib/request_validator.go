@@ -2,7 +2,8 @@ package lib
 
 import (
 	"fmt"
-	"strings"
+	"net"
+	neturl "net/url"
 )
 
 type requestValidator struct {
@@ -12,6 +13,8 @@ type requestValidator struct {
 
 type RequestValidator interface {
 	Validate(request Request) error
+	// ValidateAddress checks the given address (host:port) and the IP addresses it has been resolved to against the deny and allow list
+	ValidateAddress(address string, ips []net.IP) error
 }
 
 func NewRequestValidator(denyListProvider DenyListProvider, ipResolver IPResolver) RequestValidator {
@@ -26,40 +29,141 @@ func (c requestValidator) Validate(request Request) error {
 	if request.URL == "" {
 		return fmt.Errorf("curl command contains empty URL")
 	}
-	denyList := c.denyListProvider.Get()
+	parsedURL, err := neturl.Parse(request.URL)
+	if err != nil {
+		return fmt.Errorf("could not parse URL '%s': %w", request.URL, err)
+	}
 	ipAddresses, err := c.ipResolver.Resolve(request.URL)
 	if err != nil {
 		return err
 	}
-	for _, url := range denyList {
-		if strings.Contains(request.URL, url) {
-			return fmt.Errorf("curl command contains denied URL '%s'", url)
+	violation := c.checkTarget(request.URL, parsedURL.Hostname(), getPort(parsedURL), ipAddresses)
+	if violation == nil {
+		return nil
+	}
+	switch violation.kind {
+	case deniedHost:
+		return fmt.Errorf("curl command contains denied URL '%s'", violation.rule)
+	case deniedIP:
+		return fmt.Errorf("curl command contains denied IP address '%s'", violation.rule)
+	case deniedResolvedHost:
+		return fmt.Errorf("curl command url resolves to denied host '%s'", violation.rule)
+	case invalidAllowList:
+		return fmt.Errorf("curl command URL '%s' is denied, since the allow list contains invalid entries", request.URL)
+	default:
+		return fmt.Errorf("curl command URL '%s' is not contained in the allow list", request.URL)
+	}
+}
+
+func (c requestValidator) ValidateAddress(address string, ips []net.IP) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid request target '%s': %w", address, err)
+	}
+	violation := c.checkTarget(address, host, port, c.ipResolver.LookupHosts(ips))
+	if violation == nil {
+		return nil
+	}
+	switch violation.kind {
+	case deniedHost:
+		return fmt.Errorf("request target '%s' is denied by '%s'", address, violation.rule)
+	case deniedIP:
+		return fmt.Errorf("request target '%s' resolves to denied IP address '%s'", address, violation.rule)
+	case deniedResolvedHost:
+		return fmt.Errorf("request target '%s' resolves to denied host '%s'", address, violation.rule)
+	case invalidAllowList:
+		return fmt.Errorf("request target '%s' is denied, since the allow list contains invalid entries", address)
+	default:
+		return fmt.Errorf("request target '%s' is not contained in the allow list", address)
+	}
+}
+
+type violationKind int
+
+const (
+	deniedHost violationKind = iota
+	deniedIP
+	deniedResolvedHost
+	notAllowed
+	invalidAllowList
+)
+
+type targetViolation struct {
+	kind violationKind
+	rule TargetRule
+}
+
+// checkTarget checks the host and the resolved IP addresses of a request target. The target is denied if the host, one of its IP addresses,
+// or one of the host names the IP addresses resolve to is matched by the default denied targets or the deny list. Invalid entries of the deny list
+// are matched as substrings of the target, see ParseDenyRules.
+// If the allow list is not empty, the host has to be matched by the allow list, or each of its IP addresses has to be contained in an allowed network.
+// If the allow list contains invalid entries, every target is denied, since ignoring the entries might allow targets that are meant to be denied
+func (c requestValidator) checkTarget(target string, host string, port string, ipAddresses AdrDomainNameMapping) *targetViolation {
+	denyRules := append(defaultDeniedRules(), ParseDenyRules(c.denyListProvider.Get())...)
+	for _, rule := range denyRules {
+		if rule.MatchesTarget(target) || rule.MatchesHost(host, port) {
+			return &targetViolation{kind: deniedHost, rule: rule}
 		}
-		err := validateIPDomain(ipAddresses, url)
-		if err != nil {
-			return err
+		for ip, hosts := range ipAddresses {
+			if rule.MatchesIP(net.ParseIP(ip), port) {
+				return &targetViolation{kind: deniedIP, rule: rule}
+			}
+			for _, h := range hosts {
+				if h != "" && rule.MatchesHost(h, port) {
+					return &targetViolation{kind: deniedResolvedHost, rule: rule}
+				}
+			}
 		}
 	}
-	return nil
+
+	allowRules, errs := ParseTargetRules(c.denyListProvider.GetAllowList())
+	if len(errs) > 0 {
+		return &targetViolation{kind: invalidAllowList}
+	}
+	if len(allowRules) == 0 || isAllowed(allowRules, host, port, ipAddresses) {
+		return nil
+	}
+	return &targetViolation{kind: notAllowed}
 }
 
-func validateIPDomain(ipAddresses AdrDomainNameMapping, url string) error {
-	for ip, hosts := range ipAddresses {
-		if strings.Contains(ip, url) {
-			return fmt.Errorf("curl command contains denied IP address '%s'", url)
+func isAllowed(allowRules []TargetRule, host string, port string, ipAddresses AdrDomainNameMapping) bool {
+	for _, rule := range allowRules {
+		if rule.MatchesHost(host, port) {
+			return true
 		}
-		for _, h := range hosts {
-			if strings.Contains(trimEndDot(h), url) {
-				return fmt.Errorf("curl command url resolves to denied host '%s'", url)
+	}
+	if len(ipAddresses) == 0 {
+		return false
+	}
+	for ip := range ipAddresses {
+		allowed := false
+		for _, rule := range allowRules {
+			if rule.MatchesIP(net.ParseIP(ip), port) {
+				allowed = true
+				break
 			}
 		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// getPort returns the port of the given URL, or the default port of its scheme
+func getPort(url *neturl.URL) string {
+	if port := url.Port(); port != "" {
+		return port
+	}
+	if url.Scheme == "https" {
+		return "443"
 	}
-	return nil
+	return "80"
 }
 
 func trimEndDot(h string) string {
 	lastIdx := len(h) - 1
-	if h[lastIdx] == '.' {
+	if lastIdx >= 0 && h[lastIdx] == '.' {
 		h = h[:lastIdx]
 	}
 	return h
@@ -2,11 +2,15 @@ package lib_test
 
 import (
 	"fmt"
+	"net"
 	"testing"
 
 	"github.com/keptn/keptn/webhook-service/lib"
 	"github.com/keptn/keptn/webhook-service/lib/fake"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
 
 func TestRequestValidator_Validate(t *testing.T) {
@@ -99,10 +103,10 @@ func TestRequestValidator_Validate(t *testing.T) {
 			},
 			denyListProvider: fake.DenyListProviderMock{
 				GetDenyListFunc: func() []string {
-					return []string{"some-denied"}
+					return []string{"some-denied-url"}
 				},
 			},
-			want:    fmt.Errorf("curl command contains denied URL 'some-denied'"),
+			want:    fmt.Errorf("curl command contains denied URL 'some-denied-url'"),
 			wantErr: true,
 		},
 		{
@@ -163,6 +167,117 @@ func TestRequestValidator_Validate(t *testing.T) {
 			want:    fmt.Errorf("curl command url resolves to denied host 'svc.cluster.local'"),
 			wantErr: true,
 		},
+		{
+			name: "deny list entry is not matched partially",
+			data: lib.Request{
+				Method: "GET",
+				URL:    "http://some-denied-url-2",
+			},
+			ipResolver: fake.IPResolverMock{
+				ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+					res := make(lib.AdrDomainNameMapping)
+					res["10.0.0.10"] = []string{}
+					return res, nil
+				},
+			},
+			denyListProvider: fake.DenyListProviderMock{
+				GetDenyListFunc: func() []string {
+					return []string{"10.0.0.1", "some-denied-url"}
+				},
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "denied network",
+			data: lib.Request{
+				Method: "GET",
+				URL:    "http://some-url",
+			},
+			ipResolver: fake.IPResolverMock{
+				ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+					res := make(lib.AdrDomainNameMapping)
+					res["10.0.0.10"] = []string{}
+					return res, nil
+				},
+			},
+			denyListProvider: fake.DenyListProviderMock{
+				GetDenyListFunc: func() []string {
+					return []string{"10.0.0.0/8"}
+				},
+			},
+			want:    fmt.Errorf("curl command contains denied IP address '10.0.0.0/8'"),
+			wantErr: true,
+		},
+		{
+			name: "metadata endpoint denied by default",
+			data: lib.Request{
+				Method: "GET",
+				URL:    "http://169.254.169.254/latest/meta-data",
+			},
+			ipResolver: fake.IPResolverMock{
+				ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+					res := make(lib.AdrDomainNameMapping)
+					res["169.254.169.254"] = []string{}
+					return res, nil
+				},
+			},
+			denyListProvider: fake.DenyListProviderMock{
+				GetDenyListFunc: func() []string {
+					return []string{}
+				},
+			},
+			want:    fmt.Errorf("curl command contains denied URL '169.254.0.0/16'"),
+			wantErr: true,
+		},
+		{
+			name: "URL not contained in allow list",
+			data: lib.Request{
+				Method: "GET",
+				URL:    "https://other.com/hook",
+			},
+			ipResolver: fake.IPResolverMock{
+				ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+					res := make(lib.AdrDomainNameMapping)
+					res["1.1.1.1"] = []string{}
+					return res, nil
+				},
+			},
+			denyListProvider: fake.DenyListProviderMock{
+				GetDenyListFunc: func() []string {
+					return []string{}
+				},
+				GetAllowListFunc: func() []string {
+					return []string{"*.example.com"}
+				},
+			},
+			want:    fmt.Errorf("curl command URL 'https://other.com/hook' is not contained in the allow list"),
+			wantErr: true,
+		},
+		{
+			name: "URL contained in allow list",
+			data: lib.Request{
+				Method: "GET",
+				URL:    "https://hooks.example.com/hook",
+			},
+			ipResolver: fake.IPResolverMock{
+				ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+					res := make(lib.AdrDomainNameMapping)
+					res["1.1.1.1"] = []string{}
+					return res, nil
+				},
+			},
+			denyListProvider: fake.DenyListProviderMock{
+				GetDenyListFunc: func() []string {
+					return []string{}
+				},
+				GetAllowListFunc: func() []string {
+					return []string{"*.example.com"}
+				},
+			},
+			want:    nil,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,3 +294,162 @@ func TestRequestValidator_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestValidator_ValidateAddress(t *testing.T) {
+	ipResolver := fake.IPResolverMock{
+		LookupHostsFunc: func(ips []net.IP) lib.AdrDomainNameMapping {
+			res := make(lib.AdrDomainNameMapping)
+			for _, ip := range ips {
+				res[ip.String()] = []string{"some-host.svc.cluster.local."}
+			}
+			return res
+		},
+	}
+	tests := []struct {
+		name      string
+		address   string
+		ips       []net.IP
+		denyList  []string
+		allowList []string
+		want      error
+	}{
+		{
+			name:     "valid address",
+			address:  "some-url:443",
+			ips:      []net.IP{net.ParseIP("1.1.1.1")},
+			denyList: []string{"1.1.1.2"},
+			want:     nil,
+		},
+		{
+			name:     "denied host",
+			address:  "kubernetes:443",
+			ips:      []net.IP{net.ParseIP("1.1.1.1")},
+			denyList: []string{"kubernetes:443"},
+			want:     fmt.Errorf("request target 'kubernetes:443' is denied by 'kubernetes:443'"),
+		},
+		{
+			name:     "denied IP",
+			address:  "some-url:443",
+			ips:      []net.IP{net.ParseIP("1.1.1.1"), net.ParseIP("10.0.0.1")},
+			denyList: []string{"10.0.0.1"},
+			want:     fmt.Errorf("request target 'some-url:443' resolves to denied IP address '10.0.0.1'"),
+		},
+		{
+			name:     "loopback address denied by default",
+			address:  "some-url:443",
+			ips:      []net.IP{net.ParseIP("127.0.0.2")},
+			denyList: []string{},
+			want:     fmt.Errorf("request target 'some-url:443' resolves to denied IP address '127.0.0.0/8'"),
+		},
+		{
+			name:     "denied port",
+			address:  "some-url:8080",
+			ips:      []net.IP{net.ParseIP("1.1.1.1")},
+			denyList: []string{"1.1.1.0/24:8080"},
+			want:     fmt.Errorf("request target 'some-url:8080' resolves to denied IP address '1.1.1.0/24:8080'"),
+		},
+		{
+			name:     "other port",
+			address:  "some-url:443",
+			ips:      []net.IP{net.ParseIP("1.1.1.1")},
+			denyList: []string{"1.1.1.0/24:8080"},
+			want:     nil,
+		},
+		{
+			name:      "IP addresses contained in allowed network",
+			address:   "some-url:443",
+			ips:       []net.IP{net.ParseIP("192.168.1.1"), net.ParseIP("192.168.2.1")},
+			denyList:  []string{},
+			allowList: []string{"192.168.0.0/16"},
+			want:      nil,
+		},
+		{
+			name:      "IP address not contained in allowed network",
+			address:   "some-url:443",
+			ips:       []net.IP{net.ParseIP("192.168.1.1"), net.ParseIP("10.0.0.1")},
+			denyList:  []string{},
+			allowList: []string{"192.168.0.0/16"},
+			want:      fmt.Errorf("request target 'some-url:443' is not contained in the allow list"),
+		},
+		{
+			name:      "invalid entry in allow list",
+			address:   "some-url:443",
+			ips:       []net.IP{net.ParseIP("192.168.1.1")},
+			denyList:  []string{},
+			allowList: []string{"192.168.0.0/16", "192.168.0.0/33"},
+			want:      fmt.Errorf("request target 'some-url:443' is denied, since the allow list contains invalid entries"),
+		},
+		{
+			name:     "invalid entry in deny list matches IP addresses containing it",
+			address:  "some-url:443",
+			ips:      []net.IP{net.ParseIP("1.1.1.1"), net.ParseIP("10.0.0.5")},
+			denyList: []string{"10.0.0."},
+			want:     fmt.Errorf("request target 'some-url:443' resolves to denied IP address '10.0.0.'"),
+		},
+		{
+			name:     "denied domain",
+			address:  "some-url:443",
+			ips:      []net.IP{net.ParseIP("1.1.1.1")},
+			denyList: []string{"svc.cluster.local"},
+			want:     fmt.Errorf("request target 'some-url:443' resolves to denied host 'svc.cluster.local'"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			denyListProvider := fake.DenyListProviderMock{
+				GetDenyListFunc: func() []string {
+					return tt.denyList
+				},
+				GetAllowListFunc: func() []string {
+					return tt.allowList
+				},
+			}
+			requestValidator := lib.NewRequestValidator(denyListProvider, ipResolver)
+			require.Equal(t, tt.want, requestValidator.ValidateAddress(tt.address, tt.ips))
+		})
+	}
+}
+
+// TestRequestValidator_ValidateBaselineConfigMap ensures that the deny lists of ConfigMaps created before the introduction of target rules,
+// which were matched as substrings of the URL, still deny the same targets
+func TestRequestValidator_ValidateBaselineConfigMap(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: lib.WebhookConfigMap, Namespace: "keptn"},
+		Data: map[string]string{
+			"denyList": "kubernetes\nkubernetes.default\nkubernetes.default.svc\nkubernetes.default.svc.cluster.local\nsvc.cluster.local\ncluster.local\nlocalhost\n127.0.0.1\n::1\n" +
+				"http://internal-svc\nhttps://billing.example.com:8443/api\n10.0.0.1\n10.0.0.\nexample.com/admin",
+		},
+	}
+	ipResolver := fake.IPResolverMock{
+		ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+			return lib.AdrDomainNameMapping{"1.1.1.1": []string{"some-host.example.com."}}, nil
+		},
+	}
+	requestValidator := lib.NewRequestValidator(lib.NewDenyListProvider(k8sfake.NewSimpleClientset(configMap)), ipResolver)
+
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{url: "http://kubernetes.default.svc/api", want: fmt.Errorf("curl command contains denied URL 'kubernetes.default.svc'")},
+		{url: "http://my-service.keptn.svc.cluster.local", want: fmt.Errorf("curl command contains denied URL 'svc.cluster.local'")},
+		{url: "http://localhost:8080", want: fmt.Errorf("curl command contains denied URL 'localhost'")},
+		{url: "http://[::1]:8080", want: fmt.Errorf("curl command contains denied URL '::1/128'")},
+		{url: "http://internal-svc/jobs", want: fmt.Errorf("curl command contains denied URL 'http://internal-svc'")},
+		{url: "https://internal-svc:8443", want: fmt.Errorf("curl command contains denied URL 'http://internal-svc'")},
+		{url: "https://billing.example.com:8443/other", want: fmt.Errorf("curl command contains denied URL 'https://billing.example.com:8443/api'")},
+		{url: "http://10.0.0.1:8080", want: fmt.Errorf("curl command contains denied URL '10.0.0.1'")},
+		{url: "http://10.0.0.20/hook", want: fmt.Errorf("curl command contains denied URL '10.0.0.'")},
+		{url: "https://example.com/admin/users", want: fmt.Errorf("curl command contains denied URL 'example.com/admin'")},
+		{url: "https://example.com/public", want: nil},
+		{url: "https://billing.example.com/api", want: nil},
+		{url: "https://hooks.example.com", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			require.Equal(t, tt.want, requestValidator.Validate(lib.Request{Method: "GET", URL: tt.url}))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ResponseConfig defines how the response of a webhook request is evaluated
+type ResponseConfig struct {
+	// SuccessCodes are the status codes that indicate a successful request. If empty, every status code below 400 is considered successful
+	SuccessCodes []int `yaml:"successCodes,omitempty"`
+	// SuccessCondition is a template that has to evaluate to 'true' for the request to be successful, e.g. '{{ eq .body.status "done" }}'
+	SuccessCondition string `yaml:"successCondition,omitempty"`
+	// Outputs maps the names of outputs to JSONPath expressions that are evaluated on the response body, e.g. 'jobId: $.id'
+	Outputs map[string]string `yaml:"outputs,omitempty"`
+}
+
+// HTTPResponse is the response of a webhook request
+type HTTPResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Body       string `json:"body"`
+}
+
+// IsSuccessStatusCode determines whether the given status code indicates a successful request
+func (rc *ResponseConfig) IsSuccessStatusCode(statusCode int) bool {
+	if rc == nil || len(rc.SuccessCodes) == 0 {
+		return statusCode < 400
+	}
+	for _, code := range rc.SuccessCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseResponseBody returns the JSON content of the given body, or the body itself if it does not contain valid JSON
+func ParseResponseBody(body string) interface{} {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(body), &parsed); err != nil {
+		return body
+	}
+	return parsed
+}
+
+// ExtractOutputs evaluates the JSONPath expressions of the outputs on the given response body
+func (rc *ResponseConfig) ExtractOutputs(body string) (map[string]interface{}, error) {
+	outputs := map[string]interface{}{}
+	if rc == nil || len(rc.Outputs) == 0 {
+		return outputs, nil
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return nil, fmt.Errorf("could not parse response as JSON: %w", err)
+	}
+	for name, path := range rc.Outputs {
+		value, err := evaluateJSONPath(data, path)
+		if err != nil {
+			return nil, fmt.Errorf("could not extract output '%s': %w", name, err)
+		}
+		outputs[name] = value
+	}
+	return outputs, nil
+}
+
+func evaluateJSONPath(data interface{}, path string) (interface{}, error) {
+	jp := jsonpath.New("output")
+	if err := jp.Parse(normalizeJSONPath(path)); err != nil {
+		return nil, err
+	}
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+	values := []interface{}{}
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, value.Interface())
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no value found for '%s'", path)
+	}
+	if len(values) == 1 {
+		return values[0], nil
+	}
+	return values, nil
+}
+
+// normalizeJSONPath converts expressions like '$.id' or '.id' into the '{$.id}' notation
+func normalizeJSONPath(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	return "{" + path + "}"
+}
+
+func verifyResponseConfig(request Request) error {
+	rc := request.Response
+	if rc == nil {
+		return nil
+	}
+	if len(rc.SuccessCodes) > 0 && request.Options != "" {
+		return errors.New(webhookConfInvalid + "webhook response successCodes are not supported for requests with options")
+	}
+	return verifyOutputs(rc.Outputs)
+}
+
+func verifyOutputs(outputs map[string]string) error {
+	for name, path := range outputs {
+		if name == "" || name == ResponsesKey {
+			return fmt.Errorf(webhookConfInvalid+"invalid webhook response output name '%s'", name)
+		}
+		if err := jsonpath.New(name).Parse(normalizeJSONPath(path)); err != nil {
+			return fmt.Errorf(webhookConfInvalid+"invalid JSONPath '%s' for webhook response output '%s': %s", path, name, err.Error())
+		}
+	}
+	return nil
+}
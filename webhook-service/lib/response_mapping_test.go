@@ -0,0 +1,82 @@
+package lib_test
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseConfig_IsSuccessStatusCode(t *testing.T) {
+	var noConfig *lib.ResponseConfig
+	assert.True(t, noConfig.IsSuccessStatusCode(204))
+	assert.False(t, noConfig.IsSuccessStatusCode(404))
+
+	config := &lib.ResponseConfig{SuccessCodes: []int{200, 404}}
+	assert.True(t, config.IsSuccessStatusCode(404))
+	assert.False(t, config.IsSuccessStatusCode(201))
+}
+
+func TestResponseConfig_ExtractOutputs(t *testing.T) {
+	body := `{"id": "job-1", "status": {"state": "running"}, "stages": [{"name": "build"}, {"name": "test"}], "count": 2}`
+	tests := []struct {
+		name    string
+		outputs map[string]string
+		body    string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "no outputs",
+			outputs: nil,
+			body:    "not json",
+			want:    map[string]interface{}{},
+		},
+		{
+			name: "extract values",
+			outputs: map[string]string{
+				"jobId":  "$.id",
+				"state":  ".status.state",
+				"stages": "{.stages[*].name}",
+				"count":  "$.count",
+			},
+			body: body,
+			want: map[string]interface{}{
+				"jobId":  "job-1",
+				"state":  "running",
+				"stages": []interface{}{"build", "test"},
+				"count":  float64(2),
+			},
+		},
+		{
+			name:    "missing value",
+			outputs: map[string]string{"jobId": "$.unknown"},
+			body:    body,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			outputs: map[string]string{"jobId": "$.id"},
+			body:    "not json",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &lib.ResponseConfig{Outputs: tt.outputs}
+			got, err := config.ExtractOutputs(tt.body)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseResponseBody(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"id": "job-1"}, lib.ParseResponseBody(`{"id": "job-1"}`))
+	assert.Equal(t, "plain text", lib.ParseResponseBody("plain text"))
+}
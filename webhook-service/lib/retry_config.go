@@ -0,0 +1,70 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultRetryBackoff    = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+	maxRetryAttempts       = 10
+)
+
+// RetryConfig defines how often a webhook request is retried if it fails with a transient error, i.e., a connection error,
+// a timeout or a response with status code 429 or 5xx. The duration between two attempts is doubled after each attempt
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts, including the initial one
+	MaxAttempts int `yaml:"maxAttempts"`
+	// Backoff is the duration between the first and the second attempt, defaults to 1s
+	Backoff string `yaml:"backoff,omitempty"`
+	// MaxBackoff is the maximum duration between two attempts, defaults to 30s
+	MaxBackoff string `yaml:"maxBackoff,omitempty"`
+}
+
+// GetMaxAttempts returns the maximum number of attempts of the request
+func (rc *RetryConfig) GetMaxAttempts() int {
+	if rc == nil || rc.MaxAttempts < 1 {
+		return 1
+	}
+	return rc.MaxAttempts
+}
+
+// GetBackoff returns the duration to wait before the given attempt, starting with 1 for the first retry
+func (rc *RetryConfig) GetBackoff(retry int) time.Duration {
+	if rc == nil {
+		return 0
+	}
+	backoff := parseDurationOrDefault(rc.Backoff, defaultRetryBackoff)
+	maxBackoff := parseDurationOrDefault(rc.MaxBackoff, defaultRetryMaxBackoff)
+	for i := 1; i < retry && backoff < maxBackoff; i++ {
+		backoff = backoff * 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+func verifyRetryConfig(request Request) error {
+	rc := request.Retry
+	if rc == nil {
+		return nil
+	}
+	if request.Options != "" {
+		return errors.New(webhookConfInvalid + "webhook request retry is not supported for requests with options")
+	}
+	if rc.MaxAttempts < 1 || rc.MaxAttempts > maxRetryAttempts {
+		return fmt.Errorf(webhookConfInvalid+"webhook request retry maxAttempts must be between 1 and %d", maxRetryAttempts)
+	}
+	for _, duration := range []string{rc.Backoff, rc.MaxBackoff} {
+		if duration == "" {
+			continue
+		}
+		if parsed, err := time.ParseDuration(duration); err != nil || parsed <= 0 {
+			return fmt.Errorf(webhookConfInvalid+"invalid webhook request retry duration '%s'", duration)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package lib_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryConfig_GetBackoff(t *testing.T) {
+	retry := &lib.RetryConfig{MaxAttempts: 5, Backoff: "1s", MaxBackoff: "5s"}
+	assert.Equal(t, time.Second, retry.GetBackoff(1))
+	assert.Equal(t, 2*time.Second, retry.GetBackoff(2))
+	assert.Equal(t, 4*time.Second, retry.GetBackoff(3))
+	assert.Equal(t, 5*time.Second, retry.GetBackoff(4))
+
+	var noRetry *lib.RetryConfig
+	assert.Equal(t, 1, noRetry.GetMaxAttempts())
+	assert.Equal(t, time.Duration(0), noRetry.GetBackoff(1))
+}
@@ -3,13 +3,31 @@ package lib
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	secretManagedByLabel = "app.kubernetes.io/managed-by"
+	secretScopeLabel     = "app.kubernetes.io/scope"
+	secretServiceName    = "keptn-secret-service"
+	// DefaultSecretScope is the scope of secrets that are referenced without a scope
+	DefaultSecretScope = "keptn-webhook-service"
+)
+
+// secretRefNameRegex matches valid names of secrets, keys and scopes. Since they are used as file names by the FileSecretReader,
+// path separators are not allowed
+var secretRefNameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([-._a-zA-Z0-9]*[a-zA-Z0-9])?$`)
+
 //go:generate moq  -pkg fake -out ./fake/secret_reader_mock.go . ISecretReader
 type ISecretReader interface {
-	ReadSecret(name, key string) (string, error)
+	ReadSecret(ref WebHookSecretRef) (string, error)
 }
 
 type K8sSecretReater struct {
@@ -20,14 +38,81 @@ func NewK8sSecretReader(k8sClient kubernetes.Interface) *K8sSecretReater {
 	return &K8sSecretReater{k8sClient: k8sClient}
 }
 
-func (sr *K8sSecretReater) ReadSecret(name, key string) (string, error) {
-	secret, err := sr.k8sClient.CoreV1().Secrets(GetNamespaceFromEnvVar()).Get(context.TODO(), name, metav1.GetOptions{})
+func (sr *K8sSecretReater) ReadSecret(ref WebHookSecretRef) (string, error) {
+	secret, err := sr.k8sClient.CoreV1().Secrets(GetNamespaceFromEnvVar()).Get(context.TODO(), ref.Name, metav1.GetOptions{})
 	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", fmt.Errorf("secret '%s' does not exist", ref.Name)
+		}
+		if k8serrors.IsForbidden(err) {
+			return "", fmt.Errorf("the webhook service is not permitted to read secret '%s'", ref.Name)
+		}
 		return "", err
 	}
 	// only allow reading from secrets that are managed by Keptn's secret-service
-	if secret.Labels["app.kubernetes.io/managed-by"] != "keptn-secret-service" {
+	if secret.Labels[secretManagedByLabel] != secretServiceName {
 		return "", errors.New("only secrets managed by Keptn's secret-service can be referenced")
 	}
-	return string(secret.Data[key]), nil
+	if secret.Labels[secretScopeLabel] != ref.GetScope() {
+		return "", fmt.Errorf("secret '%s' does not belong to scope '%s'", ref.Name, ref.GetScope())
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret '%s' does not contain key '%s'", ref.Name, ref.Key)
+	}
+	return string(value), nil
+}
+
+// FileSecretReader reads secrets from a directory, e.g. a volume populated by an external secret store.
+// The value of a secret is read from the file <basePath>/<scope>/<name>/<key>, or <basePath>/<name>/<key> if no scope is given
+type FileSecretReader struct {
+	basePath string
+}
+
+func NewFileSecretReader(basePath string) *FileSecretReader {
+	return &FileSecretReader{basePath: basePath}
+}
+
+func (sr *FileSecretReader) ReadSecret(ref WebHookSecretRef) (string, error) {
+	elements := []string{sr.basePath}
+	if ref.Scope != "" {
+		elements = append(elements, ref.Scope)
+	}
+	elements = append(elements, ref.Name, ref.Key)
+	for _, element := range elements[1:] {
+		if !secretRefNameRegex.MatchString(element) {
+			return "", fmt.Errorf("invalid secret reference '%s'", element)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(elements...))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("secret '%s' does not exist or does not contain key '%s'", ref.Name, ref.Key)
+		}
+		return "", fmt.Errorf("could not read secret '%s'", ref.Name)
+	}
+	return string(content), nil
+}
+
+// ScopeRestrictedSecretReader only allows reading secrets of the given scopes. References without a scope refer to the default scope,
+// i.e. they are only allowed if the default scope is allowed
+type ScopeRestrictedSecretReader struct {
+	secretReader  ISecretReader
+	allowedScopes map[string]bool
+}
+
+func NewScopeRestrictedSecretReader(secretReader ISecretReader, allowedScopes []string) *ScopeRestrictedSecretReader {
+	scopes := map[string]bool{}
+	for _, scope := range allowedScopes {
+		scopes[scope] = true
+	}
+	return &ScopeRestrictedSecretReader{secretReader: secretReader, allowedScopes: scopes}
+}
+
+func (sr *ScopeRestrictedSecretReader) ReadSecret(ref WebHookSecretRef) (string, error) {
+	if !sr.allowedScopes[ref.GetScope()] {
+		return "", fmt.Errorf("secrets of scope '%s' cannot be referenced by webhooks", ref.GetScope())
+	}
+	return sr.secretReader.ReadSecret(ref)
 }
@@ -1,9 +1,14 @@
 package lib_test
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/keptn/keptn/webhook-service/lib"
+	libfake "github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,18 +20,71 @@ func TestK8sSecretReater_ReadSecret(t *testing.T) {
 	secretReader := lib.NewK8sSecretReader(fake.NewSimpleClientset(
 		getK8sSecret(map[string]string{
 			"app.kubernetes.io/managed-by": "keptn-secret-service",
+			"app.kubernetes.io/scope":      "keptn-webhook-service",
+		}),
+		getNamedK8sSecret("my-foreign-secret", map[string]string{
+			"app.kubernetes.io/managed-by": "keptn-secret-service",
+			"app.kubernetes.io/scope":      "dynatrace-service",
+		}),
+		getNamedK8sSecret("my-unscoped-secret", map[string]string{
+			"app.kubernetes.io/managed-by": "keptn-secret-service",
 		}),
 	))
 
-	secret, err := secretReader.ReadSecret("my-secret", "foo")
-
-	require.Nil(t, err)
-	require.Equal(t, "bar", secret)
-
-	secret, err = secretReader.ReadSecret("my-missing-secret", "foo")
-
-	require.NotNil(t, err)
-	require.Empty(t, secret)
+	tests := []struct {
+		name    string
+		ref     lib.WebHookSecretRef
+		want    string
+		wantErr string
+	}{
+		{
+			name: "secret without scope",
+			ref:  lib.WebHookSecretRef{Name: "my-secret", Key: "foo"},
+			want: "bar",
+		},
+		{
+			name: "secret with matching scope",
+			ref:  lib.WebHookSecretRef{Name: "my-secret", Key: "foo", Scope: "keptn-webhook-service"},
+			want: "bar",
+		},
+		{
+			name:    "secret with other scope",
+			ref:     lib.WebHookSecretRef{Name: "my-secret", Key: "foo", Scope: "dynatrace-service"},
+			wantErr: "secret 'my-secret' does not belong to scope 'dynatrace-service'",
+		},
+		{
+			name:    "secret of other scope without scope",
+			ref:     lib.WebHookSecretRef{Name: "my-foreign-secret", Key: "foo"},
+			wantErr: "secret 'my-foreign-secret' does not belong to scope 'keptn-webhook-service'",
+		},
+		{
+			name:    "secret without scope label",
+			ref:     lib.WebHookSecretRef{Name: "my-unscoped-secret", Key: "foo"},
+			wantErr: "secret 'my-unscoped-secret' does not belong to scope 'keptn-webhook-service'",
+		},
+		{
+			name:    "missing secret",
+			ref:     lib.WebHookSecretRef{Name: "my-missing-secret", Key: "foo"},
+			wantErr: "secret 'my-missing-secret' does not exist",
+		},
+		{
+			name:    "missing key",
+			ref:     lib.WebHookSecretRef{Name: "my-secret", Key: "baz"},
+			wantErr: "secret 'my-secret' does not contain key 'baz'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := secretReader.ReadSecret(tt.ref)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				require.Empty(t, secret)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, secret)
+		})
+	}
 }
 
 func TestK8sSecretReater_ReadSecretWithInvalidScope(t *testing.T) {
@@ -35,16 +93,106 @@ func TestK8sSecretReater_ReadSecretWithInvalidScope(t *testing.T) {
 		getK8sSecret(map[string]string{}),
 	))
 
-	secret, err := secretReader.ReadSecret("my-secret", "foo")
+	secret, err := secretReader.ReadSecret(lib.WebHookSecretRef{Name: "my-secret", Key: "foo"})
 
 	require.NotNil(t, err)
 	require.Equal(t, "", secret)
 }
 
+func TestFileSecretReader_ReadSecret(t *testing.T) {
+	basePath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(basePath, "my-secret"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(basePath, "my-secret", "foo"), []byte("bar"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(basePath, "my-scope", "my-secret"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(basePath, "my-scope", "my-secret", "foo"), []byte("scoped-bar"), 0600))
+
+	secretReader := lib.NewFileSecretReader(basePath)
+
+	tests := []struct {
+		name    string
+		ref     lib.WebHookSecretRef
+		want    string
+		wantErr string
+	}{
+		{
+			name: "secret without scope",
+			ref:  lib.WebHookSecretRef{Name: "my-secret", Key: "foo"},
+			want: "bar",
+		},
+		{
+			name: "secret with scope",
+			ref:  lib.WebHookSecretRef{Name: "my-secret", Key: "foo", Scope: "my-scope"},
+			want: "scoped-bar",
+		},
+		{
+			name:    "missing key",
+			ref:     lib.WebHookSecretRef{Name: "my-secret", Key: "baz"},
+			wantErr: "secret 'my-secret' does not exist or does not contain key 'baz'",
+		},
+		{
+			name:    "path traversal",
+			ref:     lib.WebHookSecretRef{Name: "..", Key: "passwd"},
+			wantErr: "invalid secret reference '..'",
+		},
+		{
+			name:    "path separator",
+			ref:     lib.WebHookSecretRef{Name: "my-scope/my-secret", Key: "foo"},
+			wantErr: "invalid secret reference 'my-scope/my-secret'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := secretReader.ReadSecret(tt.ref)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				require.Empty(t, secret)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, secret)
+		})
+	}
+}
+
+func TestScopeRestrictedSecretReader_ReadSecret(t *testing.T) {
+	secretReaderMock := &libfake.ISecretReaderMock{ReadSecretFunc: func(ref lib.WebHookSecretRef) (string, error) {
+		if ref.Name == "my-missing-secret" {
+			return "", errors.New("secret 'my-missing-secret' does not exist")
+		}
+		return "bar", nil
+	}}
+	secretReader := lib.NewScopeRestrictedSecretReader(secretReaderMock, []string{"keptn-webhook-service", "my-scope"})
+
+	secret, err := secretReader.ReadSecret(lib.WebHookSecretRef{Name: "my-secret", Key: "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, "bar", secret)
+
+	secret, err = secretReader.ReadSecret(lib.WebHookSecretRef{Name: "my-secret", Key: "foo", Scope: "my-scope"})
+	require.NoError(t, err)
+	assert.Equal(t, "bar", secret)
+
+	_, err = secretReader.ReadSecret(lib.WebHookSecretRef{Name: "my-missing-secret", Key: "foo", Scope: "my-scope"})
+	require.EqualError(t, err, "secret 'my-missing-secret' does not exist")
+
+	_, err = secretReader.ReadSecret(lib.WebHookSecretRef{Name: "my-secret", Key: "foo", Scope: "dynatrace-service"})
+	require.EqualError(t, err, "secrets of scope 'dynatrace-service' cannot be referenced by webhooks")
+	assert.Len(t, secretReaderMock.ReadSecretCalls(), 3)
+
+	// references without scope refer to the default scope, which is not allowed here
+	restrictedSecretReader := lib.NewScopeRestrictedSecretReader(secretReaderMock, []string{"my-scope"})
+	_, err = restrictedSecretReader.ReadSecret(lib.WebHookSecretRef{Name: "my-secret", Key: "foo"})
+	require.EqualError(t, err, "secrets of scope 'keptn-webhook-service' cannot be referenced by webhooks")
+	assert.Len(t, secretReaderMock.ReadSecretCalls(), 3)
+}
+
 func getK8sSecret(labels map[string]string) *corev1.Secret {
+	return getNamedK8sSecret("my-secret", labels)
+}
+
+func getNamedK8sSecret(name string, labels map[string]string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "my-secret",
+			Name:      name,
 			Namespace: "keptn",
 			Labels:    labels,
 		},
@@ -0,0 +1,202 @@
+package lib
+
+import (
+	"fmt"
+	"net"
+	neturl "net/url"
+	"strings"
+)
+
+// DefaultDeniedNetworks are denied for every webhook request, regardless of the deny and allow list:
+// loopback, link-local (including the metadata endpoints of cloud providers) and unspecified addresses
+var DefaultDeniedNetworks = []string{
+	"127.0.0.0/8",
+	"::1/128",
+	"169.254.0.0/16",
+	"fe80::/10",
+	"fd00:ec2::254/128",
+	"100.100.100.200/32",
+	"0.0.0.0/32",
+	"::/128",
+}
+
+// DefaultDeniedHosts are host names of metadata endpoints that are denied for every webhook request
+var DefaultDeniedHosts = []string{
+	"metadata.google.internal",
+}
+
+// TargetRule is an entry of the deny or allow list of webhook targets. The following formats are supported:
+//   - an IP address, e.g. '10.0.0.1', or a network in CIDR notation, e.g. '10.0.0.0/8', matching the resolved IP addresses of the target
+//   - a host name, e.g. 'example.com', matching the host itself and its subdomains
+//   - a wildcard host name, e.g. '*.example.com', matching the subdomains of the host only. '*' matches every host
+//   - a URL, e.g. 'http://internal-svc:8080/api', matching its host and port. The scheme and path are ignored
+//
+// Each of the formats can be combined with a port, e.g. 'kubernetes:443' or '[::1]:8080', to match the given port only
+type TargetRule struct {
+	entry       string
+	network     *net.IPNet
+	hostPattern string
+	port        string
+	// substring is set for entries of the deny list that are no valid target rule, see ParseDenyRules
+	substring string
+}
+
+// ParseTargetRule parses the given entry of a deny or allow list
+func ParseTargetRule(entry string) (TargetRule, error) {
+	rule := TargetRule{entry: entry}
+	value := strings.ToLower(strings.TrimSpace(entry))
+	if value == "" {
+		return rule, fmt.Errorf("empty entry")
+	}
+	if strings.Contains(value, "://") {
+		// entries of deny lists created before the introduction of target rules are often URLs
+		parsedURL, err := neturl.Parse(value)
+		if err != nil || parsedURL.Hostname() == "" {
+			return rule, fmt.Errorf("invalid URL '%s'", entry)
+		}
+		value = parsedURL.Hostname()
+		if port := parsedURL.Port(); port != "" {
+			value = net.JoinHostPort(value, port)
+		}
+	}
+	if host, port, err := net.SplitHostPort(value); err == nil {
+		if port == "" || strings.Trim(port, "0123456789") != "" {
+			return rule, fmt.Errorf("invalid port '%s' in entry '%s'", port, entry)
+		}
+		value = host
+		rule.port = port
+	}
+
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return rule, fmt.Errorf("invalid network '%s': %w", entry, err)
+		}
+		rule.network = network
+		return rule, nil
+	}
+	if ip := net.ParseIP(value); ip != nil {
+		rule.network = singleIPNetwork(ip)
+		return rule, nil
+	}
+	if err := validateHostPattern(value); err != nil {
+		return rule, fmt.Errorf("invalid host '%s': %w", entry, err)
+	}
+	rule.hostPattern = value
+	return rule, nil
+}
+
+// ParseTargetRules parses the given entries. Invalid entries are skipped, and the reasons are returned as errors
+func ParseTargetRules(entries []string) ([]TargetRule, []error) {
+	rules := []TargetRule{}
+	errs := []error{}
+	for _, entry := range entries {
+		rule, err := ParseTargetRule(entry)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errs
+}
+
+// ParseDenyRules parses the given entries of a deny list. Entries that are no valid target rule, e.g. '10.0.0.' or 'example.com/admin',
+// match every target containing them, as all entries did before the introduction of target rules, since ignoring them could allow
+// targets that are meant to be denied
+func ParseDenyRules(entries []string) []TargetRule {
+	rules := []TargetRule{}
+	for _, entry := range entries {
+		rule, err := ParseTargetRule(entry)
+		if err != nil {
+			substring := strings.ToLower(strings.TrimSpace(entry))
+			if substring == "" {
+				continue
+			}
+			rule = TargetRule{entry: entry, substring: substring}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (r TargetRule) String() string {
+	return r.entry
+}
+
+// MatchesHost determines whether the rule matches the given host name and port
+func (r TargetRule) MatchesHost(host string, port string) bool {
+	if r.port != "" && r.port != port {
+		return false
+	}
+	host = strings.ToLower(trimEndDot(host))
+	if r.substring != "" {
+		return strings.Contains(host, r.substring)
+	}
+	if r.network != nil {
+		ip := net.ParseIP(host)
+		return ip != nil && r.network.Contains(ip)
+	}
+	return matchesHostPattern(r.hostPattern, host)
+}
+
+// MatchesIP determines whether the rule matches the given IP address and port
+func (r TargetRule) MatchesIP(ip net.IP, port string) bool {
+	if r.substring != "" {
+		return strings.Contains(ip.String(), r.substring)
+	}
+	if r.network == nil || (r.port != "" && r.port != port) {
+		return false
+	}
+	return r.network.Contains(ip)
+}
+
+// MatchesTarget determines whether the rule matches the given URL or address as a whole, which is only the case for
+// entries of the deny list that are no valid target rule
+func (r TargetRule) MatchesTarget(target string) bool {
+	return r.substring != "" && strings.Contains(strings.ToLower(target), r.substring)
+}
+
+func matchesHostPattern(pattern string, host string) bool {
+	if host == "" {
+		return false
+	}
+	if pattern == "*" {
+		return true
+	}
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(host, pattern[1:])
+	}
+	return host == pattern || strings.HasSuffix(host, "."+pattern)
+}
+
+func validateHostPattern(pattern string) error {
+	if pattern == "*" {
+		return nil
+	}
+	labels := strings.Split(strings.TrimPrefix(pattern, "*."), ".")
+	for _, label := range labels {
+		if label == "" {
+			return fmt.Errorf("empty label")
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return fmt.Errorf("invalid character '%c'", c)
+			}
+		}
+	}
+	return nil
+}
+
+func singleIPNetwork(ip net.IP) *net.IPNet {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return &net.IPNet{IP: ipv4, Mask: net.CIDRMask(32, 32)}
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
+// defaultDeniedRules returns the rules that are denied for every webhook request
+func defaultDeniedRules() []TargetRule {
+	rules, _ := ParseTargetRules(append(append([]string{}, DefaultDeniedNetworks...), DefaultDeniedHosts...))
+	return rules
+}
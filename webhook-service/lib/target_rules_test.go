@@ -0,0 +1,126 @@
+package lib_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTargetRule(t *testing.T) {
+	tests := []struct {
+		entry   string
+		wantErr bool
+	}{
+		{entry: "10.0.0.1"},
+		{entry: "10.0.0.0/8"},
+		{entry: "fe80::/10"},
+		{entry: "::1"},
+		{entry: "[::1]:8080"},
+		{entry: "kubernetes:443"},
+		{entry: "*.example.com"},
+		{entry: "*"},
+		{entry: "10.0.0.0/33", wantErr: true},
+		{entry: "kubernetes:https", wantErr: true},
+		{entry: "example..com", wantErr: true},
+		{entry: "http://example.com"},
+		{entry: "https://[::1]:8080/api"},
+		{entry: "http://", wantErr: true},
+		{entry: "http://example.com:port", wantErr: true},
+		{entry: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			_, err := lib.ParseTargetRule(tt.entry)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseTargetRules(t *testing.T) {
+	rules, errs := lib.ParseTargetRules([]string{"10.0.0.0/8", "10.0.0.0/33", "example.com"})
+	require.Len(t, rules, 2)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "10.0.0.0/33")
+}
+
+func TestParseDenyRules(t *testing.T) {
+	rules := lib.ParseDenyRules([]string{"10.0.0.0/8", "10.0.0.", "example.com/admin", " "})
+	require.Len(t, rules, 3)
+
+	assert.True(t, rules[0].MatchesIP(net.ParseIP("10.1.2.3"), "443"))
+	assert.False(t, rules[0].MatchesTarget("http://10.1.2.3"))
+
+	assert.True(t, rules[1].MatchesIP(net.ParseIP("10.0.0.20"), "443"))
+	assert.False(t, rules[1].MatchesIP(net.ParseIP("10.0.1.20"), "443"))
+	assert.True(t, rules[1].MatchesHost("10.0.0.20", "443"))
+
+	assert.True(t, rules[2].MatchesTarget("https://example.com/admin/users"))
+	assert.False(t, rules[2].MatchesTarget("https://example.com/public"))
+	assert.False(t, rules[2].MatchesHost("example.com", "443"))
+}
+
+func TestTargetRule_MatchesHost(t *testing.T) {
+	tests := []struct {
+		entry string
+		host  string
+		port  string
+		want  bool
+	}{
+		{entry: "example.com", host: "example.com", port: "443", want: true},
+		{entry: "example.com", host: "hooks.example.com", port: "443", want: true},
+		{entry: "example.com", host: "myexample.com", port: "443", want: false},
+		{entry: "example.com", host: "example.com.evil.org", port: "443", want: false},
+		{entry: "*.example.com", host: "example.com", port: "443", want: false},
+		{entry: "*.example.com", host: "hooks.example.com", port: "443", want: true},
+		{entry: "*", host: "anything", port: "443", want: true},
+		{entry: "kubernetes:443", host: "kubernetes", port: "443", want: true},
+		{entry: "kubernetes:443", host: "kubernetes", port: "8080", want: false},
+		{entry: "Example.com", host: "EXAMPLE.COM.", port: "443", want: true},
+		{entry: "10.0.0.0/8", host: "10.1.2.3", port: "80", want: true},
+		{entry: "10.0.0.1", host: "10.0.0.10", port: "80", want: false},
+		{entry: "http://internal-svc", host: "internal-svc", port: "443", want: true},
+		{entry: "http://internal-svc/api", host: "internal-svc", port: "80", want: true},
+		{entry: "http://internal-svc:8080", host: "internal-svc", port: "8080", want: true},
+		{entry: "http://internal-svc:8080", host: "internal-svc", port: "80", want: false},
+		{entry: "https://[::1]:8080", host: "::1", port: "8080", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry+" "+tt.host, func(t *testing.T) {
+			rule, err := lib.ParseTargetRule(tt.entry)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.MatchesHost(tt.host, tt.port))
+		})
+	}
+}
+
+func TestTargetRule_MatchesIP(t *testing.T) {
+	tests := []struct {
+		entry string
+		ip    string
+		port  string
+		want  bool
+	}{
+		{entry: "10.0.0.1", ip: "10.0.0.1", port: "80", want: true},
+		{entry: "10.0.0.1", ip: "10.0.0.10", port: "80", want: false},
+		{entry: "172.16.0.0/12", ip: "172.31.255.255", port: "80", want: true},
+		{entry: "172.16.0.0/12", ip: "172.32.0.1", port: "80", want: false},
+		{entry: "127.0.0.0/8", ip: "::ffff:127.0.0.1", port: "80", want: true},
+		{entry: "fe80::/10", ip: "fe80::1", port: "80", want: true},
+		{entry: "10.0.0.0/8:8080", ip: "10.0.0.1", port: "80", want: false},
+		{entry: "example.com", ip: "10.0.0.1", port: "80", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry+" "+tt.ip, func(t *testing.T) {
+			rule, err := lib.ParseTargetRule(tt.entry)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.MatchesIP(net.ParseIP(tt.ip), tt.port))
+		})
+	}
+}
@@ -2,9 +2,25 @@ package lib
 
 import (
 	"bytes"
+	"regexp"
+	"strings"
 	"text/template"
+
+	"github.com/keptn/keptn/internal/templatefuncs"
 )
 
+// stringLiteralPattern matches the quoted, raw and character literals within a template action
+const stringLiteralPattern = `"(?:[^"\\]|\\.)*"|` + "`[^`]*`" + `|'(?:[^'\\]|\\.)*'`
+
+// templateActionRegex matches the actions of a template, i.e. the text between '{{' and '}}'
+var templateActionRegex = regexp.MustCompile(`(?s)\{\{(?:` + stringLiteralPattern + `|.)*?\}\}`)
+
+// stringLiteralRegex matches the string literals within a template action, which must be left untouched
+var stringLiteralRegex = regexp.MustCompile(`(?s)` + stringLiteralPattern)
+
+// responseIndexRegex matches references to the responses of previous requests by their position, e.g. '.responses.0'
+var responseIndexRegex = regexp.MustCompile(`(^|[^\w$.)\]])\.` + ResponsesKey + `\.(\d+)`)
+
 //go:generate moq  -pkg fake -out ./fake/template_engine_mock.go . ITemplateEngine
 type ITemplateEngine interface {
 	ParseTemplate(data interface{}, templateStr string) (string, error)
@@ -13,7 +29,7 @@ type ITemplateEngine interface {
 type TemplateEngine struct{}
 
 func (t *TemplateEngine) ParseTemplate(data interface{}, templateStr string) (string, error) {
-	tmpl, err := template.New("").Option("missingkey=error").Parse(templateStr)
+	tmpl, err := template.New("").Funcs(templatefuncs.FuncMap()).Option("missingkey=error").Parse(rewriteResponseIndices(templateStr))
 	if err != nil {
 		return "", err
 	}
@@ -23,3 +39,23 @@ func (t *TemplateEngine) ParseTemplate(data interface{}, templateStr string) (st
 	}
 	return tpl.String(), nil
 }
+
+// rewriteResponseIndices replaces references like '.responses.0' within the actions of a template by '(index .responses 0)',
+// since the template syntax does not support numeric field names. String literals within the actions are not rewritten
+func rewriteResponseIndices(templateStr string) string {
+	return templateActionRegex.ReplaceAllStringFunc(templateStr, func(action string) string {
+		var rewritten strings.Builder
+		last := 0
+		for _, literal := range stringLiteralRegex.FindAllStringIndex(action, -1) {
+			rewritten.WriteString(rewriteResponseIndexReferences(action[last:literal[0]]))
+			rewritten.WriteString(action[literal[0]:literal[1]])
+			last = literal[1]
+		}
+		rewritten.WriteString(rewriteResponseIndexReferences(action[last:]))
+		return rewritten.String()
+	})
+}
+
+func rewriteResponseIndexReferences(code string) string {
+	return responseIndexRegex.ReplaceAllString(code, "${1}(index ."+ResponsesKey+" ${2})")
+}
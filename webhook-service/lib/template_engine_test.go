@@ -75,6 +75,47 @@ func TestTemplateEngine_ParseTemplate(t1 *testing.T) {
 			wantErr: true,
 			errMsg:  ".env.barz",
 		},
+		{
+			name: "reference previous response by position",
+			args: args{
+				data: map[string]interface{}{
+					"responses": []interface{}{
+						map[string]interface{}{"body": map[string]interface{}{"token": "my-token"}},
+						map[string]interface{}{"body": map[string]interface{}{"id": "my-id"}},
+					},
+				},
+				templateStr: "Bearer {{.responses.0.body.token}} {{ (index .responses 1).body.id }} {{ upper .responses.1.body.id }} .responses.0",
+			},
+			want:    "Bearer my-token my-id MY-ID .responses.0",
+			wantErr: false,
+			errMsg:  "",
+		},
+		{
+			name: "do not rewrite string literals referencing a response by position",
+			args: args{
+				data: map[string]interface{}{
+					"responses": []interface{}{
+						map[string]interface{}{"body": map[string]interface{}{"token": "my-token"}},
+					},
+				},
+				templateStr: "{{ printf \"see .responses.0 }}\" }} {{ printf `%s .responses.0` .responses.0.body.token }}",
+			},
+			want:    "see .responses.0 }} my-token .responses.0",
+			wantErr: false,
+			errMsg:  "",
+		},
+		{
+			name: "reference missing response by position",
+			args: args{
+				data: map[string]interface{}{
+					"responses": []interface{}{},
+				},
+				templateStr: "Bearer {{.responses.0.body.token}}",
+			},
+			want:    "",
+			wantErr: true,
+			errMsg:  "index out of range",
+		},
 	}
 	for _, tt := range tests {
 		t1.Run(tt.name, func(t1 *testing.T) {
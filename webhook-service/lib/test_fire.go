@@ -0,0 +1,47 @@
+package lib
+
+import (
+	"errors"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+)
+
+// ErrInvalidTestFireRequest indicates that a TestFireRequest does not contain the information required to test a webhook
+var ErrInvalidTestFireRequest = errors.New("invalid test request")
+
+// TestFireRequest describes a webhook that should be tested using a sample event
+type TestFireRequest struct {
+	Project        string `json:"project"`
+	Stage          string `json:"stage"`
+	Service        string `json:"service"`
+	SubscriptionID string `json:"subscriptionID"`
+	// GitCommitID is the commit of the webhook config that should be used. If empty, the latest version is used
+	GitCommitID string `json:"gitCommitID,omitempty"`
+	// Event is used to render the templates of the webhook requests. Its project, stage and service are overridden by the ones of the TestFireRequest
+	Event models.KeptnContextExtendedCE `json:"event"`
+	// Execute determines whether the rendered requests are sent to their targets
+	Execute bool `json:"execute"`
+}
+
+// TestFireResult contains the rendered requests of a tested webhook
+type TestFireResult struct {
+	// Valid is false if the webhook config could not be loaded, or if one of its requests could not be rendered, validated or executed
+	Valid    bool                    `json:"valid"`
+	Error    string                  `json:"error,omitempty"`
+	Type     string                  `json:"type,omitempty"`
+	Requests []TestFireRequestResult `json:"requests"`
+}
+
+// TestFireRequestResult describes a rendered webhook request and, if it has been executed, its response
+type TestFireRequestResult struct {
+	Method  string   `json:"method,omitempty"`
+	URL     string   `json:"url,omitempty"`
+	Headers []Header `json:"headers,omitempty"`
+	Payload string   `json:"payload,omitempty"`
+	// Command is the curl command of requests that are executed using curl
+	Command  string                 `json:"command,omitempty"`
+	Executed bool                   `json:"executed"`
+	Response *HTTPResponse          `json:"response,omitempty"`
+	Outputs  map[string]interface{} `json:"outputs,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
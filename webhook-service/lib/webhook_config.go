@@ -3,6 +3,7 @@ package lib
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"gopkg.in/yaml.v3"
@@ -44,16 +45,51 @@ type Request struct {
 	Headers []Header `yaml:"headers,omitempty"`
 	Payload string   `yaml:"payload,omitempty"`
 	Options string   `yaml:"options,omitempty"`
+	// Timeout is the maximum duration of the request, e.g. '30s'
+	Timeout string `yaml:"timeout,omitempty"`
+	// MaxResponseSize is the maximum number of bytes read from the response body
+	MaxResponseSize int64           `yaml:"maxResponseSize,omitempty"`
+	TLS             *TLSConfig      `yaml:"tls,omitempty"`
+	Response        *ResponseConfig `yaml:"response,omitempty"`
+	Poll            *PollConfig     `yaml:"poll,omitempty"`
+	Retry           *RetryConfig    `yaml:"retry,omitempty"`
+}
+
+// TLSConfig contains the TLS options of a webhook request
+type TLSConfig struct {
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+	// CACert is a PEM encoded certificate used to verify the certificate of the webhook target
+	CACert string `yaml:"caCert,omitempty"`
+	// ClientCert and ClientKey are a PEM encoded key pair used for mutual TLS
+	ClientCert string `yaml:"clientCert,omitempty"`
+	ClientKey  string `yaml:"clientKey,omitempty"`
 }
 
 type Header struct {
-	Key   string `yaml:"key"`
-	Value string `yaml:"value"`
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
 }
 
 type WebHookSecretRef struct {
 	Key  string `yaml:"key"`
 	Name string `yaml:"name"`
+	// Scope is the secret-service scope the secret has been created in, defaults to keptn-webhook-service
+	Scope string `yaml:"scope,omitempty"`
+}
+
+// GetScope returns the scope the referenced secret has to belong to
+func (r WebHookSecretRef) GetScope() string {
+	if r.Scope == "" {
+		return DefaultSecretScope
+	}
+	return r.Scope
+}
+
+func (r WebHookSecretRef) String() string {
+	if r.Scope == "" {
+		return fmt.Sprintf("%s.%s", r.Name, r.Key)
+	}
+	return fmt.Sprintf("%s.%s (scope %s)", r.Name, r.Key, r.Scope)
 }
 
 const webhookConfInvalid = "Webhook configuration invalid: "
@@ -132,7 +168,24 @@ func verifyBeta1Request(request Request) error {
 			}
 		}
 	}
-	return nil
+	if request.Timeout != "" {
+		if timeout, err := time.ParseDuration(request.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf(webhookConfInvalid+"invalid webhook request timeout '%s'", request.Timeout)
+		}
+	}
+	if request.MaxResponseSize < 0 {
+		return fmt.Errorf(webhookConfInvalid + "webhook request maxResponseSize must not be negative")
+	}
+	if request.TLS != nil && (request.TLS.ClientCert == "") != (request.TLS.ClientKey == "") {
+		return fmt.Errorf(webhookConfInvalid + "webhook request TLS clientCert and clientKey must be set together")
+	}
+	if err := verifyResponseConfig(request); err != nil {
+		return err
+	}
+	if err := verifyRetryConfig(request); err != nil {
+		return err
+	}
+	return verifyPollConfig(request)
 }
 
 func isMethodSupported(method string) bool {
@@ -151,8 +204,19 @@ func (wh Webhook) ShouldSendStartedEvent() bool {
 	return *wh.SendStarted
 }
 
+// ShouldSendFinishedEvent determines whether the webhook service sends the .finished event of the task.
+// This is always the case if one of the requests polls the status of the job it has started
 func (wh Webhook) ShouldSendFinishedEvent() bool {
-	return wh.SendFinished
+	return wh.SendFinished || wh.hasPollingRequests()
+}
+
+func (wh Webhook) hasPollingRequests() bool {
+	for _, request := range wh.Requests {
+		if req, ok := request.(Request); ok && req.Poll != nil {
+			return true
+		}
+	}
+	return false
 }
 
 func ConvertToRequest(data interface{}) Request {
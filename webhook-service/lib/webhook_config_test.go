@@ -396,6 +396,227 @@ spec:
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "valid beta input with response section",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          timeout: 10s
+          maxResponseSize: 1024
+          tls:
+            insecureSkipVerify: true
+          response:
+            successCodes: [200, 201]
+            successCondition: '{{ eq .body.status "queued" }}'
+            outputs:
+              jobId: $.id`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									URL:             "http://localhost:8080",
+									Method:          "POST",
+									Timeout:         "10s",
+									MaxResponseSize: 1024,
+									TLS:             &TLSConfig{InsecureSkipVerify: true},
+									Response: &ResponseConfig{
+										SuccessCodes:     []int{200, 201},
+										SuccessCondition: `{{ eq .body.status "queued" }}`,
+										Outputs:          map[string]string{"jobId": "$.id"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid beta response output",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          response:
+            outputs:
+              responses: $.id`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid beta timeout",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          timeout: soon`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "beta poll without success condition",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          poll:
+            url: http://localhost:8080/status`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "beta poll with invalid interval",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          poll:
+            url: http://localhost:8080/status
+            interval: often
+            successCondition: "true"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "valid beta input with retry section",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          retry:
+            maxAttempts: 3
+            backoff: 2s
+            maxBackoff: 10s`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									URL:    "http://localhost:8080",
+									Method: "POST",
+									Retry:  &RetryConfig{MaxAttempts: 3, Backoff: "2s", MaxBackoff: "10s"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "beta retry with too many attempts",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          retry:
+            maxAttempts: 100`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "beta retry with options",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          options: --insecure
+          retry:
+            maxAttempts: 3`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "unsupported beta version",
 			args: args{
@@ -512,6 +733,16 @@ func TestWebhook_ShouldSendFinishedEvent(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "true if a request polls its status",
+			fields: fields{
+				Requests: []interface{}{
+					Request{URL: "http://localhost:8080", Method: "POST"},
+					Request{URL: "http://localhost:8080", Method: "POST", Poll: &PollConfig{URL: "http://localhost:8080/status", SuccessCondition: "true"}},
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
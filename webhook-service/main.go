@@ -1,9 +1,17 @@
 package main
 
 import (
+	"context"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/api"
 	"github.com/keptn/keptn/webhook-service/handler"
 	"github.com/keptn/keptn/webhook-service/lib"
 	log "github.com/sirupsen/logrus"
@@ -14,6 +22,20 @@ import (
 const eventTypeWildcard = "*"
 const serviceName = "webhook-service"
 const envVarLogLevel = "LOG_LEVEL"
+const envVarAPIPort = "API_PORT"
+const envVarExecutionHistorySize = "EXECUTION_HISTORY_SIZE"
+const defaultAPIPort = "8081"
+const envVarSecretBackend = "SECRET_BACKEND"
+const envVarSecretFilePath = "SECRET_FILE_PATH"
+const envVarSecretScopes = "SECRET_SCOPES"
+const secretBackendFile = "file"
+const defaultSecretFilePath = "/keptn/secrets"
+const envVarHostRateLimit = "HOST_RATE_LIMIT"
+const envVarHostRateLimitBurst = "HOST_RATE_LIMIT_BURST"
+const envVarCircuitBreakerThreshold = "CIRCUIT_BREAKER_THRESHOLD"
+const envVarCircuitBreakerOpenDuration = "CIRCUIT_BREAKER_OPEN_DURATION"
+const defaultCircuitBreakerThreshold = 5
+const defaultCircuitBreakerOpenDuration = 30 * time.Second
 
 func main() {
 	if os.Getenv(envVarLogLevel) != "" {
@@ -28,18 +50,35 @@ func main() {
 	if err != nil {
 		log.Fatalf("could not create kubernetes client: %s", err.Error())
 	}
-	secretReader := lib.NewK8sSecretReader(kubeAPI)
+	secretReader := lib.NewScopeRestrictedSecretReader(createSecretReader(kubeAPI), getSecretScopes())
 
+	// the rate limits and circuit breakers of the target hosts are shared by both executors
+	hostGuard := createHostGuard()
 	curlExecutor := lib.NewCmdCurlExecutor(
 		&lib.OSCmdExecutor{},
+		lib.WithCurlHostGuard(hostGuard),
 	)
 
 	ipResolver := lib.NewIPResolver()
 	denyListProvider := lib.NewDenyListProvider(kubeAPI)
 	requestValidator := lib.NewRequestValidator(denyListProvider, ipResolver)
-	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, curlExecutor, requestValidator, secretReader)
+	httpExecutor := lib.NewHTTPExecutor(requestValidator, lib.WithHostGuard(hostGuard))
+	// jobs whose status is polled are abandoned once the service is asked to shut down
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	executionStore := lib.NewConfigMapExecutionStore(kubeAPI, getExecutionHistorySize())
+	go executionStore.Run(ctx)
+	taskHandler := handler.NewTaskHandler(
+		&lib.TemplateEngine{},
+		curlExecutor,
+		requestValidator,
+		secretReader,
+		handler.WithHTTPExecutor(httpExecutor),
+		handler.WithExecutionStore(executionStore),
+		handler.WithContext(ctx),
+	)
 
-	log.Fatal(sdk.NewKeptn(
+	keptn := sdk.NewKeptn(
 		serviceName,
 		sdk.WithTaskHandler(
 			eventTypeWildcard,
@@ -47,7 +86,14 @@ func main() {
 		),
 		sdk.WithAutomaticResponse(false),
 		sdk.WithLogger(log.StandardLogger()),
-	).Start())
+	)
+
+	go startAPI(executionStore, taskHandler, keptn.GetResourceHandler())
+
+	err = keptn.Start()
+	// write the execution records that have been added since the last flush before the service exits
+	executionStore.Flush()
+	log.Fatal(err)
 }
 
 func createKubeAPI() (*kubernetes.Clientset, error) {
@@ -64,3 +110,75 @@ func createKubeAPI() (*kubernetes.Clientset, error) {
 	}
 	return kubeAPI, nil
 }
+
+// startAPI serves the execution history and the test endpoint on a separate port, since the port of the health endpoint is managed by the sdk
+func startAPI(executionStore lib.IExecutionStore, webhookTester api.WebhookTester, resourceHandler sdk.ResourceHandler) {
+	port := os.Getenv(envVarAPIPort)
+	if port == "" {
+		port = defaultAPIPort
+	}
+	router := api.NewRouter(
+		api.NewExecutionHandler(executionStore),
+		api.NewTestFireHandler(webhookTester, resourceHandler),
+	)
+	if err := http.ListenAndServe(":"+port, router); err != nil {
+		log.WithError(err).Error("could not start API")
+	}
+}
+
+func getExecutionHistorySize() int {
+	size, err := strconv.Atoi(os.Getenv(envVarExecutionHistorySize))
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// createSecretReader creates the reader for the secret backend configured by the SECRET_BACKEND env var.
+// Per default, secrets are read from Kubernetes secrets managed by Keptn's secret-service
+func createSecretReader(kubeAPI *kubernetes.Clientset) lib.ISecretReader {
+	if os.Getenv(envVarSecretBackend) == secretBackendFile {
+		path := os.Getenv(envVarSecretFilePath)
+		if path == "" {
+			path = defaultSecretFilePath
+		}
+		log.Infof("reading secrets from %s", path)
+		return lib.NewFileSecretReader(path)
+	}
+	return lib.NewK8sSecretReader(kubeAPI)
+}
+
+// getSecretScopes returns the scopes of the secrets that can be referenced by webhooks
+func getSecretScopes() []string {
+	scopes := []string{}
+	for _, scope := range strings.Split(os.Getenv(envVarSecretScopes), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return []string{lib.DefaultSecretScope}
+	}
+	return scopes
+}
+
+// createHostGuard creates the guard limiting the requests per target host. Rate limiting is disabled unless HOST_RATE_LIMIT is set
+func createHostGuard() *lib.HostGuard {
+	opts := []lib.HostGuardOption{}
+	if rateLimit, err := strconv.ParseFloat(os.Getenv(envVarHostRateLimit), 64); err == nil && rateLimit > 0 {
+		burst, err := strconv.Atoi(os.Getenv(envVarHostRateLimitBurst))
+		if err != nil {
+			burst = 1
+		}
+		opts = append(opts, lib.WithRateLimit(rateLimit, burst))
+	}
+	threshold, err := strconv.Atoi(os.Getenv(envVarCircuitBreakerThreshold))
+	if err != nil {
+		threshold = defaultCircuitBreakerThreshold
+	}
+	openDuration, err := time.ParseDuration(os.Getenv(envVarCircuitBreakerOpenDuration))
+	if err != nil || openDuration <= 0 {
+		openDuration = defaultCircuitBreakerOpenDuration
+	}
+	return lib.NewHostGuard(append(opts, lib.WithCircuitBreaker(threshold, openDuration))...)
+}